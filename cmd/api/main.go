@@ -3,16 +3,21 @@ package main
 import (
 	"context"
 	"os"
+	"os/signal"
 	"pokemon-cli/internal/auth"
 	"pokemon-cli/internal/battle"
 	"pokemon-cli/internal/cards"
 	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/matchmaking"
 	"pokemon-cli/internal/middleware"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/internal/seasons"
 	"pokemon-cli/internal/shop"
 	"pokemon-cli/internal/stats"
 	"pokemon-cli/pkg/config"
 	"pokemon-cli/pkg/logger"
+	"syscall"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -44,6 +49,14 @@ func main() {
 
 	appLogger.Info("Starting PokeTacTix API", "env", cfg.Server.Env, "port", cfg.Server.Port)
 
+	// Load the ruleset battles are played with
+	rules, err := ruleset.LoadFromEnv()
+	if err != nil {
+		appLogger.Error("Failed to load ruleset", "error", err)
+		os.Exit(1)
+	}
+	appLogger.Info("Ruleset loaded", "ruleset", rules.Name)
+
 	// Initialize database
 	if cfg.Database.URL != "" {
 		if err := database.InitDB(&cfg.Database); err != nil {
@@ -68,12 +81,16 @@ func main() {
 	cardsRepo := cards.NewRepository(database.GetDB())
 	shopRepo := shop.NewRepository(database.GetDB())
 	statsRepo := stats.NewRepository(database.GetDB())
+	matchmakingRepo := matchmaking.NewRepository(database.GetDB())
+	seasonsRepo := seasons.NewRepository(database.GetDB())
 
 	// Initialize services
 	authService := auth.NewService()
 	cardsService := cards.NewService(cardsRepo)
 	shopService := shop.NewService()
 	statsService := stats.NewService(statsRepo)
+	matchmakingService := matchmaking.NewService(matchmakingRepo, battle.NewRepository(database.GetDB()), appLogger)
+	seasonsService := seasons.NewService(seasonsRepo, appLogger)
 
 	// Initialize achievements in database
 	if cfg.Database.URL != "" {
@@ -91,6 +108,8 @@ func main() {
 	battleHandler := battle.NewHandler(database.GetDB(), statsService)
 	shopHandler := shop.NewHandler(shopService, shopRepo)
 	statsHandler := stats.NewHandler(statsService)
+	matchmakingHandler := matchmaking.NewHandler(matchmakingService)
+	seasonsHandler := seasons.NewHandler(seasonsService)
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -229,12 +248,44 @@ func main() {
 	battle.RegisterRoutes(app, battleHandler, authMiddleware)
 	shop.RegisterRoutes(app, shopHandler, authMiddleware)
 	stats.RegisterRoutes(app, statsHandler, authMiddleware)
+	matchmaking.RegisterRoutes(app, matchmakingHandler, authMiddleware)
+	seasons.RegisterRoutes(app, seasonsHandler, authMiddleware)
+
+	// Pair queued players, roll seasons over and push battle session changes
+	// in the background
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	if cfg.Database.URL != "" {
+		matchmakingService.Start()
+		appLogger.Info("Matchmaking started")
+		seasonsService.Start()
+		appLogger.Info("Season scheduler started")
+		go battleHandler.ListenSessionChanges(listenCtx, database.GetDB(), appLogger)
+	}
+
+	// Shut down cleanly on SIGINT/SIGTERM
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		appLogger.Info("Shutting down server")
+		if err := app.Shutdown(); err != nil {
+			appLogger.Error("Server shutdown failed", "error", err)
+		}
+	}()
 
 	// Start server
 	port := cfg.Server.Port
 	appLogger.Info("Server starting", "port", port)
 	if err := app.Listen(":" + port); err != nil {
+		matchmakingService.Stop()
+		seasonsService.Stop()
+		stopListening()
 		appLogger.Error("Server failed to start", "error", err)
 		os.Exit(1)
 	}
+
+	matchmakingService.Stop()
+	seasonsService.Stop()
+	stopListening()
+	appLogger.Info("Server stopped")
 }
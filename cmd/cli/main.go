@@ -12,6 +12,7 @@ import (
 	"pokemon-cli/internal/cli/setup"
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
+	"pokemon-cli/internal/ruleset"
 )
 
 var (
@@ -26,6 +27,10 @@ func main() {
 		displayVersion()
 		return
 	}
+	if _, err := ruleset.LoadFromEnv(); err != nil {
+		log.Fatalf("Failed to load ruleset: %v", err)
+	}
+
 	isFirst, err := setup.IsFirstLaunch()
 	if err != nil {
 		log.Fatalf("Error checking first launch: %v", err)
@@ -55,11 +60,32 @@ func main() {
 		}
 	}
 
+	// A command given on the command line (e.g. "poketactix battle --opponent-bot ./mybot")
+	// runs once instead of starting the interactive prompt
+	if len(os.Args) > 1 {
+		runCommand(gameState, os.Args[1], os.Args[2:])
+		return
+	}
+
 	displayPlayerInfo(gameState)
 
 	runCommandLoop(gameState)
 }
 
+func runCommand(state *storage.GameState, command string, args []string) {
+	scanner := bufio.NewScanner(os.Stdin)
+	cmdHandler := commands.NewCommandHandler(state, ui.NewRenderer(), scanner)
+
+	if err := cmdHandler.HandleCommand(command, args); err != nil && err.Error() != "QUIT" {
+		if ui.GetColorSupport() {
+			fmt.Printf("%s\n", ui.Colorize(fmt.Sprintf("Error: %v", err), ui.ColorRed))
+		} else {
+			fmt.Printf("Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
 func displayPlayerInfo(state *storage.GameState) {
 	fmt.Println(ui.RenderDivider(75, "═"))
 	fmt.Printf("Coins: %d | Pokemon: %d | Deck: %d\n",
@@ -0,0 +1,97 @@
+// Command sim plays AI-vs-AI battles without any UI and reports how they went,
+// for balance testing. See docs/simulator.md.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/internal/sim"
+	"pokemon-cli/pkg/rng"
+	"strings"
+	"time"
+)
+
+func main() {
+	cfg := sim.Config{}
+	var playerDeck, aiDeck, rulesetFile, format, output string
+
+	flag.IntVar(&cfg.Battles, "n", 1000, "number of battles to play")
+	flag.StringVar(&cfg.Mode, "mode", "5v5", "battle mode: 1v1 or 5v5")
+	flag.Int64Var(&cfg.Seed, "seed", rng.NewSeed(), "seed the whole run derives from (random if not given)")
+	flag.IntVar(&cfg.Workers, "workers", 0, "battles played in parallel (default one per CPU)")
+	flag.StringVar(&cfg.PlayerDifficulty, "player", "normal", "AI difficulty playing the player side")
+	flag.StringVar(&cfg.AIDifficulty, "ai", "normal", "AI difficulty playing the AI side")
+	flag.StringVar(&playerDeck, "player-deck", "", "comma-separated species for the player side (random deck per battle if empty)")
+	flag.StringVar(&aiDeck, "ai-deck", "", "comma-separated species for the AI side (random deck per battle if empty)")
+	flag.BoolVar(&cfg.Rules.SpeedOrder, "speed-order", false, "play with the speed order rule (faster Pokemon attacks first)")
+	flag.StringVar(&cfg.Rules.Damage, "damage", battle.DamageClassic, "damage model: classic or modern (accuracy, critical hits and STAB)")
+	flag.StringVar(&cfg.Rules.Field, "field", "", "weather or terrain every battle is played in, or random (none if empty)")
+	flag.StringVar(&rulesetFile, "ruleset", "", "JSON file of the ruleset to play with (the default one if empty)")
+	flag.IntVar(&cfg.MaxTurns, "max-turns", sim.DefaultMaxTurns, "turns after which a battle is called off")
+	flag.StringVar(&format, "format", "json", "report format: json or csv")
+	flag.StringVar(&output, "o", "", "file to write the report to (default stdout)")
+	flag.Parse()
+
+	if rulesetFile != "" {
+		rules, err := ruleset.Load(rulesetFile)
+		if err != nil {
+			fail(err)
+		}
+		ruleset.Use(rules)
+	}
+
+	cfg.PlayerDeck = splitDeck(playerDeck)
+	cfg.AIDeck = splitDeck(aiDeck)
+	if format != "json" && format != "csv" {
+		fail(fmt.Errorf("invalid format %q (must be json or csv)", format))
+	}
+
+	started := time.Now()
+	report, err := sim.Run(cfg)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Fprintf(os.Stderr, "Played %d battles in %s (seed %d)\n", report.Battles, time.Since(started).Round(time.Millisecond), report.Seed)
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if format == "csv" {
+		err = sim.WriteCSV(w, report)
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// splitDeck parses a comma-separated list of species
+func splitDeck(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
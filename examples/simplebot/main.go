@@ -0,0 +1,55 @@
+// Command simplebot is a minimal PokeTacTix bot: it always uses the strongest
+// attack it can afford, and passes to recover stamina when it can't afford any.
+// See docs/bot-protocol.md for the protocol.
+//
+//	go build -o simplebot ./examples/simplebot
+//	poketactix battle --opponent-bot ./simplebot
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"pokemon-cli/internal/bot"
+)
+
+func main() {
+	in := bufio.NewScanner(os.Stdin)
+	in.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var state bot.State
+	for in.Scan() {
+		command, args, _ := strings.Cut(in.Text(), " ")
+		switch command {
+		case "poketactix":
+			fmt.Println("name simplebot")
+			fmt.Println("ready")
+		case "state":
+			if err := json.Unmarshal([]byte(args), &state); err != nil {
+				fmt.Println("info bad state:", err)
+			}
+		case "go":
+			fmt.Println("move", chooseMove(state))
+		case "quit":
+			return
+		}
+	}
+}
+
+// chooseMove picks the strongest affordable attack, or passes
+func chooseMove(state bot.State) string {
+	card := state.You.Deck[state.You.Active]
+	best, bestPower := -1, 0
+	for i, move := range card.Moves {
+		if card.Stamina >= move.StaminaCost && move.Power > bestPower {
+			best, bestPower = i, move.Power
+		}
+	}
+	if best < 0 {
+		return "pass"
+	}
+	return fmt.Sprintf("attack %d", best)
+}
@@ -1,72 +1,141 @@
 package core
 
 import (
-	"math/rand"
 	"pokemon-cli/game/utils"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/pkg/rng"
+	"slices"
 	"strings"
 )
 
-// CalculateDamage calculates damage (probabilities shift with attack stat)
-func CalculateDamage(attacker, defender *pokemon.Card, defenderDefending bool, moveIdx int) int {
+// CalculateDamage calculates damage (probabilities shift with the attack stat
+// the move uses: special attack for special moves). The damage roll is drawn
+// from r so that seeded battles are reproducible.
+func CalculateDamage(r rng.Rand, attacker, defender *pokemon.Card, defenderDefending bool, moveIdx int) int {
 	move := attacker.Moves[moveIdx]
 	power := move.Power
-	attackStat := attacker.Attack
+	attackStat := attacker.AttackStat(move)
 
-	percent := rollDamagePercent(attackStat)
+	percent := rollDamagePercent(r, attackStat)
 	baseDmg := int(float64(power) * percent)
 
 	typeMultiplier := TypeMultiplier(move.Type, defender.Types, attacker.Name)
 	baseDmg = int(float64(baseDmg) * typeMultiplier)
 
 	if defenderDefending {
-		baseDmg = int(float64(baseDmg) * 0.25)
+		baseDmg = int(float64(baseDmg) * ruleset.Current().DefendDamageShare)
 	}
 	return baseDmg
 }
 
-// Roll damage percent based on attack stat
-func rollDamagePercent(attackStat int) float64 {
+// Multipliers of the modern damage rules
+const (
+	STABMultiplier     = 1.5 // Same-type attack bonus, for a move of one of the attacker's types
+	CriticalMultiplier = 1.5
+)
+
+// critChances is the chance of a critical hit, as one in n, at each of a
+// move's crit rate stages
+var critChances = []int{24, 8, 2, 1}
+
+// Hit is the outcome of an attack under the modern damage rules
+type Hit struct {
+	Damage   int
+	Missed   bool
+	Critical bool
+}
+
+// CalculateHit calculates an attack under the modern damage rules: on top of
+// CalculateDamage the move can miss, gets a same-type attack bonus and may land
+// a critical hit. Moves without an accuracy never miss.
+func CalculateHit(r rng.Rand, attacker, defender *pokemon.Card, defenderDefending bool, moveIdx int) Hit {
+	move := attacker.Moves[moveIdx]
+	if move.Accuracy > 0 && r.Intn(100) >= move.Accuracy {
+		return Hit{Missed: true}
+	}
+
+	damage := float64(CalculateDamage(r, attacker, defender, defenderDefending, moveIdx))
+	if slices.Contains(attacker.Types, move.Type) {
+		damage *= STABMultiplier
+	}
+	stage := min(max(move.CritRate, 0), len(critChances)-1)
+	critical := r.Intn(critChances[stage]) == 0
+	if critical {
+		damage *= CriticalMultiplier
+	}
+	return Hit{Damage: int(damage), Critical: critical}
+}
+
+// DamageRoll is one possible outcome of a damage roll: the share of a move's
+// power that lands and how likely it is
+type DamageRoll struct {
+	Percent float64
+	Prob    float64
+}
+
+// DamageRoller is a random source that decides damage rolls itself instead of
+// having them sampled. Search AIs pass one to CalculateDamage to walk every
+// outcome of DamageRolls in turn.
+type DamageRoller interface {
+	DamagePercent(attackStat int) float64
+}
+
+// DamageRolls returns the distribution damage rolls are drawn from for an
+// attacker with attackStat
+func DamageRolls(attackStat int) []DamageRoll {
 	// Three tables: low (<=30), high (70), super (>=120)
-	low := []struct{ pct, prob float64 }{
+	low := []DamageRoll{
 		{0.10, 0.07}, {0.20, 0.13}, {0.30, 0.35}, {0.40, 0.25}, {0.60, 0.10}, {0.80, 0.07}, {1.00, 0.03},
 	}
-	high := []struct{ pct, prob float64 }{
+	high := []DamageRoll{
 		{0.10, 0.01}, {0.20, 0.04}, {0.30, 0.10}, {0.40, 0.15}, {0.60, 0.25}, {0.80, 0.25}, {1.00, 0.20},
 	}
-	super := []struct{ pct, prob float64 }{
+	super := []DamageRoll{
 		{0.10, 0.00}, {0.20, 0.01}, {0.30, 0.04}, {0.40, 0.10}, {0.60, 0.15}, {0.80, 0.30}, {1.00, 0.40},
 	}
 
-	var table []struct{ pct, prob float64 }
 	if attackStat <= 30 {
-		table = low
-	} else if attackStat >= 120 {
-		table = super
-	} else if attackStat >= 70 {
+		return low
+	}
+	if attackStat >= 120 {
+		return super
+	}
+
+	var table []DamageRoll
+	if attackStat >= 70 {
 		// Interpolate between high and super
 		frac := float64(attackStat-70) / 50.0
-		table = make([]struct{ pct, prob float64 }, len(high))
+		table = make([]DamageRoll, len(high))
 		for i := range high {
-			table[i].pct = high[i].pct
-			table[i].prob = high[i].prob*(1-frac) + super[i].prob*frac
+			table[i].Percent = high[i].Percent
+			table[i].Prob = high[i].Prob*(1-frac) + super[i].Prob*frac
 		}
 	} else {
 		// Interpolate between low and high
 		frac := float64(attackStat-30) / 40.0
-		table = make([]struct{ pct, prob float64 }, len(low))
+		table = make([]DamageRoll, len(low))
 		for i := range low {
-			table[i].pct = low[i].pct
-			table[i].prob = low[i].prob*(1-frac) + high[i].prob*frac
+			table[i].Percent = low[i].Percent
+			table[i].Prob = low[i].Prob*(1-frac) + high[i].Prob*frac
 		}
 	}
+	return table
+}
+
+// Roll damage percent based on attack stat
+func rollDamagePercent(r rng.Rand, attackStat int) float64 {
+	if roller, ok := r.(DamageRoller); ok {
+		return roller.DamagePercent(attackStat)
+	}
+
 	// Roll
-	roll := rand.Float64()
+	roll := r.Float64()
 	cum := 0.0
-	for _, entry := range table {
-		cum += entry.prob
+	for _, entry := range DamageRolls(attackStat) {
+		cum += entry.Prob
 		if roll < cum {
-			return entry.pct
+			return entry.Percent
 		}
 	}
 	return 0.10 // fallback
@@ -88,9 +157,9 @@ func TypeMultiplier(moveType string, defenderTypes []string, attackerName string
 		}
 	}
 
-	// Apply legendary/mythical bonus (2x damage for their attacks)
+	// Apply legendary/mythical bonus (2x damage for their attacks by default)
 	if utils.IsLegendaryOrMythical(attackerName) {
-		multiplier *= 2.0
+		multiplier *= ruleset.Current().LegendaryMultiplier
 	}
 
 	return multiplier
@@ -7,6 +7,7 @@ import (
 	"pokemon-cli/game/models"
 	"pokemon-cli/game/utils"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/rng"
 	"strings"
 )
 
@@ -72,7 +73,7 @@ func StartTurnLoop(scanner *bufio.Scanner, state *models.GameState) {
 			}
 			// AI's move (with sacrifice as free action)
 			for {
-				aiMove, aiMoveIdx = GetAIMove(playerMove, aiCard, state, state.AIActiveIdx)
+				aiMove, aiMoveIdx = GetAIMove(rng.Global, playerMove, aiCard, state, state.AIActiveIdx)
 				if aiMove == "sacrifice" {
 					HandleSacrificeAI(aiCard, state)
 					continue // re-prompt AI for a real move
@@ -100,7 +101,7 @@ func StartTurnLoop(scanner *bufio.Scanner, state *models.GameState) {
 		} else {
 			// Even turns: AI chooses first, then player
 			for {
-				aiMove, aiMoveIdx = GetAIMove("", aiCard, state, state.AIActiveIdx)
+				aiMove, aiMoveIdx = GetAIMove(rng.Global, "", aiCard, state, state.AIActiveIdx)
 				if aiMove == "sacrifice" {
 					HandleSacrificeAI(aiCard, state)
 					continue // re-prompt AI for a real move
@@ -199,7 +200,7 @@ func ProcessTurnResult(playerMove, aiMove string, playerMoveIdx, aiMoveIdx int,
 		// AI does its move, player does nothing
 		switch aiMove {
 		case "attack":
-			aiDmg := CalculateDamage(aiCard, playerCard, false, aiMoveIdx)
+			aiDmg := CalculateDamage(rng.Global, aiCard, playerCard, false, aiMoveIdx)
 			playerCard.HP -= aiDmg
 			aiCard.Stamina -= aiCard.Moves[aiMoveIdx].StaminaCost
 			state.LastHpLost = aiDmg
@@ -217,7 +218,7 @@ func ProcessTurnResult(playerMove, aiMove string, playerMoveIdx, aiMoveIdx int,
 		// Player does their move, AI does nothing
 		switch playerMove {
 		case "attack":
-			playerDmg := CalculateDamage(playerCard, aiCard, false, playerMoveIdx)
+			playerDmg := CalculateDamage(rng.Global, playerCard, aiCard, false, playerMoveIdx)
 			aiCard.HP -= playerDmg
 			playerCard.Stamina -= playerCard.Moves[playerMoveIdx].StaminaCost
 			state.LastHpLost = 0
@@ -232,8 +233,8 @@ func ProcessTurnResult(playerMove, aiMove string, playerMoveIdx, aiMoveIdx int,
 		return
 	}
 	if playerMove == "attack" && aiMove == "attack" {
-		playerDmg := CalculateDamage(playerCard, aiCard, false, playerMoveIdx)
-		aiDmg := CalculateDamage(aiCard, playerCard, false, aiMoveIdx)
+		playerDmg := CalculateDamage(rng.Global, playerCard, aiCard, false, playerMoveIdx)
+		aiDmg := CalculateDamage(rng.Global, aiCard, playerCard, false, aiMoveIdx)
 		aiCard.HP -= playerDmg
 		playerCard.HP -= aiDmg
 		playerCard.Stamina -= playerCard.Moves[playerMoveIdx].StaminaCost
@@ -242,32 +243,34 @@ func ProcessTurnResult(playerMove, aiMove string, playerMoveIdx, aiMoveIdx int,
 		state.LastStaminaLost = playerCard.Moves[playerMoveIdx].StaminaCost
 		state.LastDamageDealt = playerDmg
 	} else if playerMove == "attack" && aiMove == "defend" {
-		playerDmg := CalculateDamage(playerCard, aiCard, true, playerMoveIdx)
+		playerDmg := CalculateDamage(rng.Global, playerCard, aiCard, true, playerMoveIdx)
 		aiCard.Stamina -= aiDefendCost
 		playerCard.Stamina -= playerCard.Moves[playerMoveIdx].StaminaCost
-		if playerDmg <= aiCard.Defense {
+		aiDefense := aiCard.DefenseStat(playerCard.Moves[playerMoveIdx])
+		if playerDmg <= aiDefense {
 			// AI blocks all damage
 			state.LastHpLost = 0
 			state.LastStaminaLost = playerCard.Moves[playerMoveIdx].StaminaCost
 			state.LastDamageDealt = 0
 		} else {
-			aiCard.HP -= (playerDmg - aiCard.Defense)
+			aiCard.HP -= (playerDmg - aiDefense)
 			state.LastHpLost = 0
 			state.LastStaminaLost = playerCard.Moves[playerMoveIdx].StaminaCost
-			state.LastDamageDealt = playerDmg - aiCard.Defense
+			state.LastDamageDealt = playerDmg - aiDefense
 		}
 	} else if playerMove == "defend" && aiMove == "attack" {
-		aiDmg := CalculateDamage(aiCard, playerCard, true, aiMoveIdx)
+		aiDmg := CalculateDamage(rng.Global, aiCard, playerCard, true, aiMoveIdx)
 		playerCard.Stamina -= playerDefendCost
 		aiCard.Stamina -= aiCard.Moves[aiMoveIdx].StaminaCost
-		if aiDmg <= playerCard.Defense {
+		playerDefense := playerCard.DefenseStat(aiCard.Moves[aiMoveIdx])
+		if aiDmg <= playerDefense {
 			// Player blocks all damage
 			state.LastHpLost = 0
 			state.LastStaminaLost = playerDefendCost
 			state.LastDamageDealt = 0
 		} else {
-			playerCard.HP -= (aiDmg - playerCard.Defense)
-			state.LastHpLost = aiDmg - playerCard.Defense
+			playerCard.HP -= (aiDmg - playerDefense)
+			state.LastHpLost = aiDmg - playerDefense
 			state.LastStaminaLost = playerDefendCost
 			state.LastDamageDealt = 0
 		}
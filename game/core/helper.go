@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"pokemon-cli/game/models"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
 )
 
 // Function to show battle result of 1v1 battle
@@ -43,9 +44,9 @@ func showRoundSummary(state *models.GameState, playerCard, aiCard *pokemon.Card)
 }
 
 // GetDefendCost calculates the stamina cost for defending based on the Pokemon's max HP
-// Formula: (HPMax + 1) / 2
+// Formula: HPMax / the ruleset's defend cost divisor, rounded up
 func GetDefendCost(hpMax int) int {
-	return (hpMax + 1) / 2
+	return ruleset.Current().DefendCost(hpMax)
 }
 
 // Helper function to reset battle state
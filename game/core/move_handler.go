@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"pokemon-cli/game/models"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
 	"strings"
 )
 
@@ -97,25 +98,18 @@ func getPlayerMove(scanner *bufio.Scanner, state *models.GameState, playerCard *
 			if state.SacrificeCount == nil {
 				state.SacrificeCount = make(map[int]int)
 			}
-			count := state.SacrificeCount[idx]
-			var hpCost int
-			switch count {
-			case 0:
-				hpCost = 10
-			case 1:
-				hpCost = 15
-			case 2:
-				hpCost = 20
-			default:
-				fmt.Println("You can only sacrifice three times per round.")
+			rules := ruleset.Current()
+			sacrifice, ok := rules.Sacrifice(state.SacrificeCount[idx])
+			if !ok {
+				fmt.Printf("You can only sacrifice %d times per round.\n", rules.MaxSacrifices())
 				continue
 			}
-			if float64(playerCard.Stamina) >= 0.5*float64(playerCard.Speed*2) {
-				fmt.Println("You can only use 'sacrifice' when your current stamina is less than 50% of max stamina.")
+			if !rules.CanSacrifice(playerCard.Stamina, playerCard.Speed*2) {
+				fmt.Printf("You can only use 'sacrifice' when your current stamina is less than %.0f%% of max stamina.\n", rules.SacrificeStaminaLimit*100)
 				continue
 			}
-			if playerCard.HP <= hpCost {
-				fmt.Printf("Not enough HP to sacrifice. You need at least %d HP.\n", hpCost+1)
+			if playerCard.HP <= sacrifice.HPCost {
+				fmt.Printf("Not enough HP to sacrifice. You need at least %d HP.\n", sacrifice.HPCost+1)
 				continue
 			}
 			state.JustSwitched = false
@@ -144,35 +138,24 @@ func HandleSacrifice(state *models.GameState, playerCard *pokemon.Card) {
 	}
 	count := state.SacrificeCount[idx]
 	maxStamina := playerCard.Speed * 2
-	if count >= 3 {
-		fmt.Println("You can only sacrifice three times per round.")
+	rules := ruleset.Current()
+	sacrifice, ok := rules.Sacrifice(count)
+	if !ok {
+		fmt.Printf("You can only sacrifice %d times per round.\n", rules.MaxSacrifices())
 		return
 	}
-	var hpCost int
-	var staminaGain float64
-	switch count {
-	case 0:
-		hpCost = 10
-		staminaGain = 0.5
-	case 1:
-		hpCost = 15
-		staminaGain = 0.25
-	case 2:
-		hpCost = 20
-		staminaGain = 0.15
-	}
-	if float64(playerCard.Stamina) >= 0.5*float64(maxStamina) {
-		fmt.Println("You can only use 'sacrifice' when your current stamina is less than 50% of max stamina.")
+	if !rules.CanSacrifice(playerCard.Stamina, maxStamina) {
+		fmt.Printf("You can only use 'sacrifice' when your current stamina is less than %.0f%% of max stamina.\n", rules.SacrificeStaminaLimit*100)
 		return
 	}
-	if playerCard.HP <= hpCost {
-		fmt.Printf("Not enough HP to sacrifice. You need at least %d HP.\n", hpCost+1)
+	if playerCard.HP <= sacrifice.HPCost {
+		fmt.Printf("Not enough HP to sacrifice. You need at least %d HP.\n", sacrifice.HPCost+1)
 		return
 	}
-	playerCard.HP -= hpCost
-	gain := int(float64(maxStamina) * staminaGain)
+	playerCard.HP -= sacrifice.HPCost
+	gain := int(float64(maxStamina) * sacrifice.StaminaGain)
 	playerCard.Stamina += gain
-	fmt.Printf("You sacrificed %d HP and gained %d stamina.\n", hpCost, gain)
+	fmt.Printf("You sacrificed %d HP and gained %d stamina.\n", sacrifice.HPCost, gain)
 	state.SacrificeCount[idx] = count + 1
 }
 
@@ -0,0 +1,58 @@
+package battle
+
+import (
+	"pokemon-cli/game/core"
+	"pokemon-cli/internal/pokemon"
+)
+
+// How abilities play out
+const (
+	pinchDivisor   = 3   // Pinch abilities kick in at 1/3 of max HP or below
+	pinchBoost     = 1.5 // and boost the moves of their type by 50%
+	reflectDivisor = 4   // Reflecting abilities hurt attackers for 1/4 of the damage they deal
+)
+
+// hasAbility reports whether card's ability does kind, returning the ability
+func hasAbility(card *pokemon.Card, kind string) (pokemon.Ability, bool) {
+	ability, ok := pokemon.GetAbility(card.Ability)
+	return ability, ok && ability.Kind == kind
+}
+
+// abilityMultiplier returns how much the abilities of the attacker and the
+// defender multiply the damage of a move of moveType: not at all against an
+// immune defender, and by pinchBoost for an attacker in a pinch
+func abilityMultiplier(attacker, defender *pokemon.Card, moveType string) float64 {
+	if ability, ok := hasAbility(defender, pokemon.AbilityImmune); ok && ability.Type == moveType {
+		return 0
+	}
+	if ability, ok := hasAbility(attacker, pokemon.AbilityPinch); ok && ability.Type == moveType && attacker.HP <= attacker.HPMax/pinchDivisor {
+		return pinchBoost
+	}
+	return 1
+}
+
+// defendCost returns the stamina a Pokemon with hpMax and ability pays to
+// defend, halved by guard abilities
+func defendCost(hpMax int, ability string) int {
+	cost := core.GetDefendCost(hpMax)
+	if a, ok := pokemon.GetAbility(ability); ok && a.Kind == pokemon.AbilityGuard {
+		cost = (cost + 1) / 2
+	}
+	return cost
+}
+
+// abilityReaction lets the ability of side's Pokemon react to a move of
+// attacker's that dealt it damage: immune abilities announce they took none,
+// reflecting ones hurt attacker. Both cards are the copies the turn is
+// resolved with.
+func abilityReaction(side string, holder, attacker *pokemon.Card, move pokemon.Move, damage int) []Event {
+	if ability, ok := hasAbility(holder, pokemon.AbilityImmune); ok && ability.Type == move.Type && damage == 0 {
+		return []Event{{Type: EventAbility, Side: side, Pokemon: holder.Name, Ability: ability.Name}}
+	}
+	if ability, ok := hasAbility(holder, pokemon.AbilityReflect); ok && damage > 0 && attacker.HP > 0 {
+		recoil := max(damage/reflectDivisor, 1)
+		attacker.HP -= recoil
+		return []Event{{Type: EventAbility, Side: side, Pokemon: holder.Name, Ability: ability.Name, Damage: recoil}}
+	}
+	return nil
+}
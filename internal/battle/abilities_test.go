@@ -0,0 +1,105 @@
+package battle
+
+import (
+	"testing"
+
+	"pokemon-cli/game/core"
+	"pokemon-cli/internal/pokemon"
+)
+
+func TestAbilityMultiplier(t *testing.T) {
+	tests := []struct {
+		name               string
+		attacker, defender pokemon.Card
+		moveType           string
+		want               float64
+	}{
+		{"immune defender", pokemon.Card{}, pokemon.Card{Ability: "levitate"}, "ground", 0},
+		{"immunity to another type", pokemon.Card{}, pokemon.Card{Ability: "levitate"}, "water", 1},
+		{"attacker in a pinch", pokemon.Card{Ability: "torrent", HP: 20, HPMax: 60}, pokemon.Card{}, "water", pinchBoost},
+		{"pinch for another type", pokemon.Card{Ability: "torrent", HP: 20, HPMax: 60}, pokemon.Card{}, "fire", 1},
+		{"attacker above 1/3 HP", pokemon.Card{Ability: "torrent", HP: 21, HPMax: 60}, pokemon.Card{}, "water", 1},
+		{"ability the game doesn't model", pokemon.Card{Ability: "static"}, pokemon.Card{Ability: "static"}, "electric", 1},
+	}
+	for _, tt := range tests {
+		if got := abilityMultiplier(&tt.attacker, &tt.defender, tt.moveType); got != tt.want {
+			t.Errorf("%s: multiplier %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestImmuneAbility(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	bs.AIDeck[0].Ability = "volt-absorb"
+	hp := bs.AIDeck[0].HP
+
+	events, err := ProcessMove(bs, "attack", intPtr(0))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if bs.AIDeck[0].HP != hp {
+		t.Errorf("thunderbolt took %d HP from a volt absorb Pokemon", hp-bs.AIDeck[0].HP)
+	}
+	if used := eventsOfType(events, EventAbility); len(used) != 1 || used[0].Side != "ai" || used[0].Ability != "volt-absorb" {
+		t.Errorf("ability events %+v, want one for the AI's volt absorb", used)
+	}
+
+	// Moves of other types still hit
+	if _, err := ProcessMove(bs, "attack", intPtr(1)); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if bs.AIDeck[0].HP == hp {
+		t.Error("quick attack didn't hurt a volt absorb Pokemon")
+	}
+}
+
+func TestGuardAbilityHalvesDefendCost(t *testing.T) {
+	cost := core.GetDefendCost(100)
+	if got := defendCost(100, "shell-armor"); got != (cost+1)/2 {
+		t.Errorf("defend cost with shell armor %d, want %d", got, (cost+1)/2)
+	}
+
+	bs := newSeededBattle(t, 3)
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	card := &bs.PlayerDeck[0]
+	card.Stamina = defendCost(card.HPMax, "sturdy")
+	if _, err := ProcessMove(bs, "defend", nil); err == nil {
+		t.Fatal("defended without the stamina for it")
+	}
+
+	card.Ability = "sturdy"
+	if _, err := ProcessMove(bs, "defend", nil); err != nil {
+		t.Fatalf("a sturdy Pokemon couldn't defend for half the stamina: %v", err)
+	}
+	if card.Stamina != 0 {
+		t.Errorf("stamina %d after defending, want 0", card.Stamina)
+	}
+}
+
+func TestReflectAbility(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	bs.AIDeck[0].Ability = "rough-skin"
+	bs.AIDeck[0].HP, bs.AIDeck[0].HPMax = 999, 999
+	hp := bs.PlayerDeck[0].HP
+
+	events, err := ProcessMove(bs, "attack", intPtr(0))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	damage := eventsOfType(events, EventDamageDealt)[0].Damage
+	recoil := eventsOfType(events, EventAbility)
+	if len(recoil) != 1 || recoil[0].Damage != damage/reflectDivisor {
+		t.Fatalf("ability events %+v, want rough skin hurting the attacker for %d", recoil, damage/reflectDivisor)
+	}
+	if lost := hp - bs.PlayerDeck[0].HP; lost != damage/reflectDivisor {
+		t.Errorf("attacker lost %d HP to rough skin, want %d", lost, damage/reflectDivisor)
+	}
+}
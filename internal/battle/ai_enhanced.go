@@ -1,10 +1,9 @@
 package battle
 
 import (
-	"math/rand"
-	"pokemon-cli/game/core"
 	"pokemon-cli/game/utils"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
 	"strings"
 )
 
@@ -39,25 +38,11 @@ func GetEnhancedAIMove(bs *BattleState, playerMove string) (string, int) {
 		}
 	}
 
-	defendCost := core.GetDefendCost(aCard.HPMax)
-	canDefend := aCard.Stamina >= defendCost
-
-	sacrificeCount := bs.SacrificeCount[bs.AIActiveIdx]
-	canSacrifice := false
-	var hpCost int
-	switch sacrificeCount {
-	case 0:
-		hpCost = 10
-	case 1:
-		hpCost = 15
-	case 2:
-		hpCost = 20
-	default:
-		hpCost = 9999
-	}
-	if float64(aCard.Stamina) < 0.5*float64(maxStamina) && aCard.HP > hpCost && sacrificeCount < 3 {
-		canSacrifice = true
-	}
+	canDefend := aCard.Stamina >= defendCost(aCard.HPMax, aCard.Ability)
+
+	rules := ruleset.Current()
+	sacrifice, ok := rules.Sacrifice(bs.AISacrificeCount[bs.AIActiveIdx])
+	canSacrifice := ok && rules.CanSacrifice(aCard.Stamina, maxStamina) && aCard.HP > sacrifice.HPCost
 
 	if !canAttack && !canDefend {
 		if canSacrifice {
@@ -66,7 +51,7 @@ func GetEnhancedAIMove(bs *BattleState, playerMove string) (string, int) {
 
 		if bs.Mode == "1v1" {
 			if hpPercent < 0.1 && aCard.Stamina == 0 {
-				if rand.Float64() < 0.3 { // 30% chance to surrender even in dire situation
+				if bs.Rand().Float64() < 0.3 { // 30% chance to surrender even in dire situation
 					return "surrender", 0
 				}
 			}
@@ -83,7 +68,7 @@ func GetEnhancedAIMove(bs *BattleState, playerMove string) (string, int) {
 			}
 
 			if hpPercent < 0.25 && hasStrongerPokemon && aCard.Stamina < maxStamina/4 {
-				if rand.Float64() < 0.4 { // 40% chance for strategic retreat
+				if bs.Rand().Float64() < 0.4 { // 40% chance for strategic retreat
 					return "surrender", 0
 				}
 			}
@@ -98,7 +83,12 @@ func GetEnhancedAIMove(bs *BattleState, playerMove string) (string, int) {
 	// Evaluate attack moves
 	if canAttack {
 		for _, moveIdx := range attackMoves {
-			score := evaluateAttackMove(&aCard, &pCard, moveIdx, playerMove, hpPercent)
+			score := 0.0
+			if move := aCard.Moves[moveIdx]; move.IsSupport() {
+				score = evaluateSupportMove(bs, aiCard, playerCard, move, playerMove)
+			} else {
+				score = evaluateAttackMove(&aCard, &pCard, moveIdx, playerMove, hpPercent, bs.Field)
+			}
 			decisions = append(decisions, EnhancedAIDecision{
 				Move:    "attack",
 				MoveIdx: moveIdx,
@@ -139,15 +129,16 @@ func GetEnhancedAIMove(bs *BattleState, playerMove string) (string, int) {
 }
 
 // evaluateAttackMove scores an attack move based on various factors
-func evaluateAttackMove(aiCard, playerCard *pokemon.Card, moveIdx int, playerMove string, hpPercent float64) float64 {
+func evaluateAttackMove(aiCard, playerCard *pokemon.Card, moveIdx int, playerMove string, hpPercent float64, field Field) float64 {
 	move := aiCard.Moves[moveIdx]
 	score := 0.0
 
 	// Base score from move power
 	score += float64(move.Power) / 100.0
 
-	// Type effectiveness (60% weight)
-	typeMultiplier := getTypeEffectiveness(move.Type, playerCard.Types)
+	// Type effectiveness (60% weight), boosted or weakened by the weather and
+	// terrain, boosted by the AI's held item and changed by both abilities
+	typeMultiplier := getTypeEffectiveness(move.Type, playerCard.Types) * field.multiplier(move.Type) * itemMultiplier(aiCard.HeldItem, move.Type) * abilityMultiplier(aiCard, playerCard, move.Type)
 	if typeMultiplier > 1.0 {
 		score += 0.6 * (typeMultiplier - 1.0) // Super effective bonus
 	} else if typeMultiplier < 1.0 {
@@ -178,6 +169,109 @@ func evaluateAttackMove(aiCard, playerCard *pokemon.Card, moveIdx int, playerMov
 	return score
 }
 
+// How much the AI values a stat stage it gains (or the player loses) through a
+// support move. Defense only counts when defending, and speed only under the
+// speed order rule.
+const (
+	attackStageValue     = 0.5
+	defenseStageValue    = 0.2
+	speedStageValue      = 0.35
+	speedStageValueNoUse = 0.05
+	setupStages          = 2 // Stages after which the AI stops setting up a stat
+)
+
+// How much the AI values weather and terrain: per move of its own a field
+// boosts by half (or of the player's it weakens by half), and for sandstorm or
+// hail hurting only the player
+const (
+	fieldMoveValue   = 0.5
+	weatherHurtValue = 0.3
+)
+
+// evaluateSupportMove scores a support move. Healing and stamina are worth more
+// the more of it is missing; stat stages, weather and terrain are worth more the
+// healthier the AI is, since it has more turns left to make use of them.
+func evaluateSupportMove(bs *BattleState, aiCard, playerCard *BattleCard, move pokemon.Move, playerMove string) float64 {
+	score := 0.0
+	hpPercent := float64(aiCard.HP) / float64(aiCard.HPMax)
+
+	if move.Healing > 0 {
+		score += 2.5 * min(float64(move.Healing)/100, 1-hpPercent)
+	}
+	if move.StaminaGain > 0 && aiCard.StaminaMax > 0 {
+		missing := 1 - float64(aiCard.Stamina)/float64(aiCard.StaminaMax)
+		score += 1.5 * min(float64(move.StaminaGain)/100, missing)
+	}
+
+	for _, change := range move.StatChanges {
+		// Work in stages gained by the AI: lowering a player stat counts as
+		// raising the AI's
+		gained, current := change.Stages, 0
+		if change.Target == pokemon.TargetOpponent {
+			gained, current = -change.Stages, -playerCard.Stages.of(change.Stat)
+		} else {
+			current = aiCard.Stages.of(change.Stat)
+		}
+		if gained > 0 && current >= setupStages {
+			continue
+		}
+
+		value := attackStageValue
+		switch change.Stat {
+		case pokemon.StatDefense, pokemon.StatSpDefense:
+			value = defenseStageValue
+		case pokemon.StatSpeed:
+			value = speedStageValueNoUse
+			if bs.Rules.SpeedOrder {
+				value = speedStageValue
+			}
+		}
+		score += value * float64(gained) * hpPercent
+	}
+
+	if move.Field != "" {
+		field := bs.Field
+		field.set(move.Field, fieldTurns)
+		score += (fieldAdvantage(field, aiCard, playerCard) - fieldAdvantage(bs.Field, aiCard, playerCard)) * hpPercent
+	}
+
+	// Setting up while being hit is risky; a free turn is the time for it
+	switch playerMove {
+	case "attack":
+		score -= 0.2
+	case "defend", "pass":
+		score += 0.2
+	}
+
+	return score
+}
+
+// fieldAdvantage scores how much field favors the AI over the player: how much
+// more it boosts the AI's attacks than the player's, and whether its weather
+// hurts only one of them
+func fieldAdvantage(field Field, aiCard, playerCard *BattleCard) float64 {
+	advantage := 0.0
+	for _, move := range aiCard.Moves {
+		if !move.IsSupport() {
+			advantage += field.multiplier(move.Type) - 1
+		}
+	}
+	for _, move := range playerCard.Moves {
+		if !move.IsSupport() {
+			advantage -= field.multiplier(move.Type) - 1
+		}
+	}
+	advantage *= 2 * fieldMoveValue
+
+	if field.hurts(playerCard.Types) {
+		advantage += weatherHurtValue
+	}
+	if field.hurts(aiCard.Types) {
+		advantage -= weatherHurtValue
+	}
+	return advantage
+}
+
 func evaluateDefend(aiCard, playerCard *pokemon.Card, playerMove string, hpPercent float64) float64 {
 	score := 0.0
 
@@ -0,0 +1,352 @@
+package battle
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"pokemon-cli/game/core"
+	"pokemon-cli/pkg/rng"
+	"time"
+)
+
+// ExpectimaxConfig bounds an expectimax search
+type ExpectimaxConfig struct {
+	MaxDepth   int           // Turns searched ahead at most
+	NodeBudget int           // Turns the search may simulate; 0 means unlimited
+	TimeBudget time.Duration // Wall-clock limit; 0 means none
+}
+
+// DefaultExpectimaxConfig is what the master difficulty searches with. It has
+// no time budget: how far a timed search gets depends on the machine, and the
+// AI's choices must be the same when a battle is replayed.
+var DefaultExpectimaxConfig = ExpectimaxConfig{MaxDepth: 3, NodeBudget: 10000}
+
+// ExpectimaxResult is the outcome of an expectimax search
+type ExpectimaxResult struct {
+	Move    string
+	MoveIdx int
+	Value   float64 // Expected evaluatePosition score of the chosen move
+	Depth   int     // Deepest search that finished within the budget; 0 if none did
+	Nodes   int     // Turns simulated
+}
+
+// The player's reply at a search node is uncertain. The move the heuristic AI
+// would make in their place gets this much of the probability and the other
+// plausible replies share the rest.
+const predictedReplyWeight = 0.6
+
+var errSearchBudget = errors.New("search budget exhausted")
+
+// GetExpectimaxAIMove picks the AI's move with DefaultExpectimaxConfig
+func GetExpectimaxAIMove(bs *BattleState) (string, int) {
+	result := SearchExpectimax(bs, DefaultExpectimaxConfig)
+	return result.Move, result.MoveIdx
+}
+
+// SearchExpectimax picks the AI's move by expectimax search. The AI maximizes
+// over its moves (optionally sacrificing first); the player's reply and every
+// damage roll are chance nodes, the rolls weighted by core.DamageRolls. The
+// player's move this turn is already known, later ones are guessed at.
+//
+// The search deepens one turn at a time until it reaches cfg.MaxDepth or runs
+// out of budget, and answers with the deepest search that finished. If not even
+// one turn fits in the budget it falls back to the heuristic AI.
+func SearchExpectimax(bs *BattleState, cfg ExpectimaxConfig) ExpectimaxResult {
+	plans := aiPlans(bs)
+	if len(plans) == 1 && plans[0].action.Move == "pass" {
+		// Nothing worth searching; let the heuristic AI decide between passing and giving up
+		move, moveIdx := GetEnhancedAIMove(bs, bs.PendingPlayerMove)
+		return ExpectimaxResult{Move: move, MoveIdx: moveIdx}
+	}
+
+	base := bs.Seed
+	if bs.RNG != nil {
+		base = int64(bs.RNG.State)
+	}
+
+	// Search from a copy without the timeline, which every simulated turn would otherwise copy
+	bs = bs.Clone()
+	bs.Turns = nil
+
+	s := &expectimaxSearch{cfg: cfg, fallback: rand.New(rng.NewSource(base))}
+	if cfg.TimeBudget > 0 {
+		s.deadline = time.Now().Add(cfg.TimeBudget)
+	}
+
+	result := ExpectimaxResult{}
+	for depth := 1; depth <= cfg.MaxDepth; depth++ {
+		best, value, err := s.root(bs, plans, depth)
+		if err != nil {
+			break
+		}
+		result.Move, result.MoveIdx = best.decision()
+		result.Value = value
+		result.Depth = depth
+	}
+	result.Nodes = s.nodes
+
+	if result.Depth == 0 {
+		result.Move, result.MoveIdx = GetEnhancedAIMove(bs, bs.PendingPlayerMove)
+	}
+	return result
+}
+
+// turnPlan is what a side does in one turn: optionally sacrifice, then act
+type turnPlan struct {
+	sacrifice bool
+	action    turnAction
+}
+
+// decision is the move processAIMove should make for the plan. A sacrifice is
+// made on its own; the AI is asked again afterwards.
+func (p turnPlan) decision() (string, int) {
+	if p.sacrifice {
+		return "sacrifice", 0
+	}
+	return p.action.Move, p.action.MoveIdx
+}
+
+// weightedPlan is a plausible player reply and the probability given to it
+type weightedPlan struct {
+	plan   turnPlan
+	weight float64
+}
+
+type expectimaxSearch struct {
+	cfg      ExpectimaxConfig
+	nodes    int
+	deadline time.Time
+	fallback rng.Rand // Serves rolls other than damage, which aren't enumerated
+}
+
+// tick counts a simulated turn and reports when the budget has run out
+func (s *expectimaxSearch) tick() error {
+	s.nodes++
+	if s.cfg.NodeBudget > 0 && s.nodes > s.cfg.NodeBudget {
+		return errSearchBudget
+	}
+	if !s.deadline.IsZero() && s.nodes%64 == 0 && time.Now().After(s.deadline) {
+		return errSearchBudget
+	}
+	return nil
+}
+
+// root searches depth turns ahead, knowing the player's move for this turn
+func (s *expectimaxSearch) root(bs *BattleState, plans []turnPlan, depth int) (turnPlan, float64, error) {
+	playerPlan := turnPlan{action: turnAction{Move: bs.PendingPlayerMove, MoveIdx: bs.PendingPlayerMoveIdx}}
+
+	best := plans[0]
+	bestValue := math.Inf(-1)
+	for _, plan := range plans {
+		value, err := s.chance(bs, playerPlan, plan, depth, false)
+		if err != nil {
+			return turnPlan{}, 0, err
+		}
+		if value > bestValue {
+			best = plan
+			bestValue = value
+		}
+	}
+	return best, bestValue, nil
+}
+
+// value is the expected score of bs with depth turns left to search
+func (s *expectimaxSearch) value(bs *BattleState, depth int) (float64, error) {
+	if bs.BattleOver || depth == 0 {
+		return evaluatePosition(bs), nil
+	}
+
+	replies := playerReplies(bs)
+	best := math.Inf(-1)
+	for _, plan := range aiPlans(bs) {
+		expected := 0.0
+		for _, reply := range replies {
+			value, err := s.chance(bs, reply.plan, plan, depth, true)
+			if err != nil {
+				return 0, err
+			}
+			expected += reply.weight * value
+		}
+		best = max(best, expected)
+	}
+	return best, nil
+}
+
+// chance plays the turn under every combination of damage rolls and returns
+// the probability-weighted value of the positions it leads to. Below the root
+// the rolls are coarsened to keep the tree small.
+func (s *expectimaxSearch) chance(bs *BattleState, playerPlan, aiPlan turnPlan, depth int, coarse bool) (float64, error) {
+	expected := 0.0
+	var choices []int
+	for {
+		if err := s.tick(); err != nil {
+			return 0, err
+		}
+
+		rolls := &rollScript{choices: choices, prob: 1, coarse: coarse, fallback: s.fallback}
+		sim := bs.Clone()
+		sim.roller = rolls
+		playTurn(sim, playerPlan, aiPlan)
+		sim.roller = nil
+
+		if rolls.prob > 0 {
+			value, err := s.value(sim, depth-1)
+			if err != nil {
+				return 0, err
+			}
+			expected += rolls.prob * value
+		}
+
+		if choices = rolls.next(); choices == nil {
+			return expected, nil
+		}
+	}
+}
+
+// playTurn applies both sides' plans to bs and resolves the turn with the real battle code
+func playTurn(bs *BattleState, playerPlan, aiPlan turnPlan) {
+	if playerPlan.sacrifice {
+		sacrifice(bs, "player")
+	}
+	if aiPlan.sacrifice {
+		sacrifice(bs, "ai")
+	}
+
+	bs.PendingPlayerMove = playerPlan.action.Move
+	bs.PendingPlayerMoveIdx = playerPlan.action.MoveIdx
+	bs.PendingAIMove = aiPlan.action.Move
+	bs.PendingAIMoveIdx = aiPlan.action.MoveIdx
+
+	resolveTurn(bs)
+	handleKnockouts(bs)
+	bs.CheckBattleEnd()
+}
+
+// aiPlans lists everything the AI could do this turn, including sacrificing
+// for stamina first when that's allowed
+func aiPlans(bs *BattleState) []turnPlan {
+	plans := sidePlans(bs, "ai")
+	return append(plans, turnPlan{action: turnAction{Move: "pass"}})
+}
+
+// sidePlans lists side's affordable actions, and the actions a sacrifice would make affordable
+func sidePlans(bs *BattleState, side string) []turnPlan {
+	plans := []turnPlan{}
+	for _, action := range affordableActions(bs.activeCard(side)) {
+		plans = append(plans, turnPlan{action: action})
+	}
+
+	sacrificed := bs.Clone()
+	if _, err := sacrifice(sacrificed, side); err == nil {
+		for _, action := range affordableActions(sacrificed.activeCard(side)) {
+			plans = append(plans, turnPlan{sacrifice: true, action: action})
+		}
+	}
+	return plans
+}
+
+// playerReplies returns the player's plausible moves: what the heuristic AI
+// would do in their place, their hardest-hitting attack and defending (or
+// passing when they can't afford to).
+func playerReplies(bs *BattleState) []weightedPlan {
+	predicted, predictedIdx := predictPlayerMove(bs)
+	plans := []turnPlan{{action: turnAction{Move: predicted, MoveIdx: predictedIdx}}}
+
+	candidates := []turnAction{{Move: "pass"}}
+	if card, target := bs.GetActivePlayerCard(), bs.GetActiveAICard(); card != nil && target != nil {
+		strongest, strongestPower := -1, 0.0
+		for _, action := range affordableActions(card) {
+			if action.Move == "defend" {
+				candidates[0] = action
+				continue
+			}
+			move := card.Moves[action.MoveIdx]
+			power := float64(move.Power) * core.TypeMultiplier(move.Type, target.Types, card.Name)
+			if strongest < 0 || power > strongestPower {
+				strongest, strongestPower = action.MoveIdx, power
+			}
+		}
+		if strongest >= 0 {
+			candidates = append(candidates, turnAction{Move: "attack", MoveIdx: strongest})
+		}
+	}
+	for _, action := range candidates {
+		if action != plans[0].action {
+			plans = append(plans, turnPlan{action: action})
+		}
+	}
+
+	if len(plans) == 1 {
+		return []weightedPlan{{plan: plans[0], weight: 1}}
+	}
+	replies := []weightedPlan{{plan: plans[0], weight: predictedReplyWeight}}
+	for _, plan := range plans[1:] {
+		replies = append(replies, weightedPlan{plan: plan, weight: (1 - predictedReplyWeight) / float64(len(plans)-1)})
+	}
+	return replies
+}
+
+// rollScript decides damage rolls for one path through a chance node. Calling
+// next after a turn has been played gives the choices for the following path,
+// so every combination of rolls is visited exactly once.
+type rollScript struct {
+	choices  []int // Outcome picked for each damage roll, in the order they're made
+	sizes    []int // Outcomes each roll had
+	calls    int
+	prob     float64 // Probability of the path so far
+	coarse   bool
+	fallback rng.Rand
+}
+
+// DamagePercent implements core.DamageRoller
+func (r *rollScript) DamagePercent(attackStat int) float64 {
+	rolls := core.DamageRolls(attackStat)
+	if r.coarse {
+		rolls = coarseRolls(rolls)
+	}
+
+	i := r.calls
+	r.calls++
+	if i == len(r.choices) {
+		r.choices = append(r.choices, 0)
+	}
+	r.sizes = append(r.sizes, len(rolls))
+
+	roll := rolls[r.choices[i]]
+	r.prob *= roll.Prob
+	return roll.Percent
+}
+
+func (r *rollScript) Float64() float64 { return r.fallback.Float64() }
+func (r *rollScript) Intn(n int) int   { return r.fallback.Intn(n) }
+
+// next returns the choices for the path after this one, or nil if this was the last
+func (r *rollScript) next() []int {
+	choices := append([]int(nil), r.choices[:r.calls]...)
+	for i := len(choices) - 1; i >= 0; i-- {
+		choices[i]++
+		if choices[i] < r.sizes[i] {
+			return choices[:i+1]
+		}
+	}
+	return nil
+}
+
+// coarseRolls merges a damage roll distribution into low, middle and high
+// outcomes, each worth its members' probability-weighted percent
+func coarseRolls(rolls []core.DamageRoll) []core.DamageRoll {
+	groups := [][2]int{{0, 3}, {3, 5}, {5, len(rolls)}}
+	merged := make([]core.DamageRoll, 0, len(groups))
+	for _, g := range groups {
+		roll := core.DamageRoll{}
+		for _, r := range rolls[min(g[0], len(rolls)):min(g[1], len(rolls))] {
+			roll.Percent += r.Percent * r.Prob
+			roll.Prob += r.Prob
+		}
+		if roll.Prob > 0 {
+			roll.Percent /= roll.Prob
+		}
+		merged = append(merged, roll)
+	}
+	return merged
+}
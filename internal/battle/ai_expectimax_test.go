@@ -0,0 +1,167 @@
+package battle
+
+import (
+	"math"
+	"testing"
+
+	"pokemon-cli/game/core"
+	"pokemon-cli/internal/pokemon"
+)
+
+func TestRollScriptVisitsEveryOutcome(t *testing.T) {
+	var choices []int
+	paths, total := 0, 0.0
+	for {
+		rolls := &rollScript{choices: choices, prob: 1}
+		rolls.DamagePercent(55)
+		rolls.DamagePercent(130)
+		paths++
+		total += rolls.prob
+		if choices = rolls.next(); choices == nil {
+			break
+		}
+	}
+
+	want := len(core.DamageRolls(55)) * len(core.DamageRolls(130))
+	if paths != want {
+		t.Errorf("visited %d paths, want %d", paths, want)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("path probabilities sum to %f, want 1", total)
+	}
+}
+
+func TestCoarseRollsKeepExpectedDamage(t *testing.T) {
+	for _, attack := range []int{20, 55, 90, 140} {
+		rolls := core.DamageRolls(attack)
+		want, got, prob := 0.0, 0.0, 0.0
+		for _, r := range rolls {
+			want += r.Percent * r.Prob
+		}
+		for _, r := range coarseRolls(rolls) {
+			got += r.Percent * r.Prob
+			prob += r.Prob
+		}
+		if math.Abs(got-want) > 1e-9 || math.Abs(prob-1) > 1e-9 {
+			t.Errorf("attack %d: coarse rolls expect %f (prob %f), want %f (prob 1)", attack, got, prob, want)
+		}
+	}
+}
+
+func TestExpectimaxTakesTheKnockout(t *testing.T) {
+	bs := newSeededBattle(t, 5)
+	// The player is nearly out and the AI's bite can't fail to finish them
+	bs.PlayerDeck[0].HP = 1
+	bs.PendingPlayerMove = "attack"
+	bs.PendingPlayerMoveIdx = 0
+
+	result := SearchExpectimax(bs, DefaultExpectimaxConfig)
+	if result.Move != "attack" {
+		t.Errorf("got %s, want an attack that knocks the player out", result.Move)
+	}
+	if result.Depth == 0 {
+		t.Error("search did not finish a single turn")
+	}
+}
+
+func TestExpectimaxIsDeterministic(t *testing.T) {
+	bs := newSeededBattle(t, 21)
+	bs.PendingPlayerMove = "defend"
+	bs.Rand()
+	before := *bs.RNG
+
+	first := SearchExpectimax(bs, DefaultExpectimaxConfig)
+	second := SearchExpectimax(bs, DefaultExpectimaxConfig)
+	if first != second {
+		t.Errorf("searches of the same state differ: %+v vs %+v", first, second)
+	}
+	if *bs.RNG != before {
+		t.Error("searching advanced the battle's random source")
+	}
+}
+
+func TestExpectimaxRespectsNodeBudget(t *testing.T) {
+	bs := newSeededBattle(t, 8)
+	bs.PendingPlayerMove = "attack"
+
+	result := SearchExpectimax(bs, ExpectimaxConfig{MaxDepth: 10, NodeBudget: 500})
+	// The turn that runs over is counted before the search gives up
+	if result.Nodes > 501 {
+		t.Errorf("simulated %d turns with a budget of 500", result.Nodes)
+	}
+	if result.Move == "" {
+		t.Error("no move chosen")
+	}
+}
+
+// benchmarkDecks returns two 5v5 decks of evenly matched Pokemon
+func benchmarkDecks() ([]pokemon.Card, []pokemon.Card) {
+	moves := func(t string) []pokemon.Move {
+		return []pokemon.Move{
+			{Name: t + "-blast", Power: 90, StaminaCost: 30, Type: t},
+			{Name: "tackle", Power: 40, StaminaCost: 13, Type: "normal"},
+		}
+	}
+	types := []string{"fire", "water", "grass", "electric", "rock"}
+
+	var player, ai []pokemon.Card
+	for i, typ := range types {
+		player = append(player, testCard(typ+"-p", 60+i*5, 60, 50, 60, []string{typ}, moves(typ)))
+		ai = append(ai, testCard(typ+"-a", 60+i*5, 60, 50, 60, []string{types[(i+2)%len(types)]}, moves(types[(i+2)%len(types)])))
+	}
+	return player, ai
+}
+
+// playHeuristicPlayer drives the player's side with the heuristic AI until the battle ends
+func playHeuristicPlayer(b *testing.B, bs *BattleState) {
+	b.Helper()
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		move, idx := predictPlayerMove(bs)
+		var moveIdx *int
+		if move == "attack" {
+			moveIdx = &idx
+		}
+		if _, err := ProcessMove(bs, move, moveIdx); err != nil {
+			b.Fatalf("ProcessMove failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkDifficultyVsHeuristic pits each difficulty against a player played
+// by the heuristic AI and reports how often the AI side wins. Run with
+// go test -bench Difficulty ./internal/battle
+func BenchmarkDifficultyVsHeuristic(b *testing.B) {
+	for _, difficulty := range Difficulties {
+		b.Run(difficulty, func(b *testing.B) {
+			wins := 0
+			for i := 0; i < b.N; i++ {
+				player, ai := benchmarkDecks()
+				bs, err := StartBattleWithSeed(1, "5v5", player, ai, int64(i))
+				if err != nil {
+					b.Fatalf("StartBattleWithSeed failed: %v", err)
+				}
+				if err := bs.SetDifficulty(difficulty); err != nil {
+					b.Fatalf("SetDifficulty failed: %v", err)
+				}
+				playHeuristicPlayer(b, bs)
+				if bs.Winner == "ai" {
+					wins++
+				}
+			}
+			b.ReportMetric(float64(wins)/float64(b.N), "ai-win-rate")
+		})
+	}
+}
+
+func BenchmarkSearchExpectimax(b *testing.B) {
+	player, ai := benchmarkDecks()
+	bs, err := StartBattleWithSeed(1, "5v5", player, ai, 1)
+	if err != nil {
+		b.Fatalf("StartBattleWithSeed failed: %v", err)
+	}
+	bs.PendingPlayerMove = "attack"
+
+	for i := 0; i < b.N; i++ {
+		SearchExpectimax(bs, DefaultExpectimaxConfig)
+	}
+}
@@ -0,0 +1,137 @@
+package battle
+
+import (
+	"pokemon-cli/pkg/rng"
+)
+
+// Damage rolls are random, so every simulated line is played this many times
+// with different rolls and the outcomes averaged
+const (
+	hardSamples   = 6
+	expertSamples = 4
+)
+
+// sampleSeedStep separates the seeds of successive samples
+const sampleSeedStep = 0xd1b54a32d192ed03
+
+// GetLookaheadAIMove simulates every action the AI can afford against the
+// player's chosen move and picks the one that leaves the AI best off. With a
+// depth of 2 it also plays the following turn, with the player replying as the
+// heuristic AI would and the AI answering with its best one-turn action.
+//
+// The simulations run on clones with their own random sources, so looking
+// ahead never advances the battle's RNG.
+func GetLookaheadAIMove(bs *BattleState, depth, samples int) (string, int) {
+	actions := aiActions(bs)
+	if len(actions) == 0 {
+		return GetEnhancedAIMove(bs, bs.PendingPlayerMove)
+	}
+	actions = append(actions, turnAction{Move: "pass"})
+
+	base := uint64(bs.Seed)
+	if bs.RNG != nil {
+		base = bs.RNG.State
+	}
+
+	best := actions[0]
+	bestScore := 0.0
+	for i, action := range actions {
+		total := 0.0
+		for k := range samples {
+			seed := int64(base + uint64(k+1)*sampleSeedStep)
+			sim := simulateTurn(bs, bs.PendingPlayerMove, bs.PendingPlayerMoveIdx, action, seed)
+			if depth > 1 && !sim.BattleOver {
+				total += bestReplyScore(sim, seed+1)
+			} else {
+				total += evaluatePosition(sim)
+			}
+		}
+
+		score := total / float64(samples)
+		if i == 0 || score > bestScore {
+			best = action
+			bestScore = score
+		}
+	}
+
+	return best.Move, best.MoveIdx
+}
+
+// bestReplyScore plays the turn after sim: the player moves as the heuristic AI
+// would in their place and the AI answers with whichever action scores best
+func bestReplyScore(sim *BattleState, seed int64) float64 {
+	playerMove, playerMoveIdx := predictPlayerMove(sim)
+
+	actions := append(aiActions(sim), turnAction{Move: "pass"})
+	best := 0.0
+	for i, action := range actions {
+		score := evaluatePosition(simulateTurn(sim, playerMove, playerMoveIdx, action, seed))
+		if i == 0 || score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// predictPlayerMove guesses the player's next move by letting the heuristic AI
+// choose for them from a mirrored copy of the battle
+func predictPlayerMove(bs *BattleState) (string, int) {
+	mirror := bs.Clone()
+	mirror.PlayerDeck, mirror.AIDeck = mirror.AIDeck, mirror.PlayerDeck
+	mirror.PlayerActiveIdx, mirror.AIActiveIdx = mirror.AIActiveIdx, mirror.PlayerActiveIdx
+
+	move, moveIdx := GetEnhancedAIMove(mirror, "")
+	if move != "attack" && move != "defend" {
+		// Sacrifices and surrenders aren't simulated; treat them as doing nothing
+		return "pass", 0
+	}
+	return move, moveIdx
+}
+
+// simulateTurn plays one turn on a copy of bs, with damage rolls drawn from seed
+func simulateTurn(bs *BattleState, playerMove string, playerMoveIdx int, action turnAction, seed int64) *BattleState {
+	sim := bs.Clone()
+	sim.RNG = rng.NewSource(seed)
+	sim.PendingPlayerMove = playerMove
+	sim.PendingPlayerMoveIdx = playerMoveIdx
+	sim.PendingAIMove = action.Move
+	sim.PendingAIMoveIdx = action.MoveIdx
+
+	resolveTurn(sim)
+	handleKnockouts(sim)
+	sim.CheckBattleEnd()
+	return sim
+}
+
+// evaluatePosition scores a battle from the AI's point of view. Decided battles
+// dominate; otherwise each side is worth its surviving Pokemon, their remaining
+// HP and, to a lesser degree, their stamina and stat stages.
+func evaluatePosition(bs *BattleState) float64 {
+	if bs.BattleOver {
+		switch bs.Winner {
+		case "ai":
+			return 100
+		case "player":
+			return -100
+		}
+		return 0
+	}
+	return deckStrength(bs.AIDeck) - deckStrength(bs.PlayerDeck)
+}
+
+// deckStrength sums what a side's Pokemon are worth to evaluatePosition
+func deckStrength(deck []BattleCard) float64 {
+	strength := 0.0
+	for _, card := range deck {
+		if card.HP <= 0 || card.HPMax <= 0 {
+			continue
+		}
+		strength += 1 + float64(card.HP)/float64(card.HPMax)
+		if card.StaminaMax > 0 {
+			strength += 0.25 * float64(card.Stamina) / float64(card.StaminaMax)
+		}
+		stages := card.Stages
+		strength += 0.05 * float64(stages.Attack+stages.Defense+stages.SpAttack+stages.SpDefense+stages.Speed)
+	}
+	return strength
+}
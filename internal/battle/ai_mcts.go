@@ -0,0 +1,240 @@
+package battle
+
+import (
+	"math"
+	"math/rand"
+	"pokemon-cli/pkg/rng"
+)
+
+// MCTSConfig tunes a Monte Carlo tree search
+type MCTSConfig struct {
+	Iterations  int     // Playouts run per decision
+	Horizon     int     // Turns a playout runs before the position is scored
+	Exploration float64 // UCB1 exploration constant
+}
+
+// DefaultMCTSConfig is what the AI searches with. The search is bounded by
+// iterations rather than time so the same battle always gets the same answer.
+var DefaultMCTSConfig = MCTSConfig{Iterations: 1500, Horizon: 10, Exploration: 1.4}
+
+// mctsAction is one AI decision. Switching and sacrificing don't end the AI's
+// turn; it decides again afterwards.
+type mctsAction struct {
+	Move string // attack, defend, pass, sacrifice or switch
+	Idx  int    // Move index for attack, deck index for switch
+}
+
+// ends reports whether the action commits the AI's move for the turn
+func (a mctsAction) ends() bool {
+	return a.Move != "sacrifice" && a.Move != "switch"
+}
+
+type mctsNode struct {
+	action   mctsAction
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []mctsAction
+	expanded bool
+	visits   int
+	total    float64 // Sum of playout rewards, from the AI's point of view
+}
+
+// GetMCTSAIMove picks the AI's move with DefaultMCTSConfig
+func GetMCTSAIMove(bs *BattleState) (string, int) {
+	return SearchMCTS(bs, DefaultMCTSConfig)
+}
+
+// SearchMCTS picks the AI's next decision by Monte Carlo tree search. Moves,
+// sacrifices and (in 5v5) switching to any healthy Pokemon on the bench form
+// one action space. Each iteration walks the tree on a clone of bs, then plays
+// random moves for both sides through the real turn resolution until the
+// horizon, and scores where the battle ended up.
+//
+// The player's move this turn is known; later player moves are sampled, so the
+// tree is open loop. Every random choice comes from a source seeded by the
+// battle's RNG state, which makes the search deterministic without advancing it.
+func SearchMCTS(bs *BattleState, cfg MCTSConfig) (string, int) {
+	switched := bs.aiSwitchedIn != nil
+	actions := mctsActions(bs, switched)
+	if len(actions) == 1 && actions[0].Move == "pass" {
+		// Nothing worth searching; let the heuristic AI decide between passing and giving up
+		return GetEnhancedAIMove(bs, bs.PendingPlayerMove)
+	}
+	if len(actions) == 1 {
+		return actions[0].Move, actions[0].Idx
+	}
+
+	base := bs.Seed
+	if bs.RNG != nil {
+		base = int64(bs.RNG.State)
+	}
+	r := rand.New(rng.NewSource(base))
+
+	// Search from a copy without the timeline, which every iteration would otherwise copy
+	start := bs.Clone()
+	start.Turns = nil
+
+	root := &mctsNode{untried: actions, expanded: true}
+	for range cfg.Iterations {
+		sim := start.Clone()
+		sim.RNG = rng.NewSource(r.Int63())
+		turnSwitched := switched
+
+		// Walk down the tree, adding one node
+		node := root
+		for !sim.BattleOver {
+			if !node.expanded {
+				node.untried = mctsActions(sim, turnSwitched)
+				node.expanded = true
+			}
+			if len(node.untried) > 0 {
+				i := r.Intn(len(node.untried))
+				action := node.untried[i]
+				node.untried = append(node.untried[:i], node.untried[i+1:]...)
+				child := &mctsNode{action: action, parent: node}
+				node.children = append(node.children, child)
+				node = child
+				turnSwitched = applyMCTSAction(sim, action, turnSwitched, r)
+				break
+			}
+			if len(node.children) == 0 {
+				break
+			}
+			node = node.bestChild(cfg.Exploration)
+			turnSwitched = applyMCTSAction(sim, node.action, turnSwitched, r)
+		}
+
+		reward := mctsPlayout(sim, cfg.Horizon, r)
+		for n := node; n != nil; n = n.parent {
+			n.visits++
+			n.total += reward
+		}
+	}
+
+	// The most visited action is the most robust choice
+	best := root.children[0]
+	for _, child := range root.children[1:] {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+	return best.action.Move, best.action.Idx
+}
+
+// bestChild picks the child with the highest UCB1 score
+func (n *mctsNode) bestChild(exploration float64) *mctsNode {
+	var best *mctsNode
+	bestScore := math.Inf(-1)
+	logVisits := math.Log(float64(n.visits))
+	for _, child := range n.children {
+		score := child.total/float64(child.visits) + exploration*math.Sqrt(logVisits/float64(child.visits))
+		if score > bestScore {
+			best = child
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// mctsActions lists the AI's options: affordable moves, passing, a sacrifice
+// when allowed and, unless it already switched this turn, every switch
+func mctsActions(bs *BattleState, switched bool) []mctsAction {
+	actions := []mctsAction{}
+	for _, action := range aiActions(bs) {
+		actions = append(actions, mctsAction{Move: action.Move, Idx: action.MoveIdx})
+	}
+	actions = append(actions, mctsAction{Move: "pass"})
+
+	if canSacrifice(bs, "ai") {
+		actions = append(actions, mctsAction{Move: "sacrifice"})
+	}
+
+	if bs.Mode == "5v5" && !switched {
+		for i, card := range bs.AIDeck {
+			if i != bs.AIActiveIdx && card.HP > 0 {
+				actions = append(actions, mctsAction{Move: "switch", Idx: i})
+			}
+		}
+	}
+	return actions
+}
+
+// canSacrifice reports whether side's active Pokemon is allowed to sacrifice
+func canSacrifice(bs *BattleState, side string) bool {
+	_, err := sacrifice(bs.Clone(), side)
+	return err == nil
+}
+
+// applyMCTSAction plays an AI decision on sim. A decision that ends the turn
+// resolves it and samples the player's move for the next one. It reports
+// whether the AI has switched during the turn in progress.
+func applyMCTSAction(sim *BattleState, action mctsAction, switched bool, r *rand.Rand) bool {
+	switch action.Move {
+	case "switch":
+		// The action was found on another path through the tree; it may not apply here
+		if switchPokemon(sim, "ai", action.Idx) == nil {
+			return true
+		}
+		return switched
+	case "sacrifice":
+		sacrifice(sim, "ai")
+		return switched
+	}
+
+	aiPlan := turnPlan{action: turnAction{Move: action.Move, MoveIdx: action.Idx}}
+	if card := sim.GetActiveAICard(); card == nil || validateMove(card, action.Move, &action.Idx) != nil {
+		aiPlan = turnPlan{action: turnAction{Move: "pass"}}
+	}
+	playerPlan := turnPlan{action: turnAction{Move: sim.PendingPlayerMove, MoveIdx: sim.PendingPlayerMoveIdx}}
+	playTurn(sim, playerPlan, aiPlan)
+
+	if !sim.BattleOver {
+		next := randomPlan(sim, "player", r)
+		if next.sacrifice {
+			sacrifice(sim, "player")
+		}
+		sim.PendingPlayerMove = next.action.Move
+		sim.PendingPlayerMoveIdx = next.action.MoveIdx
+	}
+	return false
+}
+
+// mctsPlayout plays random turns for both sides until the battle ends or the
+// horizon is reached, then scores the position between 0 (AI lost) and 1 (AI won)
+func mctsPlayout(sim *BattleState, horizon int, r *rand.Rand) float64 {
+	for turn := 0; turn < horizon && !sim.BattleOver; turn++ {
+		aiPlan := randomPlan(sim, "ai", r)
+		playerPlan := turnPlan{action: turnAction{Move: sim.PendingPlayerMove, MoveIdx: sim.PendingPlayerMoveIdx}}
+		if turn > 0 {
+			playerPlan = randomPlan(sim, "player", r)
+		}
+		playTurn(sim, playerPlan, aiPlan)
+	}
+
+	if sim.BattleOver {
+		switch sim.Winner {
+		case "ai":
+			return 1
+		case "player":
+			return 0
+		}
+		return 0.5
+	}
+	return 0.5 + 0.5*math.Tanh(evaluatePosition(sim)/2)
+}
+
+// randomPlan picks one of side's affordable actions at random, sacrificing
+// first when nothing is affordable and passing when even that isn't possible
+func randomPlan(bs *BattleState, side string, r *rand.Rand) turnPlan {
+	if actions := affordableActions(bs.activeCard(side)); len(actions) > 0 {
+		return turnPlan{action: actions[r.Intn(len(actions))]}
+	}
+
+	sacrificed := bs.Clone()
+	if _, err := sacrifice(sacrificed, side); err == nil {
+		if actions := affordableActions(sacrificed.activeCard(side)); len(actions) > 0 {
+			return turnPlan{sacrifice: true, action: actions[r.Intn(len(actions))]}
+		}
+	}
+	return turnPlan{action: turnAction{Move: "pass"}}
+}
@@ -0,0 +1,114 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newBenchmarkBattle(t *testing.T, seed int64) *BattleState {
+	t.Helper()
+	player, ai := benchmarkDecks()
+	bs, err := StartBattleWithSeed(1, "5v5", player, ai, seed)
+	if err != nil {
+		t.Fatalf("StartBattleWithSeed failed: %v", err)
+	}
+	return bs
+}
+
+func TestMCTSIsDeterministic(t *testing.T) {
+	bs := newBenchmarkBattle(t, 17)
+	bs.PendingPlayerMove = "attack"
+	bs.Rand()
+	before := *bs.RNG
+
+	cfg := MCTSConfig{Iterations: 300, Horizon: 8, Exploration: 1.4}
+	move, idx := SearchMCTS(bs, cfg)
+	for range 3 {
+		if m, i := SearchMCTS(bs, cfg); m != move || i != idx {
+			t.Fatalf("searches of the same state differ: %s/%d vs %s/%d", move, idx, m, i)
+		}
+	}
+	if *bs.RNG != before {
+		t.Error("searching advanced the battle's random source")
+	}
+}
+
+func TestMCTSSwitchesOutOfHopelessPosition(t *testing.T) {
+	bs := newBenchmarkBattle(t, 4)
+	// The active AI Pokemon is about to fall and can't afford to do anything
+	bs.AIDeck[0].HP = 1
+	bs.AIDeck[0].Stamina = 0
+	bs.AISacrificeCount[0] = 3
+	bs.PendingPlayerMove = "attack"
+	bs.PendingPlayerMoveIdx = 1
+
+	move, idx := SearchMCTS(bs, DefaultMCTSConfig)
+	if move != "switch" {
+		t.Fatalf("got %s, want a switch", move)
+	}
+	if idx == bs.AIActiveIdx || bs.AIDeck[idx].HP <= 0 {
+		t.Errorf("switched to invalid Pokemon %d", idx)
+	}
+}
+
+func TestAISwitchIsRecordedInTurn(t *testing.T) {
+	bs := newBenchmarkBattle(t, 4)
+	if err := bs.SetDifficulty(DifficultyChampion); err != nil {
+		t.Fatalf("SetDifficulty failed: %v", err)
+	}
+	bs.AIDeck[0].HP = 1
+	bs.AIDeck[0].Stamina = 0
+	bs.AISacrificeCount[0] = 3
+
+	events, err := ProcessMove(bs, "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	var switchedTo string
+	for _, e := range events {
+		if e.Type == EventSwitched && e.Side == "ai" {
+			switchedTo = e.Pokemon
+		}
+	}
+	if switchedTo == "" {
+		t.Fatal("AI did not switch")
+	}
+	if got := bs.Turns[0].AIPokemon; got != switchedTo {
+		t.Errorf("turn record follows %q, want %q", got, switchedTo)
+	}
+	if bs.aiSwitchedIn != nil {
+		t.Error("switch was not cleared at the end of the turn")
+	}
+}
+
+func TestChampionBattleReplays(t *testing.T) {
+	bs := newBenchmarkBattle(t, 12)
+	if err := bs.SetDifficulty(DifficultyChampion); err != nil {
+		t.Fatalf("SetDifficulty failed: %v", err)
+	}
+
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		move, idx := "pass", (*int)(nil)
+		if actions := affordableActions(bs.GetActivePlayerCard()); len(actions) > 0 {
+			move = actions[0].Move
+			if move == "attack" {
+				idx = intPtr(actions[0].MoveIdx)
+			}
+		}
+		if _, err := ProcessMove(bs, move, idx); err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+	}
+	if !bs.BattleOver {
+		t.Fatal("battle did not finish")
+	}
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	if last := frames[len(frames)-1].State; !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+		t.Error("replay does not reproduce the battle")
+	}
+}
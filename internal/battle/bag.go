@@ -0,0 +1,100 @@
+package battle
+
+import (
+	"fmt"
+	"maps"
+	"pokemon-cli/internal/pokemon"
+)
+
+// NoItems is the Rules.ItemLimit of battles played without items from the bag
+const NoItems = -1
+
+// defaultItemLimits are how many items from their bag the player may use in a
+// battle of each mode when its rules don't say
+var defaultItemLimits = map[string]int{
+	"1v1": 2,
+	"5v5": 3,
+}
+
+// ItemLimit returns how many items from their bag the player may use in the battle
+func (bs *BattleState) ItemLimit() int {
+	switch {
+	case bs.Rules.ItemLimit == NoItems:
+		return 0
+	case bs.Rules.ItemLimit > 0:
+		return bs.Rules.ItemLimit
+	}
+	return defaultItemLimits[bs.Mode]
+}
+
+// SetBag gives the player the battle items they bring to a battle that hasn't
+// started playing yet, by name
+func (bs *BattleState) SetBag(bag map[string]int) error {
+	if len(bs.Turns) > 0 {
+		return fmt.Errorf("the bag can't change once the battle has started")
+	}
+	for name := range bag {
+		if _, _, ok := pokemon.GetBattleItem(name); !ok {
+			return fmt.Errorf("unknown battle item %q", name)
+		}
+	}
+
+	bs.Bag = maps.Clone(bag)
+	if bs.Replay != nil {
+		bs.Replay.Bag = maps.Clone(bag)
+	}
+	return nil
+}
+
+// checkBagItem checks that the player can use the battle item at itemIdx of
+// pokemon.BattleItems on card this turn
+func checkBagItem(bs *BattleState, card *BattleCard, itemIdx *int) error {
+	if itemIdx == nil {
+		return fmt.Errorf("item index required for item")
+	}
+	if *itemIdx < 0 || *itemIdx >= len(pokemon.BattleItems) {
+		return fmt.Errorf("invalid item index")
+	}
+	item := pokemon.BattleItems[*itemIdx]
+	if bs.Bag[item.Name] <= 0 {
+		return fmt.Errorf("no %s left in your bag", pokemon.HeldItemName(item.Name))
+	}
+	if bs.ItemsUsed >= bs.ItemLimit() {
+		return fmt.Errorf("no more items can be used this battle (limit %d)", bs.ItemLimit())
+	}
+
+	healing := item.Healing > 0 && card.HP < card.HPMax
+	stamina := item.StaminaGain > 0 && card.Stamina < card.StaminaMax
+	cure := item.Cures && card.Status != ""
+	if !healing && !stamina && !cure {
+		return fmt.Errorf("%s would have no effect on %s", pokemon.HeldItemName(item.Name), card.Name)
+	}
+	return nil
+}
+
+// useBagItem takes the battle item at itemIdx of pokemon.BattleItems out of
+// the player's bag and uses it on card. checkBagItem must allow it.
+func useBagItem(bs *BattleState, card *BattleCard, itemIdx int) Event {
+	item := pokemon.BattleItems[itemIdx]
+	bs.Bag[item.Name]--
+	if bs.Bag[item.Name] == 0 {
+		delete(bs.Bag, item.Name)
+	}
+	bs.ItemsUsed++
+
+	used := Event{Type: EventBagItem, Side: "player", Pokemon: card.Name, Item: item.Name}
+	if item.Healing > 0 {
+		used.HPGained = min(max(card.HPMax*item.Healing/100, 1), card.HPMax-card.HP)
+		card.HP += used.HPGained
+	}
+	if item.StaminaGain > 0 {
+		used.StaminaGained = min(max(card.StaminaMax*item.StaminaGain/100, 1), card.StaminaMax-card.Stamina)
+		card.Stamina += used.StaminaGained
+	}
+	if item.Cures && card.Status != "" {
+		used.Status = card.Status
+		card.Status = ""
+		card.StatusTurns = 0
+	}
+	return used
+}
@@ -0,0 +1,176 @@
+package battle
+
+import (
+	"testing"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+// bagBattle returns a battle against a passing AI where the player brings bag
+// and their Pokemon is down to half its HP
+func bagBattle(t *testing.T, bag map[string]int) *BattleState {
+	t.Helper()
+	bs := newSeededBattle(t, 11)
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	if err := bs.SetBag(bag); err != nil {
+		t.Fatalf("SetBag failed: %v", err)
+	}
+	bs.PlayerDeck[0].HP = bs.PlayerDeck[0].HPMax / 2
+	return bs
+}
+
+// battleItemIdx returns the index of the battle item called name
+func battleItemIdx(t *testing.T, name string) *int {
+	t.Helper()
+	_, idx, ok := pokemon.GetBattleItem(name)
+	if !ok {
+		t.Fatalf("no battle item %q", name)
+	}
+	return &idx
+}
+
+func TestPotionHealsAndTakesTheTurn(t *testing.T) {
+	bs := bagBattle(t, map[string]int{"potion": 2})
+	card := &bs.PlayerDeck[0]
+	hp, aiHP := card.HP, bs.AIDeck[0].HP
+
+	events, err := ProcessMove(bs, "item", battleItemIdx(t, "potion"))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	if want := hp + card.HPMax*30/100; card.HP != want {
+		t.Errorf("HP %d after a potion, want %d", card.HP, want)
+	}
+	if used := eventsOfType(events, EventBagItem); len(used) != 1 || used[0].HPGained != card.HP-hp {
+		t.Errorf("bag_item events %+v, want one for the HP restored", used)
+	}
+	if bs.Bag["potion"] != 1 || bs.ItemsUsed != 1 {
+		t.Errorf("bag %v and %d items used after a potion, want 1 potion left and 1 used", bs.Bag, bs.ItemsUsed)
+	}
+	if bs.AIDeck[0].HP != aiHP || len(eventsOfType(events, EventDamageDealt)) != 0 {
+		t.Error("the player attacked on the turn they used an item")
+	}
+	if bs.ConsecutivePasses != 0 || len(eventsOfType(events, EventPassed)) != 0 {
+		t.Error("using an item counted towards a stalemate")
+	}
+}
+
+func TestFullHealCuresStatus(t *testing.T) {
+	bs := bagBattle(t, map[string]int{"full-heal": 1})
+	card := &bs.PlayerDeck[0]
+	card.Status = pokemon.StatusSleep
+	card.StatusTurns = 3
+
+	events, err := ProcessMove(bs, "item", battleItemIdx(t, "full-heal"))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if card.Status != "" || card.StatusTurns != 0 {
+		t.Errorf("status %q (%d turns) after a full heal, want none", card.Status, card.StatusTurns)
+	}
+	if used := eventsOfType(events, EventBagItem); len(used) != 1 || used[0].Status != pokemon.StatusSleep {
+		t.Errorf("bag_item events %+v, want one curing sleep", used)
+	}
+	if _, ok := bs.Bag["full-heal"]; ok {
+		t.Error("the last full heal stayed in the bag")
+	}
+}
+
+func TestBagItemErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		bag   map[string]int
+		rules Rules
+		setup func(bs *BattleState)
+		item  *int
+	}{
+		{name: "no index", bag: map[string]int{"potion": 1}},
+		{name: "bad index", bag: map[string]int{"potion": 1}, item: intPtr(len(pokemon.BattleItems))},
+		{name: "not in bag", bag: map[string]int{"potion": 1}, item: intPtr(1)},
+		{name: "no items rule", bag: map[string]int{"potion": 1}, rules: Rules{ItemLimit: NoItems}, item: intPtr(0)},
+		{name: "no effect", bag: map[string]int{"full-heal": 1}, item: intPtr(3)},
+		{name: "full HP", bag: map[string]int{"potion": 1}, item: intPtr(0), setup: func(bs *BattleState) {
+			bs.PlayerDeck[0].HP = bs.PlayerDeck[0].HPMax
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs := bagBattle(t, tt.bag)
+			bs.Rules = tt.rules
+			if tt.setup != nil {
+				tt.setup(bs)
+			}
+			if _, err := ProcessMove(bs, "item", tt.item); err == nil {
+				t.Error("expected an error")
+			}
+			if bs.ItemsUsed != 0 || bs.TurnNumber != 1 {
+				t.Error("a refused item was used")
+			}
+		})
+	}
+}
+
+func TestItemLimit(t *testing.T) {
+	potion := battleItemIdx(t, "potion")
+	for _, tc := range []struct {
+		limit int
+		want  int
+	}{
+		{0, defaultItemLimits["1v1"]},
+		{1, 1},
+		{NoItems, 0},
+	} {
+		bs := bagBattle(t, map[string]int{"potion": 5})
+		bs.Rules.ItemLimit = tc.limit
+		bs.PlayerDeck[0].HP, bs.PlayerDeck[0].HPMax = 1, 999
+
+		used := 0
+		for range 5 {
+			if _, err := ProcessMove(bs, "item", potion); err != nil {
+				break
+			}
+			used++
+		}
+		if used != tc.want {
+			t.Errorf("item limit %d: used %d potions, want %d", tc.limit, used, tc.want)
+		}
+	}
+}
+
+func TestSetBagOnlyBeforeTheBattle(t *testing.T) {
+	bs := newSeededBattle(t, 11)
+	if err := bs.SetBag(map[string]int{"leftovers": 1}); err == nil {
+		t.Error("SetBag accepted a held item")
+	}
+	if _, err := ProcessMove(bs, "pass", nil); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if err := bs.SetBag(map[string]int{"potion": 1}); err == nil {
+		t.Error("SetBag changed the bag mid-battle")
+	}
+}
+
+func TestReplayReproducesItems(t *testing.T) {
+	bs := newSeededBattle(t, 11)
+	if err := bs.SetBag(map[string]int{"stamina-drink": 1}); err != nil {
+		t.Fatalf("SetBag failed: %v", err)
+	}
+	if _, err := ProcessMove(bs, "attack", intPtr(1)); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if _, err := ProcessMove(bs, "item", battleItemIdx(t, "stamina-drink")); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	if last := frames[len(frames)-1].State; last.ItemsUsed != 1 || last.PlayerDeck[0].Stamina != bs.PlayerDeck[0].Stamina {
+		t.Error("the replay didn't reproduce the stamina drink")
+	}
+}
@@ -2,8 +2,9 @@ package battle
 
 import (
 	"fmt"
-	"pokemon-cli/game/core"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/pkg/rng"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,97 +12,120 @@ import (
 
 // StartBattle initializes a new battle with the given mode and decks
 func StartBattle(userID int, mode string, playerDeck []pokemon.Card, aiDeck []pokemon.Card) (*BattleState, error) {
+	return StartBattleWithSeed(userID, mode, playerDeck, aiDeck, rng.NewSeed())
+}
+
+// StartBattleWithSeed initializes a new battle whose random rolls are drawn from seed.
+// The same seed, decks and player inputs always produce the same battle.
+func StartBattleWithSeed(userID int, mode string, playerDeck []pokemon.Card, aiDeck []pokemon.Card, seed int64) (*BattleState, error) {
+	if err := validateDeckSize(mode, len(playerDeck)); err != nil {
+		return nil, err
+	}
+	if err := validateDeckSize(mode, len(aiDeck)); err != nil {
+		return nil, err
+	}
+
+	// Convert decks to BattleCards
+	playerBattleDeck := toBattleDeck(playerDeck)
+	aiBattleDeck := toBattleDeck(aiDeck)
+
+	now := time.Now()
+	battleState := &BattleState{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		Mode:             mode,
+		PlayerDeck:       playerBattleDeck,
+		AIDeck:           aiBattleDeck,
+		PlayerActiveIdx:  0,
+		AIActiveIdx:      0,
+		TurnNumber:       1,
+		RoundNumber:      1,
+		WhoseTurn:        "player", // Player always goes first in turn 1
+		BattleOver:       false,
+		Winner:           "",
+		SacrificeCount:   make(map[int]int),
+		AISacrificeCount: make(map[int]int),
+		Seed:             seed,
+		RNG:              rng.NewSource(seed),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	battleState.Replay = newReplay(battleState)
+
+	return battleState, nil
+}
+
+// validateDeckSize checks that a side brings the right number of Pokemon for mode
+func validateDeckSize(mode string, size int) error {
 	if mode != "1v1" && mode != "5v5" {
-		return nil, fmt.Errorf("invalid battle mode: %s", mode)
+		return fmt.Errorf("invalid battle mode: %s", mode)
 	}
 
-	if mode == "1v1" && (len(playerDeck) < 1 || len(aiDeck) < 1) {
-		return nil, fmt.Errorf("1v1 mode requires at least 1 Pokemon per side")
+	if mode == "1v1" && size < 1 {
+		return fmt.Errorf("1v1 mode requires at least 1 Pokemon per side")
 	}
 
-	if mode == "5v5" && (len(playerDeck) != 5 || len(aiDeck) != 5) {
-		return nil, fmt.Errorf("5v5 mode requires exactly 5 Pokemon per side")
+	if mode == "5v5" && size != 5 {
+		return fmt.Errorf("5v5 mode requires exactly 5 Pokemon per side")
 	}
 
-	// Convert decks to BattleCards
-	playerBattleDeck := make([]BattleCard, len(playerDeck))
-	for i, card := range playerDeck {
-		playerBattleDeck[i] = ConvertToBattleCard(card, i+1)
+	return nil
+}
+
+// toBattleDeck converts a deck of cards to BattleCards
+func toBattleDeck(deck []pokemon.Card) []BattleCard {
+	battleDeck := make([]BattleCard, len(deck))
+	for i, card := range deck {
+		battleDeck[i] = ConvertToBattleCard(card, i+1)
 	}
+	return battleDeck
+}
 
-	aiBattleDeck := make([]BattleCard, len(aiDeck))
-	for i, card := range aiDeck {
-		aiBattleDeck[i] = ConvertToBattleCard(card, i+1)
+// ProcessMove processes a player's move in the battle, returning the events it caused.
+// The move is added to the battle's timeline and replay.
+func ProcessMove(bs *BattleState, move string, moveIdx *int) ([]Event, error) {
+	turn := bs.TurnNumber
+	rec, snap := beginTurnRecord(bs, move)
+	events, err := processMove(bs, move, moveIdx)
+	if err != nil {
+		bs.takeDecisions()
+		return nil, err
 	}
 
-	now := time.Now()
-	battleState := &BattleState{
-		ID:              uuid.New().String(),
-		UserID:          userID,
-		Mode:            mode,
-		PlayerDeck:      playerBattleDeck,
-		AIDeck:          aiBattleDeck,
-		PlayerActiveIdx: 0,
-		AIActiveIdx:     0,
-		TurnNumber:      1,
-		RoundNumber:     1,
-		WhoseTurn:       "player", // Player always goes first in turn 1
-		BattleOver:      false,
-		Winner:          "",
-		SacrificeCount:  make(map[int]int),
-		CreatedAt:       now,
-		UpdatedAt:       now,
+	if bs.BattleOver && !hasEvent(events, EventBattleEnded) {
+		events = append(events, Event{Type: EventBattleEnded, Winner: bs.Winner, Reason: EndKnockout})
 	}
 
-	return battleState, nil
+	finishTurnRecord(bs, rec, snap, events)
+	bs.record(ReplayAction{Kind: "move", Turn: turn, Move: move, MoveIdx: moveIdx, AI: bs.takeDecisions(), Log: EventLog(events), Events: events})
+	return events, nil
+}
+
+// hasEvent reports whether events contains an event of type t
+func hasEvent(events []Event, t EventType) bool {
+	for _, e := range events {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
 }
 
-// ProcessMove processes a player's move in the battle
-func ProcessMove(bs *BattleState, move string, moveIdx *int) ([]string, error) {
+// processMove applies a player's move to the battle state
+func processMove(bs *BattleState, move string, moveIdx *int) ([]Event, error) {
 	if bs.BattleOver {
 		return nil, fmt.Errorf("battle is already over")
 	}
 
-	logEntries := []string{}
+	events := []Event{}
 
 	// Get active cards first
 	playerCard := bs.GetActivePlayerCard()
 	aiCard := bs.GetActiveAICard()
 
 	// Handle surrender
-	if move == "surrender" {
-		if bs.Mode == "1v1" {
-			// In 1v1, surrender ends the entire battle
-			bs.BattleOver = true
-			bs.Winner = "ai"
-			logEntries = append(logEntries, "Player surrendered! AI wins the battle!")
-			return logEntries, nil
-		} else if bs.Mode == "5v5" {
-			// In 5v5, surrender only knocks out current Pokemon
-			if playerCard != nil {
-				playerCard.HP = 0
-				playerCard.IsKnockedOut = true
-				logEntries = append(logEntries, fmt.Sprintf("Player surrendered! %s was knocked out!", playerCard.Name))
-			}
-
-			// Check if player has any Pokemon left
-			if !bs.HasPlayerPokemonAlive() {
-				bs.BattleOver = true
-				bs.Winner = "ai"
-				logEntries = append(logEntries, "Player has no Pokemon left! AI wins the battle!")
-			} else {
-				// Player must switch to next available Pokemon
-				for i, card := range bs.PlayerDeck {
-					if card.HP > 0 && i != bs.PlayerActiveIdx {
-						bs.PlayerActiveIdx = i
-						bs.RoundNumber++
-						logEntries = append(logEntries, fmt.Sprintf("Player must switch to another Pokemon. Round %d begins.", bs.RoundNumber))
-						break
-					}
-				}
-			}
-			return logEntries, nil
-		}
+	if move == "surrender" && (bs.Mode == "1v1" || bs.Mode == "5v5") {
+		return surrender(bs, "player"), nil
 	}
 
 	if playerCard == nil || aiCard == nil {
@@ -110,58 +134,11 @@ func ProcessMove(bs *BattleState, move string, moveIdx *int) ([]string, error) {
 
 	// Handle sacrifice (free action)
 	if move == "sacrifice" {
-		oldHP := playerCard.HP
-		oldStamina := playerCard.Stamina
-
-		// Convert to pokemon.Card for core logic
-		pCard := ConvertFromBattleCard(*playerCard)
-
-		// Use existing sacrifice logic
-		sacrificeCount := bs.SacrificeCount[bs.PlayerActiveIdx]
-		if sacrificeCount >= 3 {
-			return nil, fmt.Errorf("maximum sacrifices reached for this Pokemon")
+		sacrificed, err := sacrifice(bs, "player")
+		if err != nil {
+			return nil, err
 		}
-
-		var hpCost int
-		var staminaGain float64
-		switch sacrificeCount {
-		case 0:
-			hpCost = 10
-			staminaGain = 0.5
-		case 1:
-			hpCost = 15
-			staminaGain = 0.25
-		case 2:
-			hpCost = 20
-			staminaGain = 0.15
-		}
-
-		if pCard.HP <= hpCost {
-			return nil, fmt.Errorf("insufficient HP to sacrifice")
-		}
-
-		maxStamina := pCard.Speed * 2
-		if float64(pCard.Stamina) >= 0.5*float64(maxStamina) {
-			return nil, fmt.Errorf("stamina is already above 50%%")
-		}
-
-		pCard.HP -= hpCost
-		gain := int(float64(maxStamina) * staminaGain)
-		pCard.Stamina += gain
-		if pCard.Stamina > maxStamina {
-			pCard.Stamina = maxStamina
-		}
-
-		// Update battle card
-		playerCard.HP = pCard.HP
-		playerCard.Stamina = pCard.Stamina
-		bs.SacrificeCount[bs.PlayerActiveIdx] = sacrificeCount + 1
-
-		hpLost := oldHP - playerCard.HP
-		staminaGained := playerCard.Stamina - oldStamina
-		logEntries = append(logEntries, fmt.Sprintf("Player sacrificed %d HP and gained %d stamina.", hpLost, staminaGained))
-
-		return logEntries, nil
+		return append(events, sacrificed), nil
 	}
 
 	// Check whose turn it is
@@ -170,22 +147,12 @@ func ProcessMove(bs *BattleState, move string, moveIdx *int) ([]string, error) {
 	}
 
 	// Validate move
-	switch move {
-	case "attack":
-		if moveIdx == nil {
-			return nil, fmt.Errorf("move index required for attack")
-		}
-		if *moveIdx < 0 || *moveIdx >= len(playerCard.Moves) {
-			return nil, fmt.Errorf("invalid move index")
-		}
-		if playerCard.Stamina < playerCard.Moves[*moveIdx].StaminaCost {
-			return nil, fmt.Errorf("insufficient stamina for this move")
-		}
-	case "defend":
-		defendCost := core.GetDefendCost(playerCard.HPMax)
-		if playerCard.Stamina < defendCost {
-			return nil, fmt.Errorf("insufficient stamina to defend")
+	if move == "item" {
+		if err := checkBagItem(bs, playerCard, moveIdx); err != nil {
+			return nil, err
 		}
+	} else if err := validateMove(playerCard, move, moveIdx); err != nil {
+		return nil, err
 	}
 
 	// Store player's move
@@ -194,25 +161,37 @@ func ProcessMove(bs *BattleState, move string, moveIdx *int) ([]string, error) {
 		bs.PendingPlayerMoveIdx = *moveIdx
 	}
 
-	// Log player's move
+	// Record player's move
+	chosen := Event{Type: EventMoveChosen, Side: "player", Move: move}
 	if move == "attack" && moveIdx != nil {
-		moveName := playerCard.Moves[*moveIdx].Name
-		logEntries = append(logEntries, fmt.Sprintf("Player chose to attack with %s.", moveName))
-	} else {
-		logEntries = append(logEntries, fmt.Sprintf("Player chose %s.", move))
+		chosen.MoveName = playerCard.Moves[*moveIdx].Name
+	}
+	if move == "item" {
+		chosen.Item = pokemon.BattleItems[*moveIdx].Name
+	}
+	events = append(events, chosen)
+
+	// Items from the bag are used straight away and take up the player's turn
+	if move == "item" {
+		events = append(events, useBagItem(bs, playerCard, *moveIdx))
 	}
 
 	// AI makes its move
-	aiLogs := processAIMove(bs)
-	logEntries = append(logEntries, aiLogs...)
+	events = append(events, processAIMove(bs)...)
 
 	// Resolve the turn
-	resolveLogs := resolveTurn(bs)
-	logEntries = append(logEntries, resolveLogs...)
+	events = append(events, finishTurn(bs)...)
+
+	return events, nil
+}
+
+// finishTurn resolves the pending moves of both sides, handles knockouts and
+// prepares the next turn
+func finishTurn(bs *BattleState) []Event {
+	events := resolveTurn(bs)
 
 	// Check for knockouts and handle Pokemon switching
-	switchLogs := handleKnockouts(bs)
-	logEntries = append(logEntries, switchLogs...)
+	events = append(events, handleKnockouts(bs)...)
 
 	// Check if battle is over
 	bs.CheckBattleEnd()
@@ -221,22 +200,131 @@ func ProcessMove(bs *BattleState, move string, moveIdx *int) ([]string, error) {
 	if !bs.BattleOver {
 		bs.TurnNumber++
 		bs.WhoseTurn = "player"
+		if bs.IsPvP() {
+			bs.WhoseTurn = SideBoth
+		}
 		bs.PendingPlayerMove = ""
 		bs.PendingPlayerMoveIdx = 0
 		bs.PendingAIMove = ""
 		bs.PendingAIMoveIdx = 0
 	}
 
-	return logEntries, nil
+	return events
+}
+
+// validateMove checks that card can make move this turn
+func validateMove(card *BattleCard, move string, moveIdx *int) error {
+	switch move {
+	case "attack":
+		if moveIdx == nil {
+			return fmt.Errorf("move index required for attack")
+		}
+		if *moveIdx < 0 || *moveIdx >= len(card.Moves) {
+			return fmt.Errorf("invalid move index")
+		}
+		if card.Stamina < card.Moves[*moveIdx].StaminaCost {
+			return fmt.Errorf("insufficient stamina for this move")
+		}
+	case "defend":
+		if card.Stamina < defendCost(card.HPMax, card.Ability) {
+			return fmt.Errorf("insufficient stamina to defend")
+		}
+	}
+	return nil
+}
+
+// sacrifice trades some of side's active Pokemon's HP for stamina (a free action)
+func sacrifice(bs *BattleState, side string) (Event, error) {
+	card := bs.activeCard(side)
+	activeIdx := *bs.activeIdx(side)
+
+	oldHP := card.HP
+	oldStamina := card.Stamina
+
+	// Convert to pokemon.Card for core logic
+	pCard := ConvertFromBattleCard(*card)
+
+	// Use existing sacrifice logic
+	rules := ruleset.Current()
+	sacrificeCount := bs.sacrificeCount(side)[activeIdx]
+	sacrifice, ok := rules.Sacrifice(sacrificeCount)
+	if !ok {
+		return Event{}, fmt.Errorf("maximum sacrifices reached for this Pokemon")
+	}
+
+	if pCard.HP <= sacrifice.HPCost {
+		return Event{}, fmt.Errorf("insufficient HP to sacrifice")
+	}
+
+	maxStamina := pCard.Speed * 2
+	if !rules.CanSacrifice(pCard.Stamina, maxStamina) {
+		return Event{}, fmt.Errorf("stamina is already above %.0f%%", rules.SacrificeStaminaLimit*100)
+	}
+
+	pCard.HP -= sacrifice.HPCost
+	gain := int(float64(maxStamina) * sacrifice.StaminaGain)
+	pCard.Stamina += gain
+	if pCard.Stamina > maxStamina {
+		pCard.Stamina = maxStamina
+	}
+
+	// Update battle card
+	card.HP = pCard.HP
+	card.Stamina = pCard.Stamina
+	bs.sacrificeCount(side)[activeIdx] = sacrificeCount + 1
+
+	return Event{Type: EventSacrificed, Side: side, HPLost: oldHP - card.HP, StaminaGained: card.Stamina - oldStamina}, nil
+}
+
+// surrender gives up for side. In 1v1 that ends the battle; in 5v5 it knocks
+// out the active Pokemon and brings in the next one.
+func surrender(bs *BattleState, side string) []Event {
+	events := []Event{}
+
+	if bs.Mode == "1v1" {
+		bs.BattleOver = true
+		bs.Winner = opponent(side)
+		return append(events, Event{Type: EventBattleEnded, Winner: bs.Winner, Reason: EndSurrender})
+	}
+
+	if card := bs.activeCard(side); card != nil {
+		card.HP = 0
+		card.IsKnockedOut = true
+		card.Stages = StatStages{}
+		events = append(events, Event{Type: EventSurrendered, Side: side, Pokemon: card.Name})
+	}
+
+	if !bs.hasPokemonAlive(side) {
+		bs.BattleOver = true
+		bs.Winner = opponent(side)
+		return append(events, Event{Type: EventBattleEnded, Winner: bs.Winner, Reason: EndNoPokemonLeft})
+	}
+
+	// Bring in the next available Pokemon
+	activeIdx := bs.activeIdx(side)
+	for i, card := range bs.deckOf(side) {
+		if card.HP > 0 && i != *activeIdx {
+			*activeIdx = i
+			if side == "player" {
+				bs.RoundNumber++
+				events = append(events, Event{Type: EventRoundStarted, Side: side, Pokemon: card.Name, Round: bs.RoundNumber})
+			} else {
+				events = append(events, Event{Type: EventSwitched, Side: side, Pokemon: card.Name})
+			}
+			break
+		}
+	}
+
+	return events
 }
 
 // processAIMove handles AI decision making with enhanced logic
-func processAIMove(bs *BattleState) []string {
-	logEntries := []string{}
+func processAIMove(bs *BattleState) []Event {
+	events := []Event{}
 	aiCard := bs.GetActiveAICard()
 
 	if aiCard == nil {
-		return logEntries
+		return events
 	}
 
 	// Convert to pokemon.Card for core AI logic
@@ -244,88 +332,36 @@ func processAIMove(bs *BattleState) []string {
 
 	// Handle AI sacrifices
 	for {
-		// Use enhanced AI decision making
-		aiMove, aiMoveIdx := GetEnhancedAIMove(bs, bs.PendingPlayerMove)
-
-		if aiMove == "surrender" {
-			if bs.Mode == "1v1" {
-				// In 1v1, AI surrender ends the entire battle
-				bs.BattleOver = true
-				bs.Winner = "player"
-				logEntries = append(logEntries, "AI surrendered! Player wins the battle!")
-				return logEntries
-			} else if bs.Mode == "5v5" {
-				// In 5v5, AI surrender only knocks out current Pokemon
-				aiCard.HP = 0
-				aiCard.IsKnockedOut = true
-				logEntries = append(logEntries, fmt.Sprintf("AI surrendered! %s was knocked out!", aiCard.Name))
-
-				// Check if AI has any Pokemon left
-				if !bs.HasAIPokemonAlive() {
-					bs.BattleOver = true
-					bs.Winner = "player"
-					logEntries = append(logEntries, "AI has no Pokemon left! Player wins the battle!")
-				} else {
-					// AI switches to next available Pokemon
-					for i, card := range bs.AIDeck {
-						if card.HP > 0 && i != bs.AIActiveIdx {
-							bs.AIActiveIdx = i
-							logEntries = append(logEntries, fmt.Sprintf("AI switched to %s.", bs.AIDeck[i].Name))
-							break
-						}
-					}
-				}
-				return logEntries
-			}
-		}
+		// The battle's difficulty decides which strategy picks the move
+		aiMove, aiMoveIdx := chooseAIMove(bs)
 
-		if aiMove == "sacrifice" {
-			maxStamina := aCard.Speed * 2
-			if float64(aCard.Stamina) >= 0.5*float64(maxStamina) {
-				break
-			}
-
-			sacrificeCount := bs.SacrificeCount[bs.AIActiveIdx]
-			if sacrificeCount >= 3 {
-				break
-			}
+		if aiMove == "surrender" && (bs.Mode == "1v1" || bs.Mode == "5v5") {
+			return append(events, surrender(bs, "ai")...)
+		}
 
-			oldHP := aCard.HP
-			oldStamina := aCard.Stamina
-
-			var hpCost int
-			var staminaGain float64
-			switch sacrificeCount {
-			case 0:
-				hpCost = 10
-				staminaGain = 0.5
-			case 1:
-				hpCost = 15
-				staminaGain = 0.25
-			case 2:
-				hpCost = 20
-				staminaGain = 0.15
+		// Like the player's, the AI's switch is free: it decides again with the Pokemon it
+		// brought in. It may only switch once a turn.
+		if aiMove == "switch" {
+			if bs.aiSwitchedIn == nil && switchPokemon(bs, "ai", aiMoveIdx) == nil {
+				card := bs.AIDeck[aiMoveIdx]
+				bs.aiSwitchedIn = &turnSnapshot{aiIdx: aiMoveIdx, aiHP: card.HP, aiStamina: card.Stamina}
+				events = append(events, Event{Type: EventSwitched, Side: "ai", Pokemon: card.Name, Round: bs.RoundNumber})
+
+				aiCard = bs.GetActiveAICard()
+				aCard = ConvertFromBattleCard(*aiCard)
+				continue
 			}
+			aiMove, aiMoveIdx = "pass", 0
+		}
 
-			if aCard.HP <= hpCost {
+		if aiMove == "sacrifice" {
+			// A sacrifice the rules refuse ends the AI's sacrificing
+			event, err := sacrifice(bs, "ai")
+			if err != nil {
 				break
 			}
-
-			aCard.HP -= hpCost
-			gain := int(float64(maxStamina) * staminaGain)
-			aCard.Stamina += gain
-			if aCard.Stamina > maxStamina {
-				aCard.Stamina = maxStamina
-			}
-
-			// Update battle card
-			aiCard.HP = aCard.HP
-			aiCard.Stamina = aCard.Stamina
-			bs.SacrificeCount[bs.AIActiveIdx] = sacrificeCount + 1
-
-			hpLost := oldHP - aCard.HP
-			staminaGained := aCard.Stamina - oldStamina
-			logEntries = append(logEntries, fmt.Sprintf("AI sacrificed %d HP and gained %d stamina.", hpLost, staminaGained))
+			aCard = ConvertFromBattleCard(*aiCard)
+			events = append(events, event)
 			continue
 		}
 
@@ -333,29 +369,28 @@ func processAIMove(bs *BattleState) []string {
 		bs.PendingAIMove = aiMove
 		bs.PendingAIMoveIdx = aiMoveIdx
 
-		// Log AI's move
+		// Record AI's move
+		chosen := Event{Type: EventMoveChosen, Side: "ai", Move: aiMove}
 		if aiMove == "attack" {
-			moveName := aCard.Moves[aiMoveIdx].Name
-			logEntries = append(logEntries, fmt.Sprintf("AI chose to attack with %s.", moveName))
-		} else {
-			logEntries = append(logEntries, fmt.Sprintf("AI chose %s.", aiMove))
+			chosen.MoveName = aCard.Moves[aiMoveIdx].Name
 		}
+		events = append(events, chosen)
 
 		break
 	}
 
-	return logEntries
+	return events
 }
 
 // resolveTurn resolves both player and AI moves
-func resolveTurn(bs *BattleState) []string {
-	logEntries := []string{}
+func resolveTurn(bs *BattleState) []Event {
+	events := []Event{}
 
 	playerCard := bs.GetActivePlayerCard()
 	aiCard := bs.GetActiveAICard()
 
 	if playerCard == nil || aiCard == nil {
-		return logEntries
+		return events
 	}
 
 	// Convert to pokemon.Card for core logic
@@ -367,33 +402,59 @@ func resolveTurn(bs *BattleState) []string {
 	playerMoveIdx := bs.PendingPlayerMoveIdx
 	aiMoveIdx := bs.PendingAIMoveIdx
 
-	playerDefendCost := core.GetDefendCost(pCard.HPMax)
-	aiDefendCost := core.GetDefendCost(aCard.HPMax)
+	// Using an item from the bag took up the player's turn
+	playerUsedItem := playerMove == "item"
+	if playerUsedItem {
+		playerMove = "pass"
+	}
+
+	// Status conditions can stop a Pokemon moving, and weaken the ones that do
+	playerMove, statusEvents := checkStatus(bs, "player", playerCard, playerMove)
+	events = append(events, statusEvents...)
+	aiMove, statusEvents = checkStatus(bs, "ai", aiCard, aiMove)
+	events = append(events, statusEvents...)
+	applyStatusStats(&pCard, playerCard.Status)
+	applyStatusStats(&aCard, aiCard.Status)
+
+	// Support moves take effect before any attack lands, so the stat stages
+	// they change already count this turn
+	playerMove, playerSupported, supportEvents := useSupportMove(bs, "player", playerMove, playerMoveIdx, &pCard)
+	events = append(events, supportEvents...)
+	aiMove, aiSupported, supportEvents := useSupportMove(bs, "ai", aiMove, aiMoveIdx, &aCard)
+	events = append(events, supportEvents...)
+	applyStages(&pCard, playerCard.Stages)
+	applyStages(&aCard, aiCard.Stages)
+
+	playerDefendCost := defendCost(pCard.HPMax, pCard.Ability)
+	aiDefendCost := defendCost(aCard.HPMax, aCard.Ability)
 
 	// Track damage for logging
 	playerDamage := 0
 	aiDamage := 0
+	playerHP, aiHP := pCard.HP, aCard.HP
 
-	// Process moves based on combination
-	if playerMove == "pass" && aiMove == "pass" {
+	// Process moves based on combination. A turn where a side used a support
+	// move or an item doesn't count towards a stalemate.
+	if playerMove == "pass" && aiMove == "pass" && !playerSupported && !aiSupported && !playerUsedItem {
 		bs.ConsecutivePasses++
-		logEntries = append(logEntries, fmt.Sprintf("Both passed. Nothing happened! (Pass count: %d/3)", bs.ConsecutivePasses))
+		events = append(events, Event{Type: EventPassed, Side: SideBoth, PassCount: bs.ConsecutivePasses})
 
 		// Check for stalemate - if both players pass 3 times in a row, end in draw
 		if bs.ConsecutivePasses >= 3 {
 			bs.BattleOver = true
 			bs.Winner = "draw"
-			logEntries = append(logEntries, "Stalemate! Both players passed 3 times in a row. Battle ends in a draw!")
+			events = append(events, Event{Type: EventBattleEnded, Winner: "draw", Reason: EndStalemate})
 		}
 	} else if playerMove == "pass" {
 		// Reset consecutive passes if only one player passed
 		bs.ConsecutivePasses = 0
 		switch aiMove {
 		case "attack":
-			aiDamage = core.CalculateDamage(&aCard, &pCard, false, aiMoveIdx)
+			hit := bs.hit(&aCard, &pCard, false, aiMoveIdx)
+			aiDamage = hit.Damage
 			pCard.HP -= aiDamage
 			aCard.Stamina -= aCard.Moves[aiMoveIdx].StaminaCost
-			logEntries = append(logEntries, fmt.Sprintf("AI dealt %d damage to Player.", aiDamage))
+			events = append(events, damageEvents("ai", hit)...)
 		case "defend":
 			aCard.Stamina -= aiDefendCost
 		}
@@ -402,56 +463,124 @@ func resolveTurn(bs *BattleState) []string {
 		bs.ConsecutivePasses = 0
 		switch playerMove {
 		case "attack":
-			playerDamage = core.CalculateDamage(&pCard, &aCard, false, playerMoveIdx)
+			hit := bs.hit(&pCard, &aCard, false, playerMoveIdx)
+			playerDamage = hit.Damage
 			aCard.HP -= playerDamage
 			pCard.Stamina -= pCard.Moves[playerMoveIdx].StaminaCost
-			logEntries = append(logEntries, fmt.Sprintf("Player dealt %d damage to AI.", playerDamage))
+			events = append(events, damageEvents("player", hit)...)
 		case "defend":
 			pCard.Stamina -= playerDefendCost
 		}
+	} else if playerMove == "attack" && aiMove == "attack" && bs.Rules.SpeedOrder {
+		bs.ConsecutivePasses = 0
+		events = append(events, resolveSpeedOrder(bs, &pCard, &aCard, playerMoveIdx, aiMoveIdx)...)
 	} else if playerMove == "attack" && aiMove == "attack" {
 		// Reset consecutive passes when both attack
 		bs.ConsecutivePasses = 0
-		playerDamage = core.CalculateDamage(&pCard, &aCard, false, playerMoveIdx)
-		aiDamage = core.CalculateDamage(&aCard, &pCard, false, aiMoveIdx)
+		playerHit := bs.hit(&pCard, &aCard, false, playerMoveIdx)
+		aiHit := bs.hit(&aCard, &pCard, false, aiMoveIdx)
+		playerDamage = playerHit.Damage
+		aiDamage = aiHit.Damage
 		aCard.HP -= playerDamage
 		pCard.HP -= aiDamage
 		pCard.Stamina -= pCard.Moves[playerMoveIdx].StaminaCost
 		aCard.Stamina -= aCard.Moves[aiMoveIdx].StaminaCost
-		logEntries = append(logEntries, fmt.Sprintf("Player dealt %d damage to AI.", playerDamage))
-		logEntries = append(logEntries, fmt.Sprintf("AI dealt %d damage to Player.", aiDamage))
+		events = append(events, damageEvents("player", playerHit)...)
+		events = append(events, damageEvents("ai", aiHit)...)
 	} else if playerMove == "attack" && aiMove == "defend" {
 		// Reset consecutive passes
 		bs.ConsecutivePasses = 0
-		playerDamage = core.CalculateDamage(&pCard, &aCard, true, playerMoveIdx)
+		hit := bs.hit(&pCard, &aCard, true, playerMoveIdx)
+		playerDamage = hit.Damage
 		aCard.Stamina -= aiDefendCost
 		pCard.Stamina -= pCard.Moves[playerMoveIdx].StaminaCost
-		if playerDamage <= aCard.Defense {
-			logEntries = append(logEntries, "AI blocked all damage!")
-		} else {
-			actualDamage := playerDamage - aCard.Defense
+		events = append(events, hitEvents("player", hit)...)
+		defense := aCard.DefenseStat(pCard.Moves[playerMoveIdx])
+		switch {
+		case hit.Missed:
+		case playerDamage <= defense:
+			events = append(events, Event{Type: EventBlocked, Side: "ai"})
+		default:
+			actualDamage := playerDamage - defense
 			aCard.HP -= actualDamage
-			logEntries = append(logEntries, fmt.Sprintf("Player dealt %d damage to AI (after defense).", actualDamage))
+			events = append(events, Event{Type: EventDamageDealt, Side: "player", Damage: actualDamage, AfterDefense: true})
 		}
 	} else if playerMove == "defend" && aiMove == "attack" {
 		// Reset consecutive passes
 		bs.ConsecutivePasses = 0
-		aiDamage = core.CalculateDamage(&aCard, &pCard, true, aiMoveIdx)
+		hit := bs.hit(&aCard, &pCard, true, aiMoveIdx)
+		aiDamage = hit.Damage
 		pCard.Stamina -= playerDefendCost
 		aCard.Stamina -= aCard.Moves[aiMoveIdx].StaminaCost
-		if aiDamage <= pCard.Defense {
-			logEntries = append(logEntries, "Player blocked all damage!")
-		} else {
-			actualDamage := aiDamage - pCard.Defense
+		events = append(events, hitEvents("ai", hit)...)
+		defense := pCard.DefenseStat(aCard.Moves[aiMoveIdx])
+		switch {
+		case hit.Missed:
+		case aiDamage <= defense:
+			events = append(events, Event{Type: EventBlocked, Side: "player"})
+		default:
+			actualDamage := aiDamage - defense
 			pCard.HP -= actualDamage
-			logEntries = append(logEntries, fmt.Sprintf("AI dealt %d damage to Player (after defense).", actualDamage))
+			events = append(events, Event{Type: EventDamageDealt, Side: "ai", Damage: actualDamage, AfterDefense: true})
 		}
 	} else if playerMove == "defend" && aiMove == "defend" {
 		// Reset consecutive passes
 		bs.ConsecutivePasses = 0
 		pCard.Stamina -= playerDefendCost
 		aCard.Stamina -= aiDefendCost
-		logEntries = append(logEntries, "Both defended. No damage dealt.")
+		events = append(events, Event{Type: EventBlocked, Side: SideBoth})
+	}
+
+	// Abilities react to the moves that hit their holder
+	var reactions []Event
+	for _, e := range events {
+		if e.Type != EventDamageDealt {
+			continue
+		}
+		if e.Side == "player" {
+			reactions = append(reactions, abilityReaction("ai", &aCard, &pCard, pCard.Moves[playerMoveIdx], e.Damage)...)
+		} else {
+			reactions = append(reactions, abilityReaction("player", &pCard, &aCard, aCard.Moves[aiMoveIdx], e.Damage)...)
+		}
+	}
+	events = append(events, reactions...)
+
+	// A focus sash saves a Pokemon at full HP from a knockout
+	events = append(events, endure("player", &pCard, playerHP)...)
+	events = append(events, endure("ai", &aCard, aiHP)...)
+
+	// Moves that hit may inflict their secondary effect
+	var inflicted []Event
+	for _, e := range events {
+		if e.Type != EventDamageDealt || e.Damage <= 0 {
+			continue
+		}
+		if e.Side == "player" {
+			inflicted = append(inflicted, inflictStatus(bs, "ai", aiCard, aCard.HP, pCard.Moves[playerMoveIdx])...)
+		} else {
+			inflicted = append(inflicted, inflictStatus(bs, "player", playerCard, pCard.HP, aCard.Moves[aiMoveIdx])...)
+		}
+	}
+	events = append(events, inflicted...)
+
+	// Burn and poison hurt at the end of the turn
+	if !bs.BattleOver {
+		events = append(events, statusDamage("player", playerCard.Status, &pCard)...)
+		events = append(events, statusDamage("ai", aiCard.Status, &aCard)...)
+	}
+
+	// So do sandstorm, hail and grassy terrain, then weather and terrain set by
+	// moves count down
+	if !bs.BattleOver {
+		events = append(events, fieldDamage(bs.Field, "player", &pCard)...)
+		events = append(events, fieldDamage(bs.Field, "ai", &aCard)...)
+	}
+	events = append(events, bs.Field.tick()...)
+
+	// Then leftovers and stamina charms restore what they restore
+	if !bs.BattleOver {
+		events = append(events, itemRecovery("player", &pCard, playerCard.StaminaMax)...)
+		events = append(events, itemRecovery("ai", &aCard, aiCard.StaminaMax)...)
 	}
 
 	// Clamp HP and stamina
@@ -472,22 +601,24 @@ func resolveTurn(bs *BattleState) []string {
 	playerCard.HP = pCard.HP
 	playerCard.Stamina = pCard.Stamina
 	playerCard.IsKnockedOut = pCard.HP <= 0
+	playerCard.HeldItem = pCard.HeldItem
 	aiCard.HP = aCard.HP
 	aiCard.Stamina = aCard.Stamina
 	aiCard.IsKnockedOut = aCard.HP <= 0
+	aiCard.HeldItem = aCard.HeldItem
 
-	return logEntries
+	return events
 }
 
 // handleKnockouts handles Pokemon knockouts and switching logic
-func handleKnockouts(bs *BattleState) []string {
-	logEntries := []string{}
+func handleKnockouts(bs *BattleState) []Event {
+	events := []Event{}
 
 	playerCard := bs.GetActivePlayerCard()
 	aiCard := bs.GetActiveAICard()
 
 	if playerCard == nil || aiCard == nil {
-		return logEntries
+		return events
 	}
 
 	// Check for knockouts
@@ -495,18 +626,20 @@ func handleKnockouts(bs *BattleState) []string {
 	aiKO := aiCard.HP <= 0
 
 	if playerKO {
-		logEntries = append(logEntries, fmt.Sprintf("Player's %s was knocked out!", playerCard.Name))
+		events = append(events, Event{Type: EventKnockedOut, Side: "player", Pokemon: playerCard.Name})
 		playerCard.IsKnockedOut = true
+		playerCard.Stages = StatStages{}
 	}
 
 	if aiKO {
-		logEntries = append(logEntries, fmt.Sprintf("AI's %s was knocked out!", aiCard.Name))
+		events = append(events, Event{Type: EventKnockedOut, Side: "ai", Pokemon: aiCard.Name})
 		aiCard.IsKnockedOut = true
+		aiCard.Stages = StatStages{}
 	}
 
 	// For 1v1, battle ends on knockout
 	if bs.Mode == "1v1" {
-		return logEntries
+		return events
 	}
 
 	// For 5v5, handle Pokemon switching
@@ -516,7 +649,7 @@ func handleKnockouts(bs *BattleState) []string {
 			if card.HP > 0 && i != bs.PlayerActiveIdx {
 				bs.PlayerActiveIdx = i
 				bs.RoundNumber++
-				logEntries = append(logEntries, fmt.Sprintf("Player must switch to another Pokemon. Round %d begins.", bs.RoundNumber))
+				events = append(events, Event{Type: EventRoundStarted, Side: "player", Pokemon: card.Name, Round: bs.RoundNumber})
 				break
 			}
 		}
@@ -527,17 +660,39 @@ func handleKnockouts(bs *BattleState) []string {
 		for i, card := range bs.AIDeck {
 			if card.HP > 0 && i != bs.AIActiveIdx {
 				bs.AIActiveIdx = i
-				logEntries = append(logEntries, fmt.Sprintf("AI switched to %s.", bs.AIDeck[i].Name))
+				events = append(events, Event{Type: EventSwitched, Side: "ai", Pokemon: bs.AIDeck[i].Name})
 				break
 			}
 		}
 	}
 
-	return logEntries
+	return events
+}
+
+// SwitchPokemon allows the player to switch to a different Pokemon, returning the resulting events.
+// The switch is added to the battle's timeline and replay.
+func SwitchPokemon(bs *BattleState, newIdx int) ([]Event, error) {
+	turn := bs.TurnNumber
+	rec, snap := beginTurnRecord(bs, "switch")
+	if err := switchPokemon(bs, "player", newIdx); err != nil {
+		return nil, err
+	}
+
+	events := []Event{{Type: EventSwitched, Side: "player", Pokemon: bs.PlayerDeck[newIdx].Name, Round: bs.RoundNumber}}
+	finishTurnRecord(bs, rec, snap, events)
+
+	bs.record(ReplayAction{
+		Kind:      "switch",
+		Turn:      turn,
+		SwitchIdx: newIdx,
+		Log:       EventLog(events),
+		Events:    events,
+	})
+	return events, nil
 }
 
-// SwitchPokemon allows the player to switch to a different Pokemon
-func SwitchPokemon(bs *BattleState, newIdx int) error {
+// switchPokemon makes side's Pokemon at newIdx the active one
+func switchPokemon(bs *BattleState, side string, newIdx int) error {
 	if bs.BattleOver {
 		return fmt.Errorf("battle is already over")
 	}
@@ -546,19 +701,24 @@ func SwitchPokemon(bs *BattleState, newIdx int) error {
 		return fmt.Errorf("switching is only allowed in 5v5 battles")
 	}
 
-	if newIdx < 0 || newIdx >= len(bs.PlayerDeck) {
+	deck := bs.deckOf(side)
+	activeIdx := bs.activeIdx(side)
+
+	if newIdx < 0 || newIdx >= len(deck) {
 		return fmt.Errorf("invalid Pokemon index")
 	}
 
-	if newIdx == bs.PlayerActiveIdx {
+	if newIdx == *activeIdx {
 		return fmt.Errorf("pokemon is already active")
 	}
 
-	if bs.PlayerDeck[newIdx].HP <= 0 {
+	if deck[newIdx].HP <= 0 {
 		return fmt.Errorf("cannot switch to a knocked out Pokemon")
 	}
 
-	bs.PlayerActiveIdx = newIdx
+	// Stat stages only last while the Pokemon stays in
+	deck[*activeIdx].Stages = StatStages{}
+	*activeIdx = newIdx
 	bs.RoundNumber++
 
 	return nil
@@ -0,0 +1,172 @@
+package battle
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+func testCard(name string, hp, attack, defense, speed int, types []string, moves []pokemon.Move) pokemon.Card {
+	return pokemon.Card{
+		Name:    name,
+		HP:      hp,
+		HPMax:   hp,
+		Stamina: speed * 2,
+		Attack:  attack,
+		Defense: defense,
+		Speed:   speed,
+		Types:   types,
+		Moves:   moves,
+		Level:   1,
+	}
+}
+
+func testDecks() ([]pokemon.Card, []pokemon.Card) {
+	player := []pokemon.Card{
+		testCard("pikachu", 52, 55, 40, 90, []string{"electric"}, []pokemon.Move{
+			{Name: "thunderbolt", Power: 90, StaminaCost: 30, Type: "electric"},
+			{Name: "quick-attack", Power: 40, StaminaCost: 13, Type: "normal"},
+		}),
+	}
+	ai := []pokemon.Card{
+		testCard("squirtle", 66, 48, 65, 43, []string{"water"}, []pokemon.Move{
+			{Name: "water-gun", Power: 40, StaminaCost: 13, Type: "water"},
+			{Name: "bite", Power: 60, StaminaCost: 20, Type: "dark"},
+		}),
+	}
+	return player, ai
+}
+
+type scriptedMove struct {
+	move    string
+	moveIdx *int
+}
+
+func intPtr(i int) *int { return &i }
+
+var testScript = []scriptedMove{
+	{"attack", intPtr(0)},
+	{"defend", nil},
+	{"attack", intPtr(1)},
+	{"pass", nil},
+	{"attack", intPtr(1)},
+	{"attack", intPtr(0)},
+	{"attack", intPtr(1)},
+	{"attack", intPtr(1)},
+}
+
+// playScript runs the script until the battle ends, skipping moves the state rejects
+func playScript(t *testing.T, bs *BattleState, script []scriptedMove) []string {
+	t.Helper()
+	var log []string
+	for _, step := range script {
+		if bs.BattleOver {
+			break
+		}
+		entries, err := ProcessMove(bs, step.move, step.moveIdx)
+		if err != nil {
+			log = append(log, "error: "+err.Error())
+			continue
+		}
+		log = append(log, EventLog(entries)...)
+	}
+	return log
+}
+
+func newSeededBattle(t *testing.T, seed int64) *BattleState {
+	t.Helper()
+	player, ai := testDecks()
+	bs, err := StartBattleWithSeed(1, "1v1", player, ai, seed)
+	if err != nil {
+		t.Fatalf("StartBattleWithSeed failed: %v", err)
+	}
+	return bs
+}
+
+func TestSeededBattleIsReproducible(t *testing.T) {
+	for _, seed := range []int64{1, 42, 1337, -7} {
+		first := newSeededBattle(t, seed)
+		second := newSeededBattle(t, seed)
+
+		firstLog := playScript(t, first, testScript)
+		secondLog := playScript(t, second, testScript)
+
+		if !reflect.DeepEqual(firstLog, secondLog) {
+			t.Errorf("seed %d: logs differ\nfirst:  %q\nsecond: %q", seed, firstLog, secondLog)
+		}
+		if !reflect.DeepEqual(first.PlayerDeck, second.PlayerDeck) || !reflect.DeepEqual(first.AIDeck, second.AIDeck) {
+			t.Errorf("seed %d: final decks differ", seed)
+		}
+		if first.Winner != second.Winner || first.TurnNumber != second.TurnNumber {
+			t.Errorf("seed %d: outcome differs (%s/%d vs %s/%d)", seed, first.Winner, first.TurnNumber, second.Winner, second.TurnNumber)
+		}
+	}
+}
+
+func TestSeededBattleResumesAfterJSONRoundTrip(t *testing.T) {
+	const seed = 2024
+
+	uninterrupted := newSeededBattle(t, seed)
+	want := playScript(t, uninterrupted, testScript)
+
+	// Persist and reload the state between every move, as the web API does
+	bs := newSeededBattle(t, seed)
+	var got []string
+	for _, step := range testScript {
+		if bs.BattleOver {
+			break
+		}
+		data, err := json.Marshal(bs)
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		var reloaded BattleState
+		if err := json.Unmarshal(data, &reloaded); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		bs = &reloaded
+
+		entries, err := ProcessMove(bs, step.move, step.moveIdx)
+		if err != nil {
+			got = append(got, "error: "+err.Error())
+			continue
+		}
+		got = append(got, EventLog(entries)...)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded battle diverged\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestProcessMoveGolden(t *testing.T) {
+	bs := newSeededBattle(t, 42)
+	got := playScript(t, bs, testScript)
+
+	want := []string{
+		"Player chose to attack with thunderbolt.",
+		"AI chose defend.",
+		"AI blocked all damage!",
+		"Player chose defend.",
+		"AI chose to attack with bite.",
+		"Player blocked all damage!",
+		"Player chose to attack with quick-attack.",
+		"AI chose defend.",
+		"AI blocked all damage!",
+		"Player chose pass.",
+		"AI sacrificed 10 HP and gained 43 stamina.",
+		"AI chose to attack with bite.",
+		"AI dealt 18 damage to Player.",
+		"Player chose to attack with quick-attack.",
+		"AI chose to attack with bite.",
+		"Player dealt 8 damage to AI.",
+		"AI dealt 48 damage to Player.",
+		"Player's pikachu was knocked out!",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("golden log mismatch\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package battle
+
+import (
+	"fmt"
+	"math"
+	"pokemon-cli/pkg/glicko2"
+	"strings"
+)
+
+// AI difficulty levels. Each level plays with a different strategy and pays
+// out rewards scaled to how hard it is to beat.
+const (
+	DifficultyEasy     = "easy"     // Picks a random legal action
+	DifficultyNormal   = "normal"   // The heuristic AI (GetEnhancedAIMove)
+	DifficultyHard     = "hard"     // Simulates each action one turn ahead
+	DifficultyExpert   = "expert"   // Simulates two turns ahead, anticipating the player's reply
+	DifficultyMaster   = "master"   // Expectimax search over damage rolls and player replies
+	DifficultyChampion = "champion" // Monte Carlo tree search over moves, sacrifices and switching
+)
+
+// Difficulties lists the difficulty levels from easiest to hardest
+var Difficulties = []string{DifficultyEasy, DifficultyNormal, DifficultyHard, DifficultyExpert, DifficultyMaster, DifficultyChampion}
+
+// difficultyLevel holds what changes between difficulty levels besides the strategy
+type difficultyLevel struct {
+	rewardMultiplier float64 // Applied to coins and XP earned against the AI
+	rating           float64 // Rating the AI is treated as having on the PvE ladder
+}
+
+var difficultyLevels = map[string]difficultyLevel{
+	DifficultyEasy:     {rewardMultiplier: 0.5, rating: 1200},
+	DifficultyNormal:   {rewardMultiplier: 1, rating: 1500},
+	DifficultyHard:     {rewardMultiplier: 1.5, rating: 1700},
+	DifficultyExpert:   {rewardMultiplier: 2, rating: 1900},
+	DifficultyMaster:   {rewardMultiplier: 2.5, rating: 2100},
+	DifficultyChampion: {rewardMultiplier: 3, rating: 2300},
+}
+
+// ParseDifficulty normalizes a difficulty name. An empty name means normal.
+func ParseDifficulty(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DifficultyNormal, nil
+	}
+	if _, ok := difficultyLevels[name]; !ok {
+		return "", fmt.Errorf("invalid AI difficulty %q (must be one of %s)", name, strings.Join(Difficulties, ", "))
+	}
+	return name, nil
+}
+
+// Difficulty returns the AI difficulty of the battle. Battles started before
+// difficulties existed, and PvP battles, report normal.
+func (bs *BattleState) Difficulty() string {
+	if _, ok := difficultyLevels[bs.AIDifficulty]; !ok {
+		return DifficultyNormal
+	}
+	return bs.AIDifficulty
+}
+
+// SetDifficulty sets the AI difficulty of a battle that hasn't started playing yet
+func (bs *BattleState) SetDifficulty(name string) error {
+	if bs.IsPvP() {
+		return fmt.Errorf("PvP battles have no AI difficulty")
+	}
+	if len(bs.Turns) > 0 {
+		return fmt.Errorf("difficulty can't change once the battle has started")
+	}
+
+	difficulty, err := ParseDifficulty(name)
+	if err != nil {
+		return err
+	}
+
+	bs.AIDifficulty = difficulty
+	if bs.Replay != nil {
+		bs.Replay.Difficulty = difficulty
+	}
+	return nil
+}
+
+// RewardMultiplier returns the factor coins and XP are scaled by at difficulty
+func RewardMultiplier(difficulty string) float64 {
+	if level, ok := difficultyLevels[difficulty]; ok {
+		return level.rewardMultiplier
+	}
+	return 1
+}
+
+// ScaleReward scales a coin or XP amount by the battle's difficulty
+func ScaleReward(amount int, difficulty string) int {
+	return int(math.Round(float64(amount) * RewardMultiplier(difficulty)))
+}
+
+// AIRatingFor returns the rating the AI is treated as having at difficulty
+func AIRatingFor(difficulty string) glicko2.Rating {
+	rating := AIRating
+	if level, ok := difficultyLevels[difficulty]; ok {
+		rating.Rating = level.rating
+	}
+	return rating
+}
+
+// chooseAIMove asks the battle's Strategy what the AI does this turn or, when
+// it has none, the built-in strategy for its difficulty
+func chooseAIMove(bs *BattleState) (string, int) {
+	if bs.strategy != nil {
+		d := bs.decide()
+		return d.Move, d.index()
+	}
+
+	switch bs.Difficulty() {
+	case DifficultyEasy:
+		return GetRandomAIMove(bs)
+	case DifficultyHard:
+		return GetLookaheadAIMove(bs, 1, hardSamples)
+	case DifficultyExpert:
+		return GetLookaheadAIMove(bs, 2, expertSamples)
+	case DifficultyMaster:
+		return GetExpectimaxAIMove(bs)
+	case DifficultyChampion:
+		return GetMCTSAIMove(bs)
+	default:
+		return GetEnhancedAIMove(bs, bs.PendingPlayerMove)
+	}
+}
+
+// GetRandomAIMove picks uniformly among the attacks and defend the AI can afford.
+// With none affordable it falls back to the heuristic AI's sacrifice, surrender
+// and pass logic.
+func GetRandomAIMove(bs *BattleState) (string, int) {
+	actions := aiActions(bs)
+	if len(actions) == 0 {
+		return GetEnhancedAIMove(bs, bs.PendingPlayerMove)
+	}
+
+	action := actions[bs.Rand().Intn(len(actions))]
+	return action.Move, action.MoveIdx
+}
+
+// turnAction is one move a side could commit to this turn
+type turnAction struct {
+	Move    string
+	MoveIdx int
+}
+
+// aiActions lists the attacks and defend the active AI Pokemon has the stamina for
+func aiActions(bs *BattleState) []turnAction {
+	return affordableActions(bs.GetActiveAICard())
+}
+
+// affordableActions lists the attacks and defend card has the stamina for
+func affordableActions(card *BattleCard) []turnAction {
+	if card == nil {
+		return nil
+	}
+
+	actions := []turnAction{}
+	for i, move := range card.Moves {
+		if card.Stamina >= move.StaminaCost {
+			actions = append(actions, turnAction{Move: "attack", MoveIdx: i})
+		}
+	}
+	if card.Stamina >= defendCost(card.HPMax, card.Ability) {
+		actions = append(actions, turnAction{Move: "defend"})
+	}
+	return actions
+}
@@ -0,0 +1,114 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", DifficultyNormal, false},
+		{"easy", DifficultyEasy, false},
+		{" Hard ", DifficultyHard, false},
+		{"EXPERT", DifficultyExpert, false},
+		{"impossible", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDifficulty(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDifficulty(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDifficulty(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRewardsScaleWithDifficulty(t *testing.T) {
+	want := map[string]int{
+		DifficultyEasy:     75,
+		DifficultyNormal:   150,
+		DifficultyHard:     225,
+		DifficultyExpert:   300,
+		DifficultyMaster:   375,
+		DifficultyChampion: 450,
+	}
+
+	for difficulty, coins := range want {
+		bs := &BattleState{Mode: "5v5", Winner: "player", AIDifficulty: difficulty}
+		if got := CalculateAllRewards(bs).CoinsEarned; got != coins {
+			t.Errorf("%s: coins = %d, want %d", difficulty, got, coins)
+		}
+	}
+
+	// Battles saved before difficulties existed pay the normal rate
+	legacy := &BattleState{Mode: "1v1", Winner: "player"}
+	if got := CalculateAllRewards(legacy).CoinsEarned; got != 50 {
+		t.Errorf("legacy battle: coins = %d, want 50", got)
+	}
+}
+
+func TestEveryDifficultyFinishesAndReplays(t *testing.T) {
+	for _, difficulty := range Difficulties {
+		bs := newSeededBattle(t, 7)
+		if err := bs.SetDifficulty(difficulty); err != nil {
+			t.Fatalf("SetDifficulty(%q) failed: %v", difficulty, err)
+		}
+
+		for turn := 0; !bs.BattleOver && turn < 100; turn++ {
+			move, idx := "pass", (*int)(nil)
+			card := bs.GetActivePlayerCard()
+			for i, m := range card.Moves {
+				if card.Stamina >= m.StaminaCost {
+					move, idx = "attack", intPtr(i)
+					break
+				}
+			}
+			if _, err := ProcessMove(bs, move, idx); err != nil {
+				t.Fatalf("%s: ProcessMove failed: %v", difficulty, err)
+			}
+		}
+		if !bs.BattleOver {
+			t.Errorf("%s: battle did not finish", difficulty)
+			continue
+		}
+
+		frames, err := bs.Replay.Frames()
+		if err != nil {
+			t.Fatalf("%s: Frames failed: %v", difficulty, err)
+		}
+		last := frames[len(frames)-1].State
+		if !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+			t.Errorf("%s: replay does not reproduce the battle", difficulty)
+		}
+	}
+}
+
+func TestLookaheadDoesNotAdvanceBattleRNG(t *testing.T) {
+	bs := newSeededBattle(t, 11)
+	bs.PendingPlayerMove = "attack"
+	bs.PendingPlayerMoveIdx = 0
+	bs.Rand() // make sure the source exists
+	before := *bs.RNG
+
+	GetLookaheadAIMove(bs, 2, expertSamples)
+
+	if *bs.RNG != before {
+		t.Error("looking ahead advanced the battle's random source")
+	}
+}
+
+func TestSetDifficultyRejectsPvP(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	bs.OpponentUserID = 2
+	bs.PvPStatus = PvPActive
+	if err := bs.SetDifficulty(DifficultyHard); err == nil {
+		t.Error("expected an error setting the difficulty of a PvP battle")
+	}
+}
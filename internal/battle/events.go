@@ -0,0 +1,256 @@
+package battle
+
+import (
+	"fmt"
+	"pokemon-cli/internal/pokemon"
+	"strings"
+)
+
+// EventType identifies what happened in a battle event
+type EventType string
+
+const (
+	EventBattleStarted   EventType = "battle_started"   // A battle in Mode began
+	EventMoveChosen      EventType = "move_chosen"      // Side picked Move (and MoveName when attacking)
+	EventDamageDealt     EventType = "damage_dealt"     // Side dealt Damage to the other side
+	EventMovedFirst      EventType = "moved_first"      // Side's Pokemon was faster and attacked first (speed order rule)
+	EventMissed          EventType = "missed"           // Side's attack missed (modern damage rules)
+	EventCriticalHit     EventType = "critical_hit"     // Side's attack was a critical hit (modern damage rules)
+	EventBlocked         EventType = "blocked"          // Side blocked all damage, or "both" defended
+	EventPassed          EventType = "passed"           // Both sides passed; PassCount counts towards a stalemate
+	EventSacrificed      EventType = "sacrificed"       // Side traded HPLost for StaminaGained
+	EventSurrendered     EventType = "surrendered"      // Side gave up its active Pokemon in a 5v5 battle
+	EventKnockedOut      EventType = "knocked_out"      // Side's Pokemon was knocked out
+	EventSwitched        EventType = "switched"         // Side brought Pokemon in
+	EventRoundStarted    EventType = "round_started"    // Side had to send Pokemon in, starting Round
+	EventBattleEnded     EventType = "battle_ended"     // The battle is over; Winner is "player", "ai" or "draw"
+	EventStatusApplied   EventType = "status_applied"   // Side's Pokemon got Status from the move that hit it
+	EventStatusDamage    EventType = "status_damage"    // Side's Pokemon lost Damage HP to its Status
+	EventCantMove        EventType = "cant_move"        // Side's Pokemon couldn't move this turn because of its Status
+	EventStatusCured     EventType = "status_cured"     // Side's Pokemon woke up or thawed out
+	EventHealed          EventType = "healed"           // Side's Pokemon restored HPGained with a support move, from the Field terrain or its held Item
+	EventStaminaRestored EventType = "stamina_restored" // Side's Pokemon restored StaminaGained with a support move or its held Item
+	EventStatChanged     EventType = "stat_changed"     // Side's Pokemon's Stat was raised or lowered by Stages (0 when it can't go further)
+	EventFieldStarted    EventType = "field_started"    // Side's move put the weather or terrain Field in play
+	EventFieldEnded      EventType = "field_ended"      // The weather or terrain Field ran out
+	EventWeatherDamage   EventType = "weather_damage"   // Side's Pokemon lost Damage HP to the weather Field
+	EventItemUsed        EventType = "item_used"        // Side's Pokemon used up its held Item
+	EventAbility         EventType = "ability"          // Side's Pokemon's Ability made it immune to a move, or hurt its attacker for Damage
+	EventBagItem         EventType = "bag_item"         // Side used the battle Item from its bag on its Pokemon, restoring HPGained or StaminaGained or curing Status
+)
+
+// Reasons a battle can end
+const (
+	EndKnockout      = "knockout"        // The last Pokemon of a side (or both) was knocked out
+	EndSurrender     = "surrender"       // A side surrendered a 1v1 battle
+	EndNoPokemonLeft = "no_pokemon_left" // A side surrendered its last Pokemon in a 5v5 battle
+	EndStalemate     = "stalemate"       // Both sides kept passing
+)
+
+// SideBoth is the Side of events that concern both players
+const SideBoth = "both"
+
+// Event is one thing that happened during a battle. Only the fields relevant
+// to its Type are set.
+type Event struct {
+	Type          EventType `json:"type"`
+	Mode          string    `json:"mode,omitempty"`
+	Side          string    `json:"side,omitempty"` // "player", "ai" or "both"
+	Pokemon       string    `json:"pokemon,omitempty"`
+	Move          string    `json:"move,omitempty"` // attack, defend, pass or item
+	MoveName      string    `json:"move_name,omitempty"`
+	Damage        int       `json:"damage,omitempty"`
+	AfterDefense  bool      `json:"after_defense,omitempty"`
+	HPLost        int       `json:"hp_lost,omitempty"`
+	HPGained      int       `json:"hp_gained,omitempty"`
+	StaminaGained int       `json:"stamina_gained,omitempty"`
+	PassCount     int       `json:"pass_count,omitempty"`
+	Round         int       `json:"round,omitempty"`
+	Winner        string    `json:"winner,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+	Status        string    `json:"status,omitempty"` // Status condition, for status events
+	Stat          string    `json:"stat,omitempty"`   // Stat changed by a support move
+	Stages        int       `json:"stages,omitempty"`
+	Field         string    `json:"field,omitempty"` // Weather or terrain, for field events
+	Item          string    `json:"item,omitempty"`  // Held or battle item behind the event
+	Ability       string    `json:"ability,omitempty"`
+}
+
+// sideName is how a side is named in log messages
+func sideName(side string) string {
+	if side == "ai" {
+		return "AI"
+	}
+	return "Player"
+}
+
+// opponent returns the other side of a battle
+func opponent(side string) string {
+	if side == "ai" {
+		return "player"
+	}
+	return "ai"
+}
+
+// Message renders the event as a battle log line. Events that the log has
+// never shown on their own, such as a battle ending by knockout, render as "".
+func (e Event) Message() string {
+	switch e.Type {
+	case EventBattleStarted:
+		return fmt.Sprintf("Battle started! Mode: %s", e.Mode)
+	case EventMoveChosen:
+		if e.Move == "attack" {
+			return fmt.Sprintf("%s chose to attack with %s.", sideName(e.Side), e.MoveName)
+		}
+		if e.Move == "item" {
+			return fmt.Sprintf("%s chose to use a %s.", sideName(e.Side), pokemon.HeldItemName(e.Item))
+		}
+		return fmt.Sprintf("%s chose %s.", sideName(e.Side), e.Move)
+	case EventDamageDealt:
+		if e.AfterDefense {
+			return fmt.Sprintf("%s dealt %d damage to %s (after defense).", sideName(e.Side), e.Damage, sideName(opponent(e.Side)))
+		}
+		return fmt.Sprintf("%s dealt %d damage to %s.", sideName(e.Side), e.Damage, sideName(opponent(e.Side)))
+	case EventMovedFirst:
+		return fmt.Sprintf("%s's %s is faster and attacks first!", sideName(e.Side), e.Pokemon)
+	case EventMissed:
+		return fmt.Sprintf("%s's attack missed!", sideName(e.Side))
+	case EventCriticalHit:
+		return fmt.Sprintf("A critical hit by %s!", sideName(e.Side))
+	case EventBlocked:
+		if e.Side == SideBoth {
+			return "Both defended. No damage dealt."
+		}
+		return fmt.Sprintf("%s blocked all damage!", sideName(e.Side))
+	case EventPassed:
+		return fmt.Sprintf("Both passed. Nothing happened! (Pass count: %d/3)", e.PassCount)
+	case EventSacrificed:
+		return fmt.Sprintf("%s sacrificed %d HP and gained %d stamina.", sideName(e.Side), e.HPLost, e.StaminaGained)
+	case EventSurrendered:
+		return fmt.Sprintf("%s surrendered! %s was knocked out!", sideName(e.Side), e.Pokemon)
+	case EventKnockedOut:
+		return fmt.Sprintf("%s's %s was knocked out!", sideName(e.Side), e.Pokemon)
+	case EventSwitched:
+		if e.Side == "ai" {
+			return fmt.Sprintf("AI switched to %s.", e.Pokemon)
+		}
+		return fmt.Sprintf("Switched to %s", e.Pokemon)
+	case EventRoundStarted:
+		return fmt.Sprintf("%s must switch to another Pokemon. Round %d begins.", sideName(e.Side), e.Round)
+	case EventBattleEnded:
+		switch e.Reason {
+		case EndSurrender:
+			return fmt.Sprintf("%s surrendered! %s wins the battle!", sideName(opponent(e.Winner)), sideName(e.Winner))
+		case EndNoPokemonLeft:
+			return fmt.Sprintf("%s has no Pokemon left! %s wins the battle!", sideName(opponent(e.Winner)), sideName(e.Winner))
+		case EndStalemate:
+			return "Stalemate! Both players passed 3 times in a row. Battle ends in a draw!"
+		}
+	case EventStatusApplied:
+		return fmt.Sprintf("%s's %s %s", sideName(e.Side), e.Pokemon, statusAppliedText[e.Status])
+	case EventStatusDamage:
+		return fmt.Sprintf("%s's %s is hurt by its %s! (-%d HP)", sideName(e.Side), e.Pokemon, e.Status, e.Damage)
+	case EventCantMove:
+		return fmt.Sprintf("%s's %s %s", sideName(e.Side), e.Pokemon, cantMoveText[e.Status])
+	case EventStatusCured:
+		if e.Status == pokemon.StatusSleep {
+			return fmt.Sprintf("%s's %s woke up!", sideName(e.Side), e.Pokemon)
+		}
+		return fmt.Sprintf("%s's %s thawed out!", sideName(e.Side), e.Pokemon)
+	case EventHealed:
+		if e.Item != "" {
+			return fmt.Sprintf("%s's %s restored %d HP with its %s.", sideName(e.Side), e.Pokemon, e.HPGained, pokemon.HeldItemName(e.Item))
+		}
+		return fmt.Sprintf("%s's %s restored %d HP.", sideName(e.Side), e.Pokemon, e.HPGained)
+	case EventStaminaRestored:
+		if e.Item != "" {
+			return fmt.Sprintf("%s's %s restored %d stamina with its %s.", sideName(e.Side), e.Pokemon, e.StaminaGained, pokemon.HeldItemName(e.Item))
+		}
+		return fmt.Sprintf("%s's %s restored %d stamina.", sideName(e.Side), e.Pokemon, e.StaminaGained)
+	case EventStatChanged:
+		return fmt.Sprintf("%s's %s's %s %s", sideName(e.Side), e.Pokemon, strings.ReplaceAll(e.Stat, "-", " "), statChangeText(e.Stages))
+	case EventFieldStarted:
+		return fieldText[e.Field].started
+	case EventFieldEnded:
+		return fieldText[e.Field].ended
+	case EventWeatherDamage:
+		return fmt.Sprintf("%s's %s is %s (-%d HP)", sideName(e.Side), e.Pokemon, fieldText[e.Field].damage, e.Damage)
+	case EventItemUsed:
+		return fmt.Sprintf("%s's %s hung on using its %s!", sideName(e.Side), e.Pokemon, pokemon.HeldItemName(e.Item))
+	case EventBagItem:
+		switch {
+		case e.HPGained > 0:
+			return fmt.Sprintf("%s's %s restored %d HP with a %s.", sideName(e.Side), e.Pokemon, e.HPGained, pokemon.HeldItemName(e.Item))
+		case e.StaminaGained > 0:
+			return fmt.Sprintf("%s's %s restored %d stamina with a %s.", sideName(e.Side), e.Pokemon, e.StaminaGained, pokemon.HeldItemName(e.Item))
+		case e.Status != "":
+			return fmt.Sprintf("%s's %s was cured of its %s with a %s.", sideName(e.Side), e.Pokemon, e.Status, pokemon.HeldItemName(e.Item))
+		}
+		return fmt.Sprintf("%s used a %s on %s.", sideName(e.Side), pokemon.HeldItemName(e.Item), e.Pokemon)
+	case EventAbility:
+		if e.Damage > 0 {
+			return fmt.Sprintf("%s's %s hurt the attacker with its %s (-%d HP)", sideName(e.Side), e.Pokemon, pokemon.AbilityName(e.Ability), e.Damage)
+		}
+		return fmt.Sprintf("%s's %s is immune thanks to its %s!", sideName(e.Side), e.Pokemon, pokemon.AbilityName(e.Ability))
+	}
+	return ""
+}
+
+// statChangeText finishes the log line of a stat changing by stages
+func statChangeText(stages int) string {
+	switch {
+	case stages >= 3:
+		return "rose drastically!"
+	case stages == 2:
+		return "rose sharply!"
+	case stages == 1:
+		return "rose!"
+	case stages == -1:
+		return "fell!"
+	case stages == -2:
+		return "harshly fell!"
+	case stages <= -3:
+		return "severely fell!"
+	}
+	return "won't go any further!"
+}
+
+// statusAppliedText and cantMoveText finish the log lines of status events
+var (
+	statusAppliedText = map[string]string{
+		pokemon.StatusBurn:      "was burned!",
+		pokemon.StatusPoison:    "was poisoned!",
+		pokemon.StatusParalysis: "is paralyzed! It may be unable to move!",
+		pokemon.StatusSleep:     "fell asleep!",
+		pokemon.StatusFreeze:    "was frozen solid!",
+	}
+	cantMoveText = map[string]string{
+		pokemon.StatusParalysis: "is paralyzed! It can't move!",
+		pokemon.StatusSleep:     "is fast asleep.",
+		pokemon.StatusFreeze:    "is frozen solid!",
+	}
+)
+
+// fieldText are the log lines of weather and terrain starting, ending and
+// hurting a Pokemon
+var fieldText = map[string]struct{ started, ended, damage string }{
+	pokemon.WeatherSun:       {"The sunlight turned harsh!", "The harsh sunlight faded.", ""},
+	pokemon.WeatherRain:      {"It started to rain!", "The rain stopped.", ""},
+	pokemon.WeatherSandstorm: {"A sandstorm kicked up!", "The sandstorm subsided.", "buffeted by the sandstorm!"},
+	pokemon.WeatherHail:      {"It started to hail!", "The hail stopped.", "pelted by hail!"},
+	pokemon.TerrainElectric:  {"An electric current ran across the battlefield!", "The electricity disappeared from the battlefield.", ""},
+	pokemon.TerrainGrassy:    {"Grass grew to cover the battlefield!", "The grass disappeared from the battlefield.", ""},
+	pokemon.TerrainPsychic:   {"The battlefield got weird!", "The weirdness disappeared from the battlefield.", ""},
+	pokemon.TerrainMisty:     {"Mist swirled around the battlefield!", "The mist disappeared from the battlefield.", ""},
+}
+
+// EventLog renders events as the battle log lines they have always produced
+func EventLog(events []Event) []string {
+	logEntries := []string{}
+	for _, e := range events {
+		if msg := e.Message(); msg != "" {
+			logEntries = append(logEntries, msg)
+		}
+	}
+	return logEntries
+}
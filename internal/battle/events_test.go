@@ -0,0 +1,59 @@
+package battle
+
+import "testing"
+
+func TestEventMessagesMatchLegacyLog(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{Event{Type: EventBattleStarted, Mode: "5v5"}, "Battle started! Mode: 5v5"},
+		{Event{Type: EventMoveChosen, Side: "player", Move: "attack", MoveName: "thunderbolt"}, "Player chose to attack with thunderbolt."},
+		{Event{Type: EventMoveChosen, Side: "ai", Move: "defend"}, "AI chose defend."},
+		{Event{Type: EventDamageDealt, Side: "player", Damage: 21}, "Player dealt 21 damage to AI."},
+		{Event{Type: EventDamageDealt, Side: "ai", Damage: 7, AfterDefense: true}, "AI dealt 7 damage to Player (after defense)."},
+		{Event{Type: EventBlocked, Side: "ai"}, "AI blocked all damage!"},
+		{Event{Type: EventBlocked, Side: SideBoth}, "Both defended. No damage dealt."},
+		{Event{Type: EventPassed, Side: SideBoth, PassCount: 2}, "Both passed. Nothing happened! (Pass count: 2/3)"},
+		{Event{Type: EventSacrificed, Side: "ai", HPLost: 10, StaminaGained: 43}, "AI sacrificed 10 HP and gained 43 stamina."},
+		{Event{Type: EventSurrendered, Side: "player", Pokemon: "pikachu"}, "Player surrendered! pikachu was knocked out!"},
+		{Event{Type: EventKnockedOut, Side: "ai", Pokemon: "squirtle"}, "AI's squirtle was knocked out!"},
+		{Event{Type: EventSwitched, Side: "ai", Pokemon: "onix"}, "AI switched to onix."},
+		{Event{Type: EventSwitched, Side: "player", Pokemon: "eevee"}, "Switched to eevee"},
+		{Event{Type: EventRoundStarted, Side: "player", Pokemon: "eevee", Round: 2}, "Player must switch to another Pokemon. Round 2 begins."},
+		{Event{Type: EventBattleEnded, Winner: "ai", Reason: EndSurrender}, "Player surrendered! AI wins the battle!"},
+		{Event{Type: EventBattleEnded, Winner: "player", Reason: EndNoPokemonLeft}, "AI has no Pokemon left! Player wins the battle!"},
+		{Event{Type: EventBattleEnded, Winner: "draw", Reason: EndStalemate}, "Stalemate! Both players passed 3 times in a row. Battle ends in a draw!"},
+		{Event{Type: EventBattleEnded, Winner: "player", Reason: EndKnockout}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.event.Message(); got != tt.want {
+			t.Errorf("%s message = %q, want %q", tt.event.Type, got, tt.want)
+		}
+	}
+}
+
+func TestProcessMoveEndsWithBattleEndedEvent(t *testing.T) {
+	bs := newSeededBattle(t, 42)
+
+	var last []Event
+	for _, step := range testScript {
+		if bs.BattleOver {
+			break
+		}
+		events, err := ProcessMove(bs, step.move, step.moveIdx)
+		if err != nil {
+			continue
+		}
+		last = events
+	}
+
+	if len(last) == 0 {
+		t.Fatal("expected events from the final move")
+	}
+	end := last[len(last)-1]
+	if end.Type != EventBattleEnded || end.Winner != bs.Winner || end.Reason != EndKnockout {
+		t.Errorf("unexpected final event: %+v (winner %s)", end, bs.Winner)
+	}
+}
@@ -0,0 +1,153 @@
+package battle
+
+import (
+	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/rng"
+	"slices"
+)
+
+// How weather and terrain play out
+const (
+	fieldTurns           = 5  // Weather and terrain set by a move last 5 turns, counting the one it was used on
+	weatherDamageDivisor = 16 // Sandstorm and hail hurt the Pokemon they don't spare by 1/16 of their max HP every turn
+	grassyHealDivisor    = 16 // Grassy terrain heals 1/16 of max HP every turn
+)
+
+// FieldRandom is the Rules.Field that starts a battle with a weather or terrain
+// drawn from its seed
+const FieldRandom = "random"
+
+// fieldEffects are the weathers and terrains a battle can start with
+var fieldEffects = []string{
+	pokemon.WeatherSun, pokemon.WeatherRain, pokemon.WeatherSandstorm, pokemon.WeatherHail,
+	pokemon.TerrainElectric, pokemon.TerrainGrassy, pokemon.TerrainPsychic, pokemon.TerrainMisty,
+}
+
+// Field is the weather and terrain in play. A weather and a terrain can be in
+// play at the same time; a new one replaces the one of its kind.
+type Field struct {
+	Weather      string `json:"weather,omitempty"`
+	WeatherTurns int    `json:"weather_turns,omitempty"` // Turns left; 0 while it lasts the whole battle
+	Terrain      string `json:"terrain,omitempty"`
+	TerrainTurns int    `json:"terrain_turns,omitempty"` // Turns left; 0 while it lasts the whole battle
+}
+
+// fieldBoosts are how much weather and terrain multiply the damage of the
+// moves of each type
+var fieldBoosts = map[string]map[string]float64{
+	pokemon.WeatherSun:      {"fire": 1.5, "water": 0.5},
+	pokemon.WeatherRain:     {"water": 1.5, "fire": 0.5},
+	pokemon.TerrainElectric: {"electric": 1.3},
+	pokemon.TerrainGrassy:   {"grass": 1.3},
+	pokemon.TerrainPsychic:  {"psychic": 1.3},
+	pokemon.TerrainMisty:    {"dragon": 0.5},
+}
+
+// weatherImmunities lists the types sandstorm and hail don't hurt
+var weatherImmunities = map[string][]string{
+	pokemon.WeatherSandstorm: {"rock", "ground", "steel"},
+	pokemon.WeatherHail:      {"ice"},
+}
+
+// startingField returns the field a battle played with rules starts with
+func startingField(rules Rules) Field {
+	var f Field
+	if rules.Field != "" {
+		f.set(rules.Field, 0)
+	}
+	return f
+}
+
+// randomField draws the weather or terrain a battle with seed starts with
+// under FieldRandom. It has a source of its own so that the battle's rolls
+// stay the same.
+func randomField(seed int64) string {
+	return fieldEffects[rng.New(seed).Intn(len(fieldEffects))]
+}
+
+// set puts effect in play for turns turns, or the whole battle for 0
+func (f *Field) set(effect string, turns int) {
+	if pokemon.IsTerrain(effect) {
+		f.Terrain, f.TerrainTurns = effect, turns
+		return
+	}
+	f.Weather, f.WeatherTurns = effect, turns
+}
+
+// multiplier returns how much the field multiplies the damage of a move of moveType
+func (f Field) multiplier(moveType string) float64 {
+	m := 1.0
+	if boost, ok := fieldBoosts[f.Weather][moveType]; ok {
+		m *= boost
+	}
+	if boost, ok := fieldBoosts[f.Terrain][moveType]; ok {
+		m *= boost
+	}
+	return m
+}
+
+// hurts reports whether the weather hurts a Pokemon of types at the end of the turn
+func (f Field) hurts(types []string) bool {
+	immune, ok := weatherImmunities[f.Weather]
+	if !ok {
+		return false
+	}
+	for _, t := range types {
+		if slices.Contains(immune, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// useFieldMove puts the weather or terrain of side's support move in play
+func useFieldMove(bs *BattleState, side string, move pokemon.Move) []Event {
+	if move.Field == "" {
+		return nil
+	}
+	bs.Field.set(move.Field, fieldTurns)
+	return []Event{{Type: EventFieldStarted, Side: side, Field: move.Field}}
+}
+
+// fieldDamage takes the end of turn damage of sandstorm and hail from, and
+// gives the healing of grassy terrain to, the copy of side's card the turn is
+// resolved with
+func fieldDamage(f Field, side string, card *pokemon.Card) []Event {
+	if card.HP <= 0 {
+		return nil
+	}
+
+	var events []Event
+	if f.hurts(card.Types) {
+		damage := max(card.HPMax/weatherDamageDivisor, 1)
+		card.HP -= damage
+		events = append(events, Event{Type: EventWeatherDamage, Side: side, Pokemon: card.Name, Field: f.Weather, Damage: damage})
+	}
+	if f.Terrain == pokemon.TerrainGrassy && card.HP > 0 && card.HP < card.HPMax {
+		healed := min(max(card.HPMax/grassyHealDivisor, 1), card.HPMax-card.HP)
+		card.HP += healed
+		events = append(events, Event{Type: EventHealed, Side: side, Pokemon: card.Name, Field: f.Terrain, HPGained: healed})
+	}
+	return events
+}
+
+// tick counts down the turns left of the weather and terrain set by moves,
+// ending the ones that run out
+func (f *Field) tick() []Event {
+	var events []Event
+	if f.WeatherTurns > 0 {
+		f.WeatherTurns--
+		if f.WeatherTurns == 0 {
+			events = append(events, Event{Type: EventFieldEnded, Side: SideBoth, Field: f.Weather})
+			f.Weather = ""
+		}
+	}
+	if f.TerrainTurns > 0 {
+		f.TerrainTurns--
+		if f.TerrainTurns == 0 {
+			events = append(events, Event{Type: EventFieldEnded, Side: SideBoth, Field: f.Terrain})
+			f.Terrain = ""
+		}
+	}
+	return events
+}
@@ -0,0 +1,168 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+var rainDance = pokemon.Move{Name: "rain-dance", Type: "water", StaminaCost: pokemon.SupportMoveStaminaCost, Field: pokemon.WeatherRain}
+
+func TestFieldMultiplier(t *testing.T) {
+	tests := []struct {
+		field    Field
+		moveType string
+		want     float64
+	}{
+		{Field{}, "fire", 1},
+		{Field{Weather: pokemon.WeatherSun}, "fire", 1.5},
+		{Field{Weather: pokemon.WeatherSun}, "water", 0.5},
+		{Field{Weather: pokemon.WeatherRain}, "grass", 1},
+		{Field{Terrain: pokemon.TerrainMisty}, "dragon", 0.5},
+		{Field{Weather: pokemon.WeatherRain, Terrain: pokemon.TerrainElectric}, "electric", 1.3},
+	}
+	for _, tt := range tests {
+		if got := tt.field.multiplier(tt.moveType); got != tt.want {
+			t.Errorf("%+v: %s moves x%v, want x%v", tt.field, tt.moveType, got, tt.want)
+		}
+	}
+}
+
+func TestRainBoostsWaterMoves(t *testing.T) {
+	damage := map[string]int{}
+	for _, field := range []string{"", pokemon.WeatherRain} {
+		bs := newSeededBattle(t, 6)
+		if err := bs.SetRules(Rules{Field: field}); err != nil {
+			t.Fatalf("SetRules failed: %v", err)
+		}
+		if err := bs.SetStrategy("attacker", attackingAI); err != nil {
+			t.Fatalf("SetStrategy failed: %v", err)
+		}
+		hp := bs.PlayerDeck[0].HP
+		if _, err := ProcessMove(bs, "pass", nil); err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+		damage[field] = hp - bs.PlayerDeck[0].HP
+	}
+
+	if want := int(float64(damage[""]) * 1.5); damage[pokemon.WeatherRain] != want {
+		t.Errorf("water-gun dealt %d in the rain, want %d (1.5 x %d)", damage[pokemon.WeatherRain], want, damage[""])
+	}
+}
+
+func TestSandstormChipDamage(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	if err := bs.SetRules(Rules{Field: pokemon.WeatherSandstorm}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	bs.PlayerDeck[0].Types = []string{"rock"}
+	hp := bs.AIDeck[0].HP
+
+	events, err := ProcessMove(bs, "pass", nil)
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	hurt := eventsOfType(events, EventWeatherDamage)
+	if len(hurt) != 1 || hurt[0].Side != "ai" {
+		t.Fatalf("weather_damage events %+v, want one for the AI only", hurt)
+	}
+	if lost, want := hp-bs.AIDeck[0].HP, bs.AIDeck[0].HPMax/weatherDamageDivisor; lost != want || hurt[0].Damage != want {
+		t.Errorf("sandstorm took %d HP, want %d", lost, want)
+	}
+	if bs.Field.Weather != pokemon.WeatherSandstorm {
+		t.Error("a starting sandstorm should last the whole battle")
+	}
+}
+
+func TestFieldMoveLastsFiveTurns(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	bs.PlayerDeck[0].Moves[1] = rainDance
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+
+	events, err := ProcessMove(bs, "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if started := eventsOfType(events, EventFieldStarted); len(started) != 1 || started[0].Field != pokemon.WeatherRain {
+		t.Errorf("field_started events %+v, want one for rain", started)
+	}
+	// The turn it was used on counts
+	if bs.Field.Weather != pokemon.WeatherRain || bs.Field.WeatherTurns != fieldTurns-1 {
+		t.Fatalf("field %+v after rain-dance, want rain with %d turns left", bs.Field, fieldTurns-1)
+	}
+
+	for range fieldTurns - 2 {
+		bs.Field.tick()
+	}
+	if bs.Field.Weather != pokemon.WeatherRain {
+		t.Fatal("rain ended early")
+	}
+	if ended := bs.Field.tick(); len(ended) != 1 || ended[0].Type != EventFieldEnded || bs.Field.Weather != "" {
+		t.Errorf("rain still in play after %d turns: %+v", fieldTurns, bs.Field)
+	}
+}
+
+func TestRandomFieldReplays(t *testing.T) {
+	seen := map[string]bool{}
+	for seed := int64(1); seed <= 20; seed++ {
+		bs := newSeededBattle(t, seed)
+		if err := bs.SetRules(Rules{Field: FieldRandom}); err != nil {
+			t.Fatalf("SetRules failed: %v", err)
+		}
+		if bs.Rules.Field != randomField(seed) {
+			t.Errorf("seed %d: random field %q, then %q", seed, bs.Rules.Field, randomField(seed))
+		}
+		seen[bs.Rules.Field] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("random fields always the same: %v", seen)
+	}
+
+	bs := newBenchmarkBattle(t, 9)
+	if err := bs.SetRules(Rules{Field: FieldRandom}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		if _, err := PlayTurn(bs, DifficultyStrategy(DifficultyNormal)); err != nil {
+			t.Fatalf("PlayTurn failed: %v", err)
+		}
+	}
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	last := frames[len(frames)-1].State
+	if !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Field != bs.Field || last.Winner != bs.Winner {
+		t.Error("replay does not reproduce the battle")
+	}
+}
+
+func TestSetRulesRejectsUnknownField(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	if err := bs.SetRules(Rules{Field: "fog"}); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestAIValuesFieldMoves(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	ai := &bs.AIDeck[0]
+	ai.Moves = append(ai.Moves, rainDance)
+	rainIdx := len(ai.Moves) - 1
+	// Rain would boost the AI's water move and weaken the player's fire move
+	bs.PlayerDeck[0].Moves[1] = pokemon.Move{Name: "ember", Power: 40, StaminaCost: 13, Type: "fire"}
+
+	if move, idx := GetEnhancedAIMove(bs, "defend"); move != "attack" || idx != rainIdx {
+		t.Errorf("the AI chose %s %d, want to make it rain", move, idx)
+	}
+
+	bs.Field.set(pokemon.WeatherRain, fieldTurns)
+	if _, idx := GetEnhancedAIMove(bs, "defend"); idx == rainIdx {
+		t.Error("the AI made it rain while it was already raining")
+	}
+}
@@ -2,35 +2,66 @@
 package battle
 
 import (
+	"cmp"
+	"maps"
+	"math/rand"
 	"pokemon-cli/game/models"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/rng"
 	"time"
 )
 
 // BattleState represents the complete state of a battle session
 // This is the enhanced model that supports both 1v1 and 5v5 modes
 type BattleState struct {
-	ID                   string       `json:"id"`
-	UserID               int          `json:"user_id"`
-	Mode                 string       `json:"mode"` // "1v1" or "5v5"
-	PlayerDeck           []BattleCard `json:"player_deck"`
-	AIDeck               []BattleCard `json:"ai_deck"`
-	PlayerActiveIdx      int          `json:"player_active_idx"`
-	AIActiveIdx          int          `json:"ai_active_idx"`
-	TurnNumber           int          `json:"turn_number"`
-	RoundNumber          int          `json:"round_number"` // For 5v5 battles
-	WhoseTurn            string       `json:"whose_turn"`   // "player" or "ai"
-	BattleOver           bool         `json:"battle_over"`
-	Winner               string       `json:"winner"`             // "player", "ai", "draw"
-	RewardClaimed        bool         `json:"reward_claimed"`     // Track if 5v5 reward has been claimed
-	ConsecutivePasses    int          `json:"consecutive_passes"` // Track consecutive passes by both players
-	PendingPlayerMove    string       `json:"pending_player_move"`
-	PendingPlayerMoveIdx int          `json:"pending_player_move_idx"`
-	PendingAIMove        string       `json:"pending_ai_move"`
-	PendingAIMoveIdx     int          `json:"pending_ai_move_idx"`
-	SacrificeCount       map[int]int  `json:"sacrifice_count"` // Track sacrifices per Pokemon
-	CreatedAt            time.Time    `json:"created_at"`
-	UpdatedAt            time.Time    `json:"updated_at"`
+	ID                   string         `json:"id"`
+	UserID               int            `json:"user_id"`
+	OpponentUserID       int            `json:"opponent_user_id,omitempty"` // Second player of a PvP battle, who plays the "ai" side
+	PvPStatus            string         `json:"pvp_status,omitempty"`       // PvPPending or PvPActive; empty for battles against the AI
+	AIDifficulty         string         `json:"ai_difficulty,omitempty"`    // Strategy the AI plays with; empty means normal
+	AIBot                string         `json:"ai_bot,omitempty"`           // Name of the Strategy playing the AI side instead, if any
+	Rules                Rules          `json:"rules"`                      // Optional rules the battle is played with
+	Field                Field          `json:"field"`                      // Weather and terrain in play
+	Mode                 string         `json:"mode"`                       // "1v1" or "5v5"
+	PlayerDeck           []BattleCard   `json:"player_deck"`
+	AIDeck               []BattleCard   `json:"ai_deck"`
+	PlayerActiveIdx      int            `json:"player_active_idx"`
+	AIActiveIdx          int            `json:"ai_active_idx"`
+	TurnNumber           int            `json:"turn_number"`
+	RoundNumber          int            `json:"round_number"` // For 5v5 battles
+	WhoseTurn            string         `json:"whose_turn"`   // "player", "ai" or "both" (PvP)
+	BattleOver           bool           `json:"battle_over"`
+	Winner               string         `json:"winner"`             // "player", "ai", "draw"
+	RewardClaimed        bool           `json:"reward_claimed"`     // Track if 5v5 reward has been claimed
+	ConsecutivePasses    int            `json:"consecutive_passes"` // Track consecutive passes by both players
+	PendingPlayerMove    string         `json:"pending_player_move"`
+	PendingPlayerMoveIdx int            `json:"pending_player_move_idx"`
+	PendingAIMove        string         `json:"pending_ai_move"`
+	PendingAIMoveIdx     int            `json:"pending_ai_move_idx"`
+	SacrificeCount       map[int]int    `json:"sacrifice_count"`      // Sacrifices each of the player's Pokemon has made, by deck index
+	AISacrificeCount     map[int]int    `json:"ai_sacrifice_count"`   // Sacrifices each of the AI's (or PvP opponent's) Pokemon has made, by deck index
+	Bag                  map[string]int `json:"bag,omitempty"`        // Battle items the player brought, by name; used ones are taken out
+	ItemsUsed            int            `json:"items_used,omitempty"` // Battle items the player has used, up to the item limit
+	Seed                 int64          `json:"seed"`                 // Seed the battle's random source started from
+	RNG                  *rng.Source    `json:"rng"`                  // Current random source state, persisted between moves
+	Replay               *Replay        `json:"replay,omitempty"`     // Recording of every action taken so far
+	Turns                []TurnRecord   `json:"turns,omitempty"`      // Turn-by-turn timeline of the battle
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+
+	// roller replaces the battle's random source while a search AI plays the
+	// battle forward on a clone; it is never set on a live battle
+	roller rng.Rand
+
+	// aiSwitchedIn remembers the Pokemon the AI switched to during the turn being
+	// processed, so it switches at most once and the turn record follows the
+	// Pokemon that actually fought
+	aiSwitchedIn *turnSnapshot
+
+	// strategy plays the AI side in place of the built-in AI, and decisions
+	// collects what it decided during the action being processed
+	strategy  Strategy
+	decisions []Decision
 }
 
 // BattleCard represents a Pokemon card in battle with current state
@@ -43,12 +74,19 @@ type BattleCard struct {
 	StaminaMax   int            `json:"stamina_max"`
 	Attack       int            `json:"attack"`
 	Defense      int            `json:"defense"`
+	SpAttack     int            `json:"sp_attack"`
+	SpDefense    int            `json:"sp_defense"`
 	Speed        int            `json:"speed"`
 	Types        []string       `json:"types"`
 	Moves        []pokemon.Move `json:"moves"`
 	Sprite       string         `json:"sprite"`
 	IsKnockedOut bool           `json:"is_knocked_out"`
 	Level        int            `json:"level"`
+	Status       string         `json:"status,omitempty"`       // Status condition, if any; it stays through switching
+	StatusTurns  int            `json:"status_turns,omitempty"` // Turns left asleep
+	Stages       StatStages     `json:"stages"`                 // Stat stages from support moves; they reset on switching out
+	HeldItem     string         `json:"held_item,omitempty"`    // Held item the Pokemon brought into battle, until it is used up
+	Ability      string         `json:"ability,omitempty"`      // Passive ability of the Pokemon's species
 }
 
 // TurnState contains web-only turn-based fields (legacy support)
@@ -67,7 +105,7 @@ func ConvertToBattleCard(card pokemon.Card, fallbackID int) BattleCard {
 	if cardID == 0 {
 		cardID = fallbackID
 	}
-	
+
 	return BattleCard{
 		CardID:       cardID,
 		Name:         card.Name,
@@ -77,29 +115,91 @@ func ConvertToBattleCard(card pokemon.Card, fallbackID int) BattleCard {
 		StaminaMax:   card.Speed * 2,
 		Attack:       card.Attack,
 		Defense:      card.Defense,
+		SpAttack:     cmp.Or(card.SpAttack, card.Attack), // Cards from before special stats use their physical ones
+		SpDefense:    cmp.Or(card.SpDefense, card.Defense),
 		Speed:        card.Speed,
 		Types:        card.Types,
 		Moves:        card.Moves,
 		Sprite:       card.Sprite,
 		IsKnockedOut: card.HP <= 0,
 		Level:        card.Level,
+		HeldItem:     card.HeldItem,
+		Ability:      card.Ability,
 	}
 }
 
 // ConvertFromBattleCard converts a BattleCard back to pokemon.Card
 func ConvertFromBattleCard(bc BattleCard) pokemon.Card {
 	return pokemon.Card{
-		Name:    bc.Name,
-		HP:      bc.HP,
-		HPMax:   bc.HPMax,
-		Stamina: bc.Stamina,
-		Attack:  bc.Attack,
-		Defense: bc.Defense,
-		Speed:   bc.Speed,
-		Types:   bc.Types,
-		Moves:   bc.Moves,
-		Sprite:  bc.Sprite,
+		Name:      bc.Name,
+		HP:        bc.HP,
+		HPMax:     bc.HPMax,
+		Stamina:   bc.Stamina,
+		Attack:    bc.Attack,
+		Defense:   bc.Defense,
+		SpAttack:  bc.SpAttack,
+		SpDefense: bc.SpDefense,
+		Speed:     bc.Speed,
+		Types:     bc.Types,
+		Moves:     bc.Moves,
+		Sprite:    bc.Sprite,
+		HeldItem:  bc.HeldItem,
+		Ability:   bc.Ability,
+	}
+}
+
+// Rand returns the battle's random source. Every roll made during the battle
+// (damage, AI choices) must go through it so the battle can be replayed from its seed.
+func (bs *BattleState) Rand() rng.Rand {
+	if bs.roller != nil {
+		return bs.roller
+	}
+	if bs.RNG == nil {
+		// Sessions saved before seeding existed resume from their (zero) seed
+		bs.RNG = rng.NewSource(bs.Seed)
 	}
+	return rand.New(bs.RNG)
+}
+
+// Clone returns a deep copy of the battle state. The copy does not record a
+// replay or keep the battle's Strategy, so it can be played forward without
+// touching the original.
+func (bs *BattleState) Clone() *BattleState {
+	clone := *bs
+	clone.PlayerDeck = cloneDeck(bs.PlayerDeck)
+	clone.AIDeck = cloneDeck(bs.AIDeck)
+	clone.SacrificeCount = make(map[int]int, len(bs.SacrificeCount))
+	for idx, count := range bs.SacrificeCount {
+		clone.SacrificeCount[idx] = count
+	}
+	clone.AISacrificeCount = make(map[int]int, len(bs.AISacrificeCount))
+	for idx, count := range bs.AISacrificeCount {
+		clone.AISacrificeCount[idx] = count
+	}
+	clone.Bag = maps.Clone(bs.Bag)
+	if bs.RNG != nil {
+		rngState := *bs.RNG
+		clone.RNG = &rngState
+	}
+	clone.Turns = append([]TurnRecord(nil), bs.Turns...)
+	clone.Replay = nil
+	clone.strategy = nil
+	clone.decisions = nil
+	return &clone
+}
+
+// cloneDeck deep copies a deck of battle cards
+func cloneDeck(deck []BattleCard) []BattleCard {
+	if deck == nil {
+		return nil
+	}
+	clone := make([]BattleCard, len(deck))
+	for i, card := range deck {
+		card.Types = append([]string(nil), card.Types...)
+		card.Moves = append([]pokemon.Move(nil), card.Moves...)
+		clone[i] = card
+	}
+	return clone
 }
 
 // GetActivePlayerCard returns the active player's BattleCard
@@ -118,6 +218,51 @@ func (bs *BattleState) GetActiveAICard() *BattleCard {
 	return nil
 }
 
+// activeIdx returns a pointer to the active Pokemon index of side ("player" or "ai")
+func (bs *BattleState) activeIdx(side string) *int {
+	if side == "ai" {
+		return &bs.AIActiveIdx
+	}
+	return &bs.PlayerActiveIdx
+}
+
+// sacrificeCount returns the sacrifices each Pokemon of side ("player" or
+// "ai") has made, by deck index
+func (bs *BattleState) sacrificeCount(side string) map[int]int {
+	counts := &bs.SacrificeCount
+	if side == "ai" {
+		counts = &bs.AISacrificeCount
+	}
+	if *counts == nil {
+		*counts = make(map[int]int)
+	}
+	return *counts
+}
+
+// deckOf returns the deck of side ("player" or "ai")
+func (bs *BattleState) deckOf(side string) []BattleCard {
+	if side == "ai" {
+		return bs.AIDeck
+	}
+	return bs.PlayerDeck
+}
+
+// activeCard returns the active BattleCard of side ("player" or "ai")
+func (bs *BattleState) activeCard(side string) *BattleCard {
+	if side == "ai" {
+		return bs.GetActiveAICard()
+	}
+	return bs.GetActivePlayerCard()
+}
+
+// hasPokemonAlive checks if side ("player" or "ai") has any Pokemon with HP > 0
+func (bs *BattleState) hasPokemonAlive(side string) bool {
+	if side == "ai" {
+		return bs.HasAIPokemonAlive()
+	}
+	return bs.HasPlayerPokemonAlive()
+}
+
 // HasPlayerPokemonAlive checks if player has any Pokemon with HP > 0
 func (bs *BattleState) HasPlayerPokemonAlive() bool {
 	for _, card := range bs.PlayerDeck {
@@ -195,7 +340,7 @@ func buildWebState(state *models.GameState, turn *TurnState, logEntries []string
 }
 
 // BuildBattleResponse creates a response for the enhanced battle state
-func BuildBattleResponse(bs *BattleState, logEntries []string, hideAICards bool) map[string]any {
+func BuildBattleResponse(bs *BattleState, events []Event, hideAICards bool) map[string]any {
 	// Create a copy of the battle state for response
 	response := map[string]any{
 		"id":                bs.ID,
@@ -209,11 +354,29 @@ func BuildBattleResponse(bs *BattleState, logEntries []string, hideAICards bool)
 		"battle_over":       bs.BattleOver,
 		"winner":            bs.Winner,
 		"reward_claimed":    bs.RewardClaimed,
-		"log":               logEntries,
+		"log":               EventLog(events),
+		"events":            events,
 		"created_at":        bs.CreatedAt,
 		"updated_at":        bs.UpdatedAt,
 	}
 
+	if bs.IsPvP() {
+		response["opponent_user_id"] = bs.OpponentUserID
+		response["pvp_status"] = bs.PvPStatus
+	} else {
+		response["ai_difficulty"] = bs.Difficulty()
+		response["bag"] = bs.Bag
+		response["items_used"] = bs.ItemsUsed
+		response["item_limit"] = bs.ItemLimit()
+	}
+	response["rules"] = bs.Rules
+	response["field"] = bs.Field
+
+	// The seed would let a client predict upcoming rolls, so only reveal it once the battle is decided
+	if bs.BattleOver {
+		response["seed"] = bs.Seed
+	}
+
 	// Always show full player deck
 	response["player_deck"] = bs.PlayerDeck
 
@@ -232,12 +395,18 @@ func BuildBattleResponse(bs *BattleState, logEntries []string, hideAICards bool)
 					"stamina_max":    card.StaminaMax,
 					"attack":         card.Attack,
 					"defense":        card.Defense,
+					"sp_attack":      card.SpAttack,
+					"sp_defense":     card.SpDefense,
 					"speed":          card.Speed,
 					"types":          card.Types,
 					"moves":          card.Moves,
 					"sprite":         card.Sprite,
 					"is_knocked_out": card.IsKnockedOut,
 					"level":          card.Level,
+					"status":         card.Status,
+					"stages":         card.Stages,
+					"held_item":      card.HeldItem,
+					"ability":        card.Ability,
 					"is_active":      true,
 					"is_face_down":   false,
 				}
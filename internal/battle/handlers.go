@@ -3,17 +3,20 @@ package battle
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math/rand"
 	"pokemon-cli/game/models"
 	"pokemon-cli/internal/database"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/glicko2"
+	"pokemon-cli/pkg/rng"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,6 +26,7 @@ type Handler struct {
 	repo         *Repository         // Database repository for persistent storage
 	statsService StatsService        // Stats service for achievement checking
 	mu           sync.RWMutex        // Mutex for thread-safe access to legacy sessions
+	hub          *Hub                // WebSocket clients watching battles
 }
 
 // StatsService defines the interface for stats operations
@@ -42,6 +46,7 @@ func NewHandler(db *pgxpool.Pool, statsService StatsService) *Handler {
 		sessions:     make(map[string]*Session),
 		repo:         NewRepository(db),
 		statsService: statsService,
+		hub:          NewHub(),
 	}
 }
 
@@ -73,14 +78,18 @@ func ConvertPlayerCardToPokemonCard(dbCard database.PlayerCard) pokemon.Card {
 		Stamina:     stats.Stamina,
 		Defense:     stats.Defense,
 		Attack:      stats.Attack,
+		SpAttack:    stats.SpAttack,
+		SpDefense:   stats.SpDefense,
 		Speed:       stats.Speed,
-		Moves:       moves,
+		Moves:       pokemon.WithMoveEffects(moves),
 		Types:       types,
 		Sprite:      dbCard.Sprite,
 		Level:       dbCard.Level,
 		XP:          dbCard.XP,
 		IsLegendary: dbCard.IsLegendary,
 		IsMythical:  dbCard.IsMythical,
+		HeldItem:    dbCard.HeldItem,
+		Ability:     pokemon.SpeciesAbility(dbCard.PokemonName),
 	}
 }
 
@@ -104,6 +113,24 @@ func (h *Handler) DeleteBattleState(c *fiber.Ctx, id string) error {
 	return h.repo.DeleteBattleSession(c.Context(), id)
 }
 
+// buildDeck picks the Pokemon a user brings to a battle in mode from their configured deck
+func buildDeck(deckCards []database.PlayerCard, mode string, deckRand rng.Rand) []pokemon.Card {
+	var deck []pokemon.Card
+	if mode == "1v1" {
+		// For 1v1, select a random Pokemon from the deck
+		if len(deckCards) > 0 {
+			randomIdx := deckRand.Intn(len(deckCards))
+			deck = append(deck, ConvertPlayerCardToPokemonCard(deckCards[randomIdx]))
+		}
+	} else {
+		// For 5v5, use all cards in deck order
+		for i := 0; i < 5 && i < len(deckCards); i++ {
+			deck = append(deck, ConvertPlayerCardToPokemonCard(deckCards[i]))
+		}
+	}
+	return deck
+}
+
 // StartBattleEnhanced handles POST /api/battle/start with mode selection
 func (h *Handler) StartBattleEnhanced(c *fiber.Ctx) error {
 	// Get user ID from context (set by auth middleware)
@@ -118,7 +145,12 @@ func (h *Handler) StartBattleEnhanced(c *fiber.Ctx) error {
 	}
 
 	var req struct {
-		Mode string `json:"mode"` // "1v1" or "5v5"
+		Mode       string `json:"mode"`          // "1v1" or "5v5"
+		Difficulty string `json:"ai_difficulty"` // easy, normal, hard or expert; defaults to normal
+		SpeedOrder bool   `json:"speed_order"`   // Play with the speed order rule
+		Damage     string `json:"damage"`        // classic or modern; defaults to classic
+		Field      string `json:"field"`         // Weather or terrain to play in, or random; none if empty
+		ItemLimit  int    `json:"item_limit"`    // Items the player may use from their bag; 0 for the mode's default, -1 for none
 	}
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -140,6 +172,16 @@ func (h *Handler) StartBattleEnhanced(c *fiber.Ctx) error {
 		})
 	}
 
+	difficulty, err := ParseDifficulty(req.Difficulty)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_DIFFICULTY",
+				"message": fmt.Sprintf("Invalid AI difficulty. Must be one of: %s", strings.Join(Difficulties, ", ")),
+			},
+		})
+	}
+
 	// Fetch player's deck from database
 	playerDeckCards, err := h.repo.GetUserDeck(c.Context(), userID)
 	if err != nil {
@@ -176,35 +218,43 @@ func (h *Handler) StartBattleEnhanced(c *fiber.Ctx) error {
 		})
 	}
 
+	// Every random choice for this battle derives from one seed
+	seed := rng.NewSeed()
+	deckRand := rng.New(seed)
+
 	// Convert player's database cards to pokemon.Card format
-	var playerDeck []pokemon.Card
-	if req.Mode == "1v1" {
-		// For 1v1, select a random Pokemon from the deck
-		if len(playerDeckCards) > 0 {
-			randomIdx := rand.Intn(len(playerDeckCards))
-			playerDeck = append(playerDeck, ConvertPlayerCardToPokemonCard(playerDeckCards[randomIdx]))
-		}
-	} else {
-		// For 5v5, use all cards in deck order
-		for i := 0; i < requiredCards && i < len(playerDeckCards); i++ {
-			playerDeck = append(playerDeck, ConvertPlayerCardToPokemonCard(playerDeckCards[i]))
-		}
-	}
+	playerDeck := buildDeck(playerDeckCards, req.Mode, deckRand)
 
-	// Generate AI deck with random cards
-	var aiDeck []pokemon.Card
-	if req.Mode == "1v1" {
-		aiDeck = []pokemon.Card{pokemon.FetchRandomPokemonCard(false)}
-	} else {
-		// Generate 5 random cards for AI
-		for range 5 {
-			aiDeck = append(aiDeck, pokemon.FetchRandomPokemonCard(false))
-		}
+	// Generate AI deck with random cards, from the battle's seed so a replay
+	// faces the same opponents
+	aiDeck := make([]pokemon.Card, requiredCards)
+	for i := range aiDeck {
+		aiDeck[i] = pokemon.FetchRandomPokemonCardOfflineWithRand(deckRand)
 	}
 
 	// Start the battle
-	battleState, err := StartBattle(userID, req.Mode, playerDeck, aiDeck)
+	battleState, err := StartBattleWithSeed(userID, req.Mode, playerDeck, aiDeck, seed)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := battleState.SetDifficulty(difficulty); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := battleState.SetRules(Rules{SpeedOrder: req.SpeedOrder, Damage: req.Damage, Field: req.Field, ItemLimit: req.ItemLimit}); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// The player brings their whole bag; the item limit caps what they use
+	bag, err := h.repo.GetUserBag(c.Context(), userID)
 	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch player bag",
+			},
+		})
+	}
+	if err := battleState.SetBag(bag); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
@@ -219,7 +269,7 @@ func (h *Handler) StartBattleEnhanced(c *fiber.Ctx) error {
 	}
 
 	// Return battle state with card visibility
-	response := BuildBattleResponse(battleState, []string{fmt.Sprintf("Battle started! Mode: %s", req.Mode)}, true)
+	response := BuildBattleResponse(battleState, []Event{{Type: EventBattleStarted, Mode: req.Mode}}, true)
 
 	return c.JSON(response)
 }
@@ -288,6 +338,11 @@ func (h *Handler) MakeMove(c *fiber.Ctx) error {
 	return c.JSON(result)
 }
 
+// validMoves are the moves a player can submit
+var validMoves = map[string]bool{
+	"attack": true, "defend": true, "pass": true, "sacrifice": true, "surrender": true, "item": true,
+}
+
 // MakeMoveEnhanced handles POST /api/battle/move with enhanced battle system
 func (h *Handler) MakeMoveEnhanced(c *fiber.Ctx) error {
 	// Get user ID from context
@@ -329,14 +384,11 @@ func (h *Handler) MakeMoveEnhanced(c *fiber.Ctx) error {
 	}
 
 	// Validate move
-	validMoves := map[string]bool{
-		"attack": true, "defend": true, "pass": true, "sacrifice": true, "surrender": true,
-	}
 	if !validMoves[req.Move] {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": fiber.Map{
 				"code":    "INVALID_MOVE",
-				"message": "Invalid move. Must be one of: attack, defend, pass, sacrifice, surrender",
+				"message": "Invalid move. Must be one of: attack, defend, pass, sacrifice, surrender, item",
 			},
 		})
 	}
@@ -351,74 +403,230 @@ func (h *Handler) MakeMoveEnhanced(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get battle state from database
-	battleState, err := h.GetBattleState(c, req.BattleID)
-	if err != nil {
+	// Items are picked by their index in the shop's battle items
+	if req.Move == "item" && (req.MoveIdx == nil || *req.MoveIdx < 0) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_MOVE",
+				"message": "Move index is required for items",
+			},
+		})
+	}
+
+	db, _ := c.Locals("db").(*pgxpool.Pool)
+	result, err := h.applyAction(c.Context(), db, userID, req.BattleID, moveAction(req.Move, req.MoveIdx))
+	switch {
+	case errors.Is(err, errBattleNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": fiber.Map{
 				"code":    "BATTLE_NOT_FOUND",
 				"message": "Battle not found",
 			},
 		})
-	}
-
-	// Verify user owns this battle
-	if battleState.UserID != userID {
+	case errors.Is(err, errNotYourBattle):
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 			"error": fiber.Map{
 				"code":    "FORBIDDEN",
 				"message": "Not your battle",
 			},
 		})
-	}
-
-	// Process the move
-	logEntries, err := ProcessMove(battleState, req.Move, req.MoveIdx)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-	}
-
-	// Save updated battle state to database
-	if err := h.SaveBattleState(c, battleState); err != nil {
+	case errors.Is(err, errSaveFailed):
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": fiber.Map{
 				"code":    "DATABASE_ERROR",
 				"message": "Failed to save battle state",
 			},
 		})
+	case err != nil:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	response := result.Response
+	for field, value := range result.Rewards {
+		response[field] = value
 	}
 
-	hideAICards := !battleState.BattleOver || battleState.Winner != "player" || battleState.Mode != "5v5"
+	return c.JSON(response)
+}
 
-	response := BuildBattleResponse(battleState, logEntries, hideAICards)
+// Errors applyAction reports for its callers to turn into their own responses.
+// Any other error means the battle rules rejected the action.
+var (
+	errBattleNotFound = errors.New("battle not found")
+	errNotYourBattle  = errors.New("not your battle")
+	errSaveFailed     = errors.New("failed to save battle state")
+)
 
-	if battleState.BattleOver {
-		db, ok := c.Locals("db").(*pgxpool.Pool)
-		if ok {
-			// Calculate all rewards
-			rewards := CalculateAllRewards(battleState)
+// battleAction is a move or switch made by the user playing side
+type battleAction func(bs *BattleState, side string) ([]Event, error)
 
-			// Apply all rewards in a single transaction
-			err := ApplyAllRewards(c.Context(), db, userID, battleState, rewards, h.statsService, h.repo)
+// moveAction submits a move
+func moveAction(move string, moveIdx *int) battleAction {
+	return func(bs *BattleState, side string) ([]Event, error) {
+		if bs.IsPvP() {
+			return ProcessPvPMove(bs, side, move, moveIdx)
+		}
+		return ProcessMove(bs, move, moveIdx)
+	}
+}
+
+// switchAction switches the active Pokemon
+func switchAction(newIdx int) battleAction {
+	return func(bs *BattleState, side string) ([]Event, error) {
+		if bs.IsPvP() {
+			return SwitchPvPPokemon(bs, side, newIdx)
+		}
+		return SwitchPokemon(bs, newIdx)
+	}
+}
+
+// actionResult is what the user who took an action is told about it
+type actionResult struct {
+	Response map[string]any // The battle as the user sees it after the action
+	Rewards  map[string]any // The user's rewards, if the action ended the battle
+}
+
+// applyAction runs action for userID in a battle, saves the battle, rewards its
+// players if the action ended it and pushes the change to everyone watching it
+func (h *Handler) applyAction(ctx context.Context, db *pgxpool.Pool, userID int, battleID string, action battleAction) (*actionResult, error) {
+	// Both players of a PvP battle write to the same session, maybe through
+	// different servers: its row stays locked until the action is saved, so
+	// their actions apply one after the other
+	tx, err := h.repo.Begin(ctx)
+	if err != nil {
+		return nil, errSaveFailed
+	}
+	defer tx.Rollback(ctx)
+
+	// Get battle state from database
+	battleState, err := h.repo.GetBattleSessionForUpdateInTx(ctx, tx, battleID)
+	if err != nil {
+		return nil, errBattleNotFound
+	}
+
+	// Verify user plays in this battle
+	side := battleState.SideOf(userID)
+	if side == "" {
+		return nil, errNotYourBattle
+	}
+
+	events, err := action(battleState, side)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save updated battle state to database, along with the items it took out
+	// of the user's bag: an item that can't be taken out can't be used
+	battleState.UpdatedAt = time.Now()
+	if err := h.saveAction(ctx, tx, userID, battleState, events); err != nil {
+		return nil, err
+	}
+
+	result := &actionResult{Response: buildSideResponse(battleState, side, events)}
+
+	if battleState.BattleOver && db != nil {
+		result.Rewards = h.applyRewards(ctx, db, battleState, side)
+	}
+
+	h.hub.Publish(battleState, events)
+	return result, nil
+}
+
+// saveAction saves a battle after userID's action and takes the items the
+// action used out of their bag, all or nothing, by committing tx
+func (h *Handler) saveAction(ctx context.Context, tx pgx.Tx, userID int, battleState *BattleState, events []Event) error {
+	for _, e := range events {
+		if e.Type != EventBagItem {
+			continue
+		}
+		if err := h.repo.UseUserItemInTx(ctx, tx, userID, e.Item); err != nil {
+			if errors.Is(err, ErrItemUsedUp) {
+				return err
+			}
+			return errSaveFailed
+		}
+	}
+
+	if err := h.repo.SaveBattleSessionInTx(ctx, tx, battleState); err != nil {
+		return errSaveFailed
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errSaveFailed
+	}
+	return nil
+}
+
+// applyRewards rewards the players of a finished battle and returns the
+// rewards of the user playing side as response fields
+func (h *Handler) applyRewards(ctx context.Context, db *pgxpool.Pool, battleState *BattleState, side string) map[string]any {
+	fields := map[string]any{}
+
+	// Both players of a PvP battle are rewarded, each from their own side
+	sides := []string{side}
+	if battleState.IsPvP() {
+		sides = []string{"player", "ai"}
+	}
+
+	// Each player is rated against the other's rating from before the battle
+	opponentRatings := map[string]glicko2.Rating{"player": AIRatingFor(battleState.Difficulty())}
+	if battleState.IsPvP() {
+		for _, ratedSide := range sides {
+			rating, err := h.repo.GetRating(ctx, battleState.ForSide(ratedSide).UserID, battleState.Mode, LadderPvP)
 			if err != nil {
-				// Log error but don't fail the request - battle is already over
-				fmt.Printf("Failed to apply rewards: %v\n", err)
-				// Still return partial rewards info if available
-				response["coins_earned"] = rewards.CoinsEarned
-			} else {
-				// Add comprehensive rewards to response
-				response["coins_earned"] = rewards.CoinsEarned
-				response["xp_gains"] = rewards.XPGains
-				if len(rewards.NewlyUnlockedAchievements) > 0 {
-					response["newly_unlocked_achievements"] = rewards.NewlyUnlockedAchievements
-				}
-				response["battle_history_recorded"] = rewards.BattleHistoryRecorded
-				response["stats_updated"] = rewards.StatsUpdated
+				fmt.Printf("Failed to get rating for user %d: %v\n", battleState.ForSide(ratedSide).UserID, err)
 			}
+			opponentRatings[opponent(ratedSide)] = rating
 		}
 	}
 
-	return c.JSON(response)
+	for _, rewardSide := range sides {
+		view := battleState.ForSide(rewardSide)
+
+		// Calculate all rewards
+		rewards := CalculateAllRewards(view)
+
+		// Apply all rewards in a single transaction
+		err := ApplyAllRewards(ctx, db, view.UserID, view, rewards, opponentRatings[rewardSide], h.statsService, h.repo)
+		if err != nil {
+			// Log error but don't fail the request - battle is already over
+			fmt.Printf("Failed to apply rewards for user %d: %v\n", view.UserID, err)
+		}
+		if rewardSide != side {
+			continue
+		}
+
+		if err != nil {
+			// Still return partial rewards info if available
+			fields["coins_earned"] = rewards.CoinsEarned
+		} else {
+			// Add comprehensive rewards to response
+			fields["coins_earned"] = rewards.CoinsEarned
+			fields["xp_gains"] = rewards.XPGains
+			if len(rewards.NewlyUnlockedAchievements) > 0 {
+				fields["newly_unlocked_achievements"] = rewards.NewlyUnlockedAchievements
+			}
+			fields["battle_history_recorded"] = rewards.BattleHistoryRecorded
+			fields["battle_history_id"] = rewards.BattleHistoryID
+			fields["stats_updated"] = rewards.StatsUpdated
+			if rewards.Rating != nil {
+				fields["rating"] = rewards.Rating
+			}
+		}
+	}
+
+	return fields
+}
+
+// buildSideResponse builds the battle response shown to the user playing side.
+// The opponent's inactive Pokemon stay hidden unless the user won a 5v5 battle.
+func buildSideResponse(bs *BattleState, side string, events []Event) map[string]any {
+	view := bs.ForSide(side)
+	if side == "ai" {
+		events = MirrorEvents(events)
+	}
+
+	hideAICards := !view.BattleOver || view.Winner != "player" || view.Mode != "5v5"
+	return BuildBattleResponse(view, events, hideAICards)
 }
 
 // GetBattleStateEnhanced handles GET /api/battle/state with enhanced system
@@ -440,14 +648,13 @@ func (h *Handler) GetBattleStateEnhanced(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Battle not found"})
 	}
 
-	// Verify user owns this battle
-	if battleState.UserID != userID {
+	// Verify user plays in this battle
+	side := battleState.SideOf(userID)
+	if side == "" {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your battle"})
 	}
 
-	hideAICards := !battleState.BattleOver || battleState.Winner != "player" || battleState.Mode != "5v5"
-
-	response := BuildBattleResponse(battleState, []string{}, hideAICards)
+	response := buildSideResponse(battleState, side, []Event{})
 
 	return c.JSON(response)
 }
@@ -468,32 +675,71 @@ func (h *Handler) SwitchPokemonHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
 
-	// Get battle state from database
-	battleState, err := h.GetBattleState(c, req.BattleID)
-	if err != nil {
+	db, _ := c.Locals("db").(*pgxpool.Pool)
+	result, err := h.applyAction(c.Context(), db, userID, req.BattleID, switchAction(req.NewIdx))
+	switch {
+	case errors.Is(err, errBattleNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Battle not found"})
+	case errors.Is(err, errNotYourBattle):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your battle"})
+	case errors.Is(err, errSaveFailed):
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle state"})
+	case err != nil:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	// Verify user owns this battle
-	if battleState.UserID != userID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your battle"})
+	// Return updated state
+	return c.JSON(result.Response)
+}
+
+// GetBattleReplay handles GET /api/battle/:id/replay for finished battles
+func (h *Handler) GetBattleReplay(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
 	}
 
-	// Switch Pokemon
-	err = SwitchPokemon(battleState, req.NewIdx)
+	battleState, err := h.GetBattleState(c, c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "BATTLE_NOT_FOUND",
+				"message": "Battle not found",
+			},
+		})
 	}
 
-	// Save updated battle state to database
-	if err := h.SaveBattleState(c, battleState); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle state"})
+	// Verify user plays in this battle
+	if battleState.SideOf(userID) == "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "FORBIDDEN",
+				"message": "Not your battle",
+			},
+		})
 	}
 
-	// Return updated state
-	response := BuildBattleResponse(battleState, []string{fmt.Sprintf("Switched to %s", battleState.PlayerDeck[req.NewIdx].Name)}, true)
+	// A replay of a battle in progress would reveal the seed and the AI's hidden cards
+	if !battleState.BattleOver {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "BATTLE_IN_PROGRESS",
+				"message": "Replays are only available once the battle is over",
+			},
+		})
+	}
 
-	return c.JSON(response)
+	if battleState.Replay == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "REPLAY_NOT_AVAILABLE",
+				"message": "This battle was not recorded",
+			},
+		})
+	}
+
+	return c.JSON(battleState.Replay)
 }
 
 func (h *Handler) GetBattleStateLegacy(c *fiber.Ctx) error {
@@ -553,6 +799,11 @@ func (h *Handler) SelectRewardHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your battle"})
 	}
 
+	// Opponents in PvP battles keep their Pokemon
+	if battleState.IsPvP() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Reward selection is not available for PvP battles"})
+	}
+
 	// Validate battle was won by player and is 5v5 mode
 	if battleState.Mode != "5v5" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Reward selection only available for 5v5 battles"})
@@ -596,6 +847,7 @@ func (h *Handler) SelectRewardHandler(c *fiber.Ctx) error {
 	if err := h.SaveBattleState(c, battleState); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle state"})
 	}
+	h.hub.Publish(battleState, []Event{})
 
 	// Return success with the added card
 	return c.JSON(fiber.Map{
@@ -603,3 +855,188 @@ func (h *Handler) SelectRewardHandler(c *fiber.Ctx) error {
 		"card":    addedCard,
 	})
 }
+
+// ChallengeHandler handles POST /api/battle/pvp/challenge
+func (h *Handler) ChallengeHandler(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req struct {
+		Opponent string `json:"opponent"` // Username of the user being challenged
+		Mode     string `json:"mode"`     // "1v1" or "5v5"
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	req.Opponent = strings.TrimSpace(req.Opponent)
+	req.Mode = strings.TrimSpace(req.Mode)
+	if req.Mode != "1v1" && req.Mode != "5v5" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_MODE",
+				"message": "Invalid battle mode. Must be '1v1' or '5v5'",
+			},
+		})
+	}
+
+	opponentID, err := h.repo.GetUserIDByUsername(c.Context(), req.Opponent)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "USER_NOT_FOUND",
+				"message": "Opponent not found",
+			},
+		})
+	}
+
+	deckCards, err := h.repo.GetUserDeck(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch player deck"})
+	}
+
+	seed := rng.NewSeed()
+	deck := buildDeck(deckCards, req.Mode, rng.New(seed))
+
+	battleState, err := NewPvPChallenge(userID, opponentID, req.Mode, deck, seed)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.SaveBattleState(c, battleState); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle session"})
+	}
+
+	return c.JSON(buildSideResponse(battleState, "player", []Event{}))
+}
+
+// ListChallengesHandler handles GET /api/battle/pvp/challenges, listing the
+// challenges waiting for the user to accept them
+func (h *Handler) ListChallengesHandler(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	challenges, err := h.repo.GetPendingChallenges(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch challenges"})
+	}
+
+	response := make([]fiber.Map, len(challenges))
+	for i, challenge := range challenges {
+		response[i] = fiber.Map{
+			"battle_id":     challenge.ID,
+			"challenger_id": challenge.UserID,
+			"mode":          challenge.Mode,
+			"created_at":    challenge.CreatedAt,
+		}
+	}
+
+	return c.JSON(fiber.Map{"challenges": response})
+}
+
+// AcceptChallengeHandler handles POST /api/battle/pvp/accept
+func (h *Handler) AcceptChallengeHandler(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req struct {
+		BattleID string `json:"battle_id"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	// Lock the session so the challenge can't be accepted or declined twice
+	tx, err := h.repo.Begin(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle state"})
+	}
+	defer tx.Rollback(c.Context())
+
+	battleState, err := h.repo.GetBattleSessionForUpdateInTx(c.Context(), tx, req.BattleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Battle not found"})
+	}
+
+	// Only the challenged user can accept
+	if !battleState.IsPvP() || battleState.OpponentUserID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your challenge"})
+	}
+
+	deckCards, err := h.repo.GetUserDeck(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch player deck"})
+	}
+
+	// Draw the opponent's 1v1 pick from a different stream than the challenger's
+	deck := buildDeck(deckCards, battleState.Mode, rng.New(battleState.Seed+1))
+
+	if err := AcceptPvPChallenge(battleState, deck); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	battleState.UpdatedAt = time.Now()
+	if err := h.repo.SaveBattleSessionInTx(c.Context(), tx, battleState); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle state"})
+	}
+	if err := tx.Commit(c.Context()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save battle state"})
+	}
+
+	started := []Event{{Type: EventBattleStarted, Mode: battleState.Mode}}
+	h.hub.Publish(battleState, started)
+
+	return c.JSON(buildSideResponse(battleState, "ai", started))
+}
+
+// DeclineChallengeHandler handles POST /api/battle/pvp/decline. Either player
+// can call off a challenge that hasn't been accepted yet.
+func (h *Handler) DeclineChallengeHandler(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req struct {
+		BattleID string `json:"battle_id"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	// Lock the session so the challenge can't be accepted meanwhile
+	tx, err := h.repo.Begin(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete challenge"})
+	}
+	defer tx.Rollback(c.Context())
+
+	battleState, err := h.repo.GetBattleSessionForUpdateInTx(c.Context(), tx, req.BattleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Battle not found"})
+	}
+
+	if !battleState.IsPvP() || battleState.SideOf(userID) == "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your challenge"})
+	}
+
+	if battleState.PvPStatus != PvPPending {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Challenge was already accepted"})
+	}
+
+	if err := h.repo.DeleteBattleSessionInTx(c.Context(), tx, req.BattleID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete challenge"})
+	}
+	if err := tx.Commit(c.Context()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete challenge"})
+	}
+	h.hub.Close(req.BattleID)
+
+	return c.JSON(fiber.Map{"message": "Challenge declined"})
+}
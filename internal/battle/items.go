@@ -0,0 +1,55 @@
+package battle
+
+import "pokemon-cli/internal/pokemon"
+
+// How held items play out
+const (
+	leftoversDivisor    = 16 // Leftovers restore 1/16 of max HP every turn
+	staminaCharmDivisor = 10 // Stamina charms restore 1/10 of max stamina every turn
+)
+
+// itemMultiplier returns how much the item an attacker holds multiplies the
+// damage of its moves of moveType
+func itemMultiplier(item, moveType string) float64 {
+	if held, ok := pokemon.GetHeldItem(item); ok && held.BoostType != "" && held.BoostType == moveType {
+		return 1 + pokemon.TypeBoostPercent/100.0
+	}
+	return 1
+}
+
+// endure lets the copy of side's card the turn is resolved with hang on with
+// 1 HP when it holds a focus sash and a hit took it from full HP (hpBefore) to
+// a knockout. The sash is used up.
+func endure(side string, card *pokemon.Card, hpBefore int) []Event {
+	if card.HeldItem != pokemon.ItemFocusSash || hpBefore < card.HPMax || card.HP > 0 {
+		return nil
+	}
+	card.HP = 1
+	card.HeldItem = ""
+	return []Event{{Type: EventItemUsed, Side: side, Pokemon: card.Name, Item: pokemon.ItemFocusSash}}
+}
+
+// itemRecovery gives the end of turn healing of leftovers and stamina of
+// stamina charms to the copy of side's card the turn is resolved with
+func itemRecovery(side string, card *pokemon.Card, staminaMax int) []Event {
+	if card.HP <= 0 {
+		return nil
+	}
+
+	switch card.HeldItem {
+	case pokemon.ItemLeftovers:
+		if card.HP < card.HPMax {
+			healed := min(max(card.HPMax/leftoversDivisor, 1), card.HPMax-card.HP)
+			card.HP += healed
+			return []Event{{Type: EventHealed, Side: side, Pokemon: card.Name, Item: card.HeldItem, HPGained: healed}}
+		}
+	case pokemon.ItemStaminaCharm:
+		// Stamina spent this turn hasn't been clamped yet
+		if stamina := max(card.Stamina, 0); stamina < staminaMax {
+			restored := min(max(staminaMax/staminaCharmDivisor, 1), staminaMax-stamina)
+			card.Stamina = stamina + restored
+			return []Event{{Type: EventStaminaRestored, Side: side, Pokemon: card.Name, Item: card.HeldItem, StaminaGained: restored}}
+		}
+	}
+	return nil
+}
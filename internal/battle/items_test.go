@@ -0,0 +1,127 @@
+package battle
+
+import (
+	"testing"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+func TestTypeBoostingItem(t *testing.T) {
+	damage := map[string]int{}
+	for _, item := range []string{"", "magnet", "charcoal"} {
+		bs := newSeededBattle(t, 6)
+		if err := bs.SetStrategy("passer", passingAI); err != nil {
+			t.Fatalf("SetStrategy failed: %v", err)
+		}
+		bs.PlayerDeck[0].HeldItem = item
+		bs.AIDeck[0].HP, bs.AIDeck[0].HPMax = 999, 999
+		hp := bs.AIDeck[0].HP
+		if _, err := ProcessMove(bs, "attack", intPtr(0)); err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+		damage[item] = hp - bs.AIDeck[0].HP
+	}
+
+	if want := int(float64(damage[""]) * 1.2); damage["magnet"] != want {
+		t.Errorf("thunderbolt dealt %d with a magnet, want %d (1.2 x %d)", damage["magnet"], want, damage[""])
+	}
+	if damage["charcoal"] != damage[""] {
+		t.Errorf("charcoal boosted an electric move: %d, want %d", damage["charcoal"], damage[""])
+	}
+}
+
+// knockoutBattle returns a battle where the AI knocks out the player's
+// Pokemon with any hit
+func knockoutBattle(t *testing.T, item string) *BattleState {
+	t.Helper()
+	bs := newSeededBattle(t, 7)
+	if err := bs.SetStrategy("attacker", attackingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	bs.PlayerDeck[0].HP, bs.PlayerDeck[0].HPMax = 10, 10
+	bs.PlayerDeck[0].HeldItem = item
+	return bs
+}
+
+func TestFocusSash(t *testing.T) {
+	bs := knockoutBattle(t, pokemon.ItemFocusSash)
+	events, err := ProcessMove(bs, "pass", nil)
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	card := bs.PlayerDeck[0]
+	if card.HP != 1 || card.IsKnockedOut {
+		t.Fatalf("HP %d after a knockout hit with a focus sash, want 1", card.HP)
+	}
+	if used := eventsOfType(events, EventItemUsed); len(used) != 1 || used[0].Item != pokemon.ItemFocusSash {
+		t.Errorf("item_used events %+v, want one for the focus sash", used)
+	}
+	if card.HeldItem != "" {
+		t.Error("the focus sash wasn't used up")
+	}
+
+	// Used up, it doesn't save it again
+	if _, err := ProcessMove(bs, "pass", nil); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if !bs.BattleOver || bs.Winner != "ai" {
+		t.Error("the focus sash saved its holder twice")
+	}
+
+	// Nor below full HP
+	bs = knockoutBattle(t, pokemon.ItemFocusSash)
+	bs.PlayerDeck[0].HP--
+	if _, err := ProcessMove(bs, "pass", nil); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if !bs.PlayerDeck[0].IsKnockedOut {
+		t.Error("the focus sash saved a Pokemon below full HP")
+	}
+}
+
+func TestFocusSashHolderStillAttacksUnderSpeedOrder(t *testing.T) {
+	bs := knockoutBattle(t, pokemon.ItemFocusSash)
+	if err := bs.SetRules(Rules{SpeedOrder: true}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	bs.AIDeck[0].Speed = 999
+
+	events, err := ProcessMove(bs, "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	playerAttacked := false
+	for _, e := range eventsOfType(events, EventDamageDealt) {
+		playerAttacked = playerAttacked || e.Side == "player"
+	}
+	if bs.PlayerDeck[0].HP != 1 || !playerAttacked {
+		t.Errorf("HP %d, attacked %v: the slower focus sash holder should hang on and attack", bs.PlayerDeck[0].HP, playerAttacked)
+	}
+}
+
+func TestRecoveryItems(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	player, ai := &bs.PlayerDeck[0], &bs.AIDeck[0]
+	player.HeldItem, ai.HeldItem = pokemon.ItemLeftovers, pokemon.ItemStaminaCharm
+	player.HP = player.HPMax / 2
+	ai.Stamina = 5
+	hp := player.HP
+
+	events, err := ProcessMove(bs, "pass", nil)
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	if healed := player.HP - hp; healed != player.HPMax/leftoversDivisor {
+		t.Errorf("leftovers healed %d, want %d", healed, player.HPMax/leftoversDivisor)
+	}
+	if ai.Stamina != 5+ai.StaminaMax/staminaCharmDivisor {
+		t.Errorf("stamina charm restored %d, want %d", ai.Stamina-5, ai.StaminaMax/staminaCharmDivisor)
+	}
+	if len(eventsOfType(events, EventHealed)) != 1 || len(eventsOfType(events, EventStaminaRestored)) != 1 {
+		t.Errorf("events %+v, want one healed and one stamina_restored", events)
+	}
+}
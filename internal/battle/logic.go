@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"pokemon-cli/game/core"
 	"pokemon-cli/game/models"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/pkg/rng"
 )
 
 func ProcessWebMove(state *models.GameState, turn *TurnState, move string, moveIdx *int) (map[string]any, error) {
@@ -131,7 +133,7 @@ func ProcessWebMove(state *models.GameState, turn *TurnState, move string, moveI
 		return buildWebState(state, turn, logEntries), nil
 	} else if turn.WhoseTurn == "ai" {
 		for {
-			aiMove, aiMoveIdx := core.GetAIMove(turn.PendingPlayerMove, aiCard, state, state.AIActiveIdx)
+			aiMove, aiMoveIdx := core.GetAIMove(rng.Global, turn.PendingPlayerMove, aiCard, state, state.AIActiveIdx)
 			if aiMove == "surrender" {
 				state.BattleStarted = false
 				state.InBattle = false
@@ -147,7 +149,7 @@ func ProcessWebMove(state *models.GameState, turn *TurnState, move string, moveI
 			}
 			if aiMove == "sacrifice" {
 				maxStamina := aiCard.Speed * 2
-				if float64(aiCard.Stamina) >= 0.5*float64(maxStamina) {
+				if !ruleset.Current().CanSacrifice(aiCard.Stamina, maxStamina) {
 					break
 				}
 				oldHP := aiCard.HP
@@ -0,0 +1,324 @@
+package battle
+
+import (
+	"fmt"
+	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/rng"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PvP battle statuses
+const (
+	PvPPending = "pending" // The challenge is waiting for the opponent to accept it
+	PvPActive  = "active"  // Both players are in and submitting moves
+)
+
+// In a PvP battle the challenger plays the "player" side and the opponent the
+// "ai" side, so the engine, timeline and replay work unchanged. Both players
+// submit a move each turn and the turn resolves once both moves are in.
+
+// NewPvPChallenge creates a battle in which challengerID challenges opponentID.
+// It can't be played until the opponent accepts it with AcceptPvPChallenge.
+func NewPvPChallenge(challengerID, opponentID int, mode string, challengerDeck []pokemon.Card, seed int64) (*BattleState, error) {
+	if challengerID == opponentID {
+		return nil, fmt.Errorf("you cannot challenge yourself")
+	}
+	if err := validateDeckSize(mode, len(challengerDeck)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &BattleState{
+		ID:               uuid.New().String(),
+		UserID:           challengerID,
+		OpponentUserID:   opponentID,
+		PvPStatus:        PvPPending,
+		Mode:             mode,
+		PlayerDeck:       toBattleDeck(challengerDeck),
+		TurnNumber:       1,
+		RoundNumber:      1,
+		WhoseTurn:        SideBoth,
+		SacrificeCount:   make(map[int]int),
+		AISacrificeCount: make(map[int]int),
+		Seed:             seed,
+		RNG:              rng.NewSource(seed),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, nil
+}
+
+// AcceptPvPChallenge brings the opponent's deck into a pending challenge and starts the battle
+func AcceptPvPChallenge(bs *BattleState, opponentDeck []pokemon.Card) error {
+	if bs.PvPStatus != PvPPending {
+		return fmt.Errorf("battle is not a pending challenge")
+	}
+	if err := validateDeckSize(bs.Mode, len(opponentDeck)); err != nil {
+		return err
+	}
+
+	bs.AIDeck = toBattleDeck(opponentDeck)
+	bs.PvPStatus = PvPActive
+	bs.CreatedAt = time.Now()
+	bs.Replay = newReplay(bs)
+	return nil
+}
+
+// NewMatchedPvPBattle starts a PvP battle between two users paired by
+// matchmaking, skipping the challenge step. Each user brings their configured deck.
+func NewMatchedPvPBattle(userID, opponentID int, mode string, userDeckCards, opponentDeckCards []database.PlayerCard) (*BattleState, error) {
+	seed := rng.NewSeed()
+	bs, err := NewPvPChallenge(userID, opponentID, mode, buildDeck(userDeckCards, mode, rng.New(seed)), seed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Draw the opponent's 1v1 pick from a different stream, as when accepting a challenge
+	if err := AcceptPvPChallenge(bs, buildDeck(opponentDeckCards, mode, rng.New(seed+1))); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// CheckDeck reports why a user's configured deck can't be brought to a battle in mode, if it can't
+func CheckDeck(mode string, deckCards []database.PlayerCard) error {
+	return validateDeckSize(mode, len(deckCards))
+}
+
+// IsPvP reports whether both sides of the battle are played by users
+func (bs *BattleState) IsPvP() bool {
+	return bs.PvPStatus != ""
+}
+
+// SideOf returns the side userID plays in the battle, or "" if they aren't in it
+func (bs *BattleState) SideOf(userID int) string {
+	switch {
+	case userID == bs.UserID:
+		return "player"
+	case bs.IsPvP() && userID == bs.OpponentUserID:
+		return "ai"
+	}
+	return ""
+}
+
+// ForSide returns the battle as seen by side: side's user, deck and moves
+// become the "player" ones. The "player" view is the battle itself; the "ai"
+// view is a mirrored copy that doesn't record a replay.
+func (bs *BattleState) ForSide(side string) *BattleState {
+	if side != "ai" {
+		return bs
+	}
+
+	view := bs.Clone()
+	view.UserID, view.OpponentUserID = bs.OpponentUserID, bs.UserID
+	view.PlayerDeck, view.AIDeck = view.AIDeck, view.PlayerDeck
+	view.PlayerActiveIdx, view.AIActiveIdx = bs.AIActiveIdx, bs.PlayerActiveIdx
+	view.SacrificeCount, view.AISacrificeCount = view.AISacrificeCount, view.SacrificeCount
+	view.PendingPlayerMove, view.PendingAIMove = bs.PendingAIMove, bs.PendingPlayerMove
+	view.PendingPlayerMoveIdx, view.PendingAIMoveIdx = bs.PendingAIMoveIdx, bs.PendingPlayerMoveIdx
+	view.WhoseTurn = mirrorSide(bs.WhoseTurn)
+	view.Winner = mirrorSide(bs.Winner)
+	for i, turn := range view.Turns {
+		view.Turns[i] = mirrorTurn(turn)
+	}
+	return view
+}
+
+// mirrorSide swaps "player" and "ai", leaving "both", "draw" and "" alone
+func mirrorSide(side string) string {
+	if side == "player" || side == "ai" {
+		return opponent(side)
+	}
+	return side
+}
+
+// mirrorTurn swaps the sides of a turn record
+func mirrorTurn(t TurnRecord) TurnRecord {
+	return TurnRecord{
+		Turn:               t.Turn,
+		Round:              t.Round,
+		PlayerPokemon:      t.AIPokemon,
+		AIPokemon:          t.PlayerPokemon,
+		PlayerMove:         t.AIMove,
+		PlayerMoveName:     t.AIMoveName,
+		AIMove:             t.PlayerMove,
+		AIMoveName:         t.PlayerMoveName,
+		DamageDealt:        t.DamageTaken,
+		DamageTaken:        t.DamageDealt,
+		PlayerHPDelta:      t.AIHPDelta,
+		PlayerStaminaDelta: t.AIStaminaDelta,
+		AIHPDelta:          t.PlayerHPDelta,
+		AIStaminaDelta:     t.PlayerStaminaDelta,
+		PlayerKnockedOut:   t.AIKnockedOut,
+		AIKnockedOut:       t.PlayerKnockedOut,
+	}
+}
+
+// MirrorEvents returns events as seen from the "ai" side of a PvP battle
+func MirrorEvents(events []Event) []Event {
+	mirrored := make([]Event, len(events))
+	for i, e := range events {
+		e.Side = mirrorSide(e.Side)
+		e.Winner = mirrorSide(e.Winner)
+		mirrored[i] = e
+	}
+	return mirrored
+}
+
+// ProcessPvPMove submits side's move in a PvP battle, returning the events it caused.
+// The turn resolves once both sides have submitted a move; until then the
+// submitted move stays hidden and no events are returned.
+func ProcessPvPMove(bs *BattleState, side, move string, moveIdx *int) ([]Event, error) {
+	turn := bs.TurnNumber
+	rec, snap := beginTurnRecord(bs, "")
+	events, err := processPvPMove(bs, side, move, moveIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	if bs.BattleOver && !hasEvent(events, EventBattleEnded) {
+		events = append(events, Event{Type: EventBattleEnded, Winner: bs.Winner, Reason: EndKnockout})
+	}
+
+	if len(events) > 0 {
+		finishTurnRecord(bs, rec, snap, events)
+	}
+	bs.record(ReplayAction{Kind: "move", Side: side, Turn: turn, Move: move, MoveIdx: moveIdx, Log: EventLog(events), Events: events})
+	return events, nil
+}
+
+// processPvPMove applies side's move to a PvP battle
+func processPvPMove(bs *BattleState, side, move string, moveIdx *int) ([]Event, error) {
+	if bs.BattleOver {
+		return nil, fmt.Errorf("battle is already over")
+	}
+	if bs.PvPStatus != PvPActive {
+		return nil, fmt.Errorf("battle has not been accepted yet")
+	}
+	if side != "player" && side != "ai" {
+		return nil, fmt.Errorf("invalid side: %s", side)
+	}
+
+	if move == "surrender" {
+		events := surrender(bs, side)
+		if !bs.BattleOver {
+			// The Pokemon sent in chooses its own move
+			bs.setPendingMove(side, "", 0)
+			bs.WhoseTurn = SideBoth
+			if bs.pendingMove(opponent(side)) != "" {
+				bs.WhoseTurn = side
+			}
+		}
+		return events, nil
+	}
+
+	card := bs.activeCard(side)
+	if card == nil || bs.activeCard(opponent(side)) == nil {
+		return nil, fmt.Errorf("invalid active Pokemon")
+	}
+
+	if !bs.awaitingMoveFrom(side) {
+		return nil, fmt.Errorf("you have already chosen a move this turn")
+	}
+
+	// Sacrifice stays a free action, as long as the turn's move isn't locked in
+	if move == "sacrifice" {
+		sacrificed, err := sacrifice(bs, side)
+		if err != nil {
+			return nil, err
+		}
+		return []Event{sacrificed}, nil
+	}
+
+	if move == "item" {
+		return nil, fmt.Errorf("items can't be used in PvP battles")
+	}
+	if err := validateMove(card, move, moveIdx); err != nil {
+		return nil, err
+	}
+
+	idx := 0
+	if moveIdx != nil {
+		idx = *moveIdx
+	}
+	bs.setPendingMove(side, move, idx)
+
+	// Wait for the other player
+	if bs.WhoseTurn == SideBoth {
+		bs.WhoseTurn = opponent(side)
+		return []Event{}, nil
+	}
+
+	// Both moves are in: reveal them and resolve the turn
+	events := []Event{bs.chosenMove("player"), bs.chosenMove("ai")}
+	return append(events, finishTurn(bs)...), nil
+}
+
+// SwitchPvPPokemon switches side's active Pokemon in a PvP battle, returning the resulting events.
+// Like in battles against the AI, switching doesn't use up the turn's move.
+func SwitchPvPPokemon(bs *BattleState, side string, newIdx int) ([]Event, error) {
+	turn := bs.TurnNumber
+	rec, snap := beginTurnRecord(bs, "")
+	if bs.PvPStatus != PvPActive {
+		return nil, fmt.Errorf("battle has not been accepted yet")
+	}
+	if !bs.awaitingMoveFrom(side) {
+		return nil, fmt.Errorf("you have already chosen a move this turn")
+	}
+	if err := switchPokemon(bs, side, newIdx); err != nil {
+		return nil, err
+	}
+
+	events := []Event{{Type: EventSwitched, Side: side, Pokemon: bs.deckOf(side)[newIdx].Name, Round: bs.RoundNumber}}
+	finishTurnRecord(bs, rec, snap, events)
+
+	bs.record(ReplayAction{
+		Kind:      "switch",
+		Side:      side,
+		Turn:      turn,
+		SwitchIdx: newIdx,
+		Log:       EventLog(events),
+		Events:    events,
+	})
+	return events, nil
+}
+
+// awaitingMoveFrom reports whether side still has to submit a move this turn
+func (bs *BattleState) awaitingMoveFrom(side string) bool {
+	return bs.WhoseTurn == SideBoth || bs.WhoseTurn == side
+}
+
+// pendingMove returns the move side has submitted this turn, if any
+func (bs *BattleState) pendingMove(side string) string {
+	if side == "ai" {
+		return bs.PendingAIMove
+	}
+	return bs.PendingPlayerMove
+}
+
+// setPendingMove stores side's move for this turn
+func (bs *BattleState) setPendingMove(side, move string, moveIdx int) {
+	if side == "ai" {
+		bs.PendingAIMove = move
+		bs.PendingAIMoveIdx = moveIdx
+		return
+	}
+	bs.PendingPlayerMove = move
+	bs.PendingPlayerMoveIdx = moveIdx
+}
+
+// chosenMove describes the move side has submitted this turn
+func (bs *BattleState) chosenMove(side string) Event {
+	move, moveIdx := bs.PendingPlayerMove, bs.PendingPlayerMoveIdx
+	if side == "ai" {
+		move, moveIdx = bs.PendingAIMove, bs.PendingAIMoveIdx
+	}
+
+	chosen := Event{Type: EventMoveChosen, Side: side, Move: move}
+	if card := bs.activeCard(side); move == "attack" && card != nil {
+		chosen.MoveName = card.Moves[moveIdx].Name
+	}
+	return chosen
+}
@@ -0,0 +1,141 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+
+	"pokemon-cli/internal/ruleset"
+)
+
+func newPvPBattle(t *testing.T, seed int64) *BattleState {
+	t.Helper()
+	challenger, opponent := testDecks()
+	bs, err := NewPvPChallenge(1, 2, "1v1", challenger, seed)
+	if err != nil {
+		t.Fatalf("NewPvPChallenge failed: %v", err)
+	}
+	if err := AcceptPvPChallenge(bs, opponent); err != nil {
+		t.Fatalf("AcceptPvPChallenge failed: %v", err)
+	}
+	return bs
+}
+
+func TestPvPMovesWaitForBothPlayers(t *testing.T) {
+	challenger, _ := testDecks()
+	pending, err := NewPvPChallenge(1, 2, "1v1", challenger, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ProcessPvPMove(pending, "player", "defend", nil); err == nil {
+		t.Error("expected moves to be rejected before the challenge is accepted")
+	}
+
+	bs := newPvPBattle(t, 5)
+
+	events, err := ProcessPvPMove(bs, "ai", "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("first move failed: %v", err)
+	}
+	if len(events) != 0 || bs.WhoseTurn != "player" || bs.TurnNumber != 1 {
+		t.Fatalf("first move should wait for the other player (events %v, whose turn %q)", events, bs.WhoseTurn)
+	}
+	if _, err := ProcessPvPMove(bs, "ai", "defend", nil); err == nil {
+		t.Error("expected a second move in the same turn to be rejected")
+	}
+
+	events, err = ProcessPvPMove(bs, "player", "defend", nil)
+	if err != nil {
+		t.Fatalf("second move failed: %v", err)
+	}
+
+	want := []Event{
+		{Type: EventMoveChosen, Side: "player", Move: "defend"},
+		{Type: EventMoveChosen, Side: "ai", Move: "attack", MoveName: "bite"},
+	}
+	if len(events) < 2 || !reflect.DeepEqual(events[:2], want) {
+		t.Errorf("turn should reveal both moves first, got %+v", events)
+	}
+	if bs.TurnNumber != 2 || bs.WhoseTurn != SideBoth {
+		t.Errorf("turn did not advance: turn %d, whose turn %q", bs.TurnNumber, bs.WhoseTurn)
+	}
+	if len(bs.Turns) != 1 || bs.Turns[0].PlayerMove != "defend" || bs.Turns[0].AIMove != "attack" {
+		t.Errorf("unexpected timeline: %+v", bs.Turns)
+	}
+}
+
+func TestPvPSideViews(t *testing.T) {
+	bs := newPvPBattle(t, 11)
+	if bs.SideOf(1) != "player" || bs.SideOf(2) != "ai" || bs.SideOf(3) != "" {
+		t.Fatal("SideOf did not map the players to their sides")
+	}
+
+	if _, err := ProcessPvPMove(bs, "player", "attack", intPtr(0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ProcessPvPMove(bs, "ai", "attack", intPtr(0)); err != nil {
+		t.Fatal(err)
+	}
+	bs.Winner = "player"
+
+	view := bs.ForSide("ai")
+	if view.UserID != 2 || view.PlayerDeck[0].Name != "squirtle" || view.Winner != "ai" {
+		t.Errorf("opponent's view is not mirrored: user %d, deck %s, winner %s", view.UserID, view.PlayerDeck[0].Name, view.Winner)
+	}
+	if view.Turns[0].DamageDealt != bs.Turns[0].DamageTaken || view.Turns[0].PlayerMoveName != "water-gun" {
+		t.Errorf("opponent's timeline is not mirrored: %+v", view.Turns[0])
+	}
+	if bs.PlayerDeck[0].Name != "pikachu" || bs.Winner != "player" {
+		t.Error("ForSide modified the battle")
+	}
+}
+
+func TestPvPSidesSacrificeSeparately(t *testing.T) {
+	bs := newPvPBattle(t, 13)
+	bs.PlayerDeck[0].Stamina, bs.AIDeck[0].Stamina = 0, 0
+	bs.SacrificeCount[0] = ruleset.Current().MaxSacrifices()
+
+	if _, err := ProcessPvPMove(bs, "player", "sacrifice", nil); err == nil {
+		t.Error("expected the challenger's sacrifices to be used up")
+	}
+	if _, err := ProcessPvPMove(bs, "ai", "sacrifice", nil); err != nil {
+		t.Fatalf("the challenger's sacrifices used up the opponent's: %v", err)
+	}
+	if bs.AISacrificeCount[0] != 1 || bs.SacrificeCount[0] != ruleset.Current().MaxSacrifices() {
+		t.Errorf("sacrifice counts %v and %v, want the opponent's sacrifice counted apart", bs.SacrificeCount, bs.AISacrificeCount)
+	}
+	if view := bs.ForSide("ai"); view.SacrificeCount[0] != 1 {
+		t.Errorf("opponent's view counts %v sacrifices, want its own", view.SacrificeCount)
+	}
+}
+
+func TestPvPReplayReproducesBattle(t *testing.T) {
+	bs := newPvPBattle(t, 21)
+	for !bs.BattleOver {
+		if _, err := ProcessPvPMove(bs, "player", "attack", intPtr(1)); err != nil {
+			// Out of stamina
+			if _, err := ProcessPvPMove(bs, "player", "pass", nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if bs.BattleOver {
+			break
+		}
+		if _, err := ProcessPvPMove(bs, "ai", "attack", intPtr(0)); err != nil {
+			if _, err := ProcessPvPMove(bs, "ai", "pass", nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if !bs.Replay.PvP {
+		t.Fatal("replay is not marked as PvP")
+	}
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	last := frames[len(frames)-1].State
+	if !reflect.DeepEqual(last.PlayerDeck, bs.PlayerDeck) || !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+		t.Error("final replay frame does not match the finished battle")
+	}
+}
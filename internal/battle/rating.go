@@ -0,0 +1,44 @@
+package battle
+
+import (
+	"pokemon-cli/pkg/glicko2"
+)
+
+// Rating ladders. PvP and AI battles are rated separately, so beating the AI
+// can't carry a player up the PvP leaderboard.
+const (
+	LadderPvP = "pvp"
+	LadderPvE = "pve"
+)
+
+// AIRating is the rating the normal AI opponent is treated as having on the PvE
+// ladder; see AIRatingFor for the other difficulties. Its deviation is low
+// because each difficulty plays the same way every battle.
+var AIRating = glicko2.Rating{Rating: 1500, Deviation: 50, Volatility: 0.06}
+
+// RatingChange describes how a battle moved a player's rating
+type RatingChange struct {
+	Ladder    string  `json:"ladder"`
+	OldRating float64 `json:"old_rating"`
+	NewRating float64 `json:"new_rating"`
+	Deviation float64 `json:"deviation"`
+}
+
+// Ladder returns the rating ladder the battle counts towards
+func (bs *BattleState) Ladder() string {
+	if bs.IsPvP() {
+		return LadderPvP
+	}
+	return LadderPvE
+}
+
+// ratingScore converts a battle result into a Glicko-2 game score
+func ratingScore(result string) float64 {
+	switch result {
+	case "win":
+		return 1
+	case "draw":
+		return 0.5
+	}
+	return 0
+}
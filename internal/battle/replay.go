@@ -0,0 +1,200 @@
+package battle
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"pokemon-cli/internal/ruleset"
+	"reflect"
+	"time"
+)
+
+// ReplayVersion is the current replay format version. Bump it whenever the
+// format or the battle rules change in a way that old replays can't reproduce.
+const ReplayVersion = 1
+
+// Replay is everything needed to play a battle back: the seed, the starting
+// decks and every action taken, in order
+type Replay struct {
+	Version    int            `json:"version"`
+	BattleID   string         `json:"battle_id"`
+	Mode       string         `json:"mode"`
+	PvP        bool           `json:"pvp,omitempty"` // Both sides were played by users
+	Difficulty string         `json:"ai_difficulty,omitempty"`
+	Bot        string         `json:"ai_bot,omitempty"` // Strategy that played the AI side; its decisions are in the actions
+	Rules      Rules          `json:"rules"`
+	Ruleset    string         `json:"ruleset,omitempty"` // Name of the ruleset the battle was played under; missing means the default one
+	Seed       int64          `json:"seed"`
+	PlayerDeck []BattleCard   `json:"player_deck"` // Decks as they were when the battle started
+	AIDeck     []BattleCard   `json:"ai_deck"`
+	Bag        map[string]int `json:"bag,omitempty"` // Battle items the player brought
+	Actions    []ReplayAction `json:"actions"`
+	Winner     string         `json:"winner,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// ReplayAction is one successful ProcessMove or SwitchPokemon call (or their PvP counterparts)
+type ReplayAction struct {
+	Kind      string     `json:"kind"`           // "move" or "switch"
+	Side      string     `json:"side,omitempty"` // Side that acted in a PvP battle
+	Turn      int        `json:"turn"`           // Turn number the action was taken on
+	Move      string     `json:"move,omitempty"`
+	MoveIdx   *int       `json:"move_idx,omitempty"`
+	SwitchIdx int        `json:"switch_idx,omitempty"`
+	AI        []Decision `json:"ai,omitempty"` // What the Strategy playing the AI side decided, in order
+	Log       []string   `json:"log"`
+	Events    []Event    `json:"events,omitempty"` // Missing from replays recorded before events existed
+}
+
+// ReplayFrame is the battle as it stood after an action. The first frame is
+// the starting position and has no action.
+type ReplayFrame struct {
+	Action *ReplayAction
+	State  *BattleState
+	Events []Event
+	Log    []string
+}
+
+// newReplay starts recording a freshly created battle
+func newReplay(bs *BattleState) *Replay {
+	return &Replay{
+		Version:    ReplayVersion,
+		BattleID:   bs.ID,
+		Mode:       bs.Mode,
+		PvP:        bs.IsPvP(),
+		Difficulty: bs.AIDifficulty,
+		Bot:        bs.AIBot,
+		Rules:      bs.Rules,
+		Ruleset:    ruleset.Current().Name,
+		Seed:       bs.Seed,
+		PlayerDeck: cloneDeck(bs.PlayerDeck),
+		AIDeck:     cloneDeck(bs.AIDeck),
+		Bag:        maps.Clone(bs.Bag),
+		Actions:    []ReplayAction{},
+		CreatedAt:  bs.CreatedAt,
+	}
+}
+
+// record appends an action to the battle's replay, if it is recording one
+func (bs *BattleState) record(action ReplayAction) {
+	if bs.Replay == nil {
+		return
+	}
+	if action.MoveIdx != nil {
+		idx := *action.MoveIdx
+		action.MoveIdx = &idx
+	}
+	bs.Replay.Actions = append(bs.Replay.Actions, action)
+	if bs.BattleOver {
+		bs.Replay.Winner = bs.Winner
+	}
+}
+
+// Frames replays the battle from its seed and returns the state after every
+// action. It fails if the replay doesn't reproduce the recorded log, which
+// means it was made by an incompatible version of the battle rules.
+func (r *Replay) Frames() ([]ReplayFrame, error) {
+	if r.Version != ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d (expected %d)", r.Version, ReplayVersion)
+	}
+	played := r.Ruleset
+	if played == "" {
+		played = ruleset.Default().Name
+	}
+	if current := ruleset.Current().Name; played != current {
+		return nil, fmt.Errorf("replay was played under the %q ruleset, not %q", played, current)
+	}
+
+	bs := &BattleState{
+		ID:               r.BattleID,
+		Mode:             r.Mode,
+		AIDifficulty:     r.Difficulty,
+		AIBot:            r.Bot,
+		Rules:            r.Rules,
+		PlayerDeck:       cloneDeck(r.PlayerDeck),
+		AIDeck:           cloneDeck(r.AIDeck),
+		Bag:              maps.Clone(r.Bag),
+		TurnNumber:       1,
+		RoundNumber:      1,
+		WhoseTurn:        "player",
+		SacrificeCount:   make(map[int]int),
+		AISacrificeCount: make(map[int]int),
+		Seed:             r.Seed,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.CreatedAt,
+	}
+	bs.Field = startingField(r.Rules)
+	if r.PvP {
+		bs.PvPStatus = PvPActive
+		bs.WhoseTurn = SideBoth
+	}
+
+	started := []Event{{Type: EventBattleStarted, Mode: r.Mode}}
+	frames := []ReplayFrame{{
+		State:  bs.Clone(),
+		Events: started,
+		Log:    EventLog(started),
+	}}
+
+	for i := range r.Actions {
+		action := &r.Actions[i]
+
+		if r.Bot != "" {
+			// The strategy itself isn't available; play back what it decided
+			bs.strategy = &replayStrategy{decisions: action.AI}
+		}
+
+		var events []Event
+		var err error
+		switch {
+		case action.Kind == "move" && r.PvP:
+			events, err = ProcessPvPMove(bs, action.Side, action.Move, action.MoveIdx)
+		case action.Kind == "move":
+			events, err = ProcessMove(bs, action.Move, action.MoveIdx)
+		case action.Kind == "switch" && r.PvP:
+			events, err = SwitchPvPPokemon(bs, action.Side, action.SwitchIdx)
+		case action.Kind == "switch":
+			events, err = SwitchPokemon(bs, action.SwitchIdx)
+		default:
+			err = fmt.Errorf("unknown action kind %q", action.Kind)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("replay action %d failed: %w", i+1, err)
+		}
+
+		logEntries := EventLog(events)
+		diverged := !reflect.DeepEqual(logEntries, action.Log)
+		if action.Events != nil {
+			diverged = !reflect.DeepEqual(events, action.Events)
+		}
+		if diverged {
+			return nil, fmt.Errorf("replay diverged at action %d", i+1)
+		}
+
+		frames = append(frames, ReplayFrame{
+			Action: action,
+			State:  bs.Clone(),
+			Events: events,
+			Log:    logEntries,
+		})
+	}
+
+	return frames, nil
+}
+
+// EncodeReplay serializes a replay to the replay file format
+func EncodeReplay(r *Replay) ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// DecodeReplay parses a replay file, rejecting versions this build can't play
+func DecodeReplay(data []byte) (*Replay, error) {
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse replay: %w", err)
+	}
+	if r.Version == 0 || r.Version > ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+	return &r, nil
+}
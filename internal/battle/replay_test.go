@@ -0,0 +1,95 @@
+package battle
+
+import (
+	"pokemon-cli/internal/ruleset"
+	"reflect"
+	"testing"
+)
+
+func TestReplayFramesReproduceBattle(t *testing.T) {
+	bs := newSeededBattle(t, 99)
+	playScript(t, bs, testScript)
+
+	if bs.Replay == nil {
+		t.Fatal("battle did not record a replay")
+	}
+
+	data, err := EncodeReplay(bs.Replay)
+	if err != nil {
+		t.Fatalf("EncodeReplay failed: %v", err)
+	}
+	replay, err := DecodeReplay(data)
+	if err != nil {
+		t.Fatalf("DecodeReplay failed: %v", err)
+	}
+
+	frames, err := replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+
+	if len(frames) != len(replay.Actions)+1 {
+		t.Fatalf("got %d frames, want %d", len(frames), len(replay.Actions)+1)
+	}
+
+	last := frames[len(frames)-1].State
+	if !reflect.DeepEqual(last.PlayerDeck, bs.PlayerDeck) || !reflect.DeepEqual(last.AIDeck, bs.AIDeck) {
+		t.Error("final replay frame does not match the finished battle")
+	}
+	if last.Winner != bs.Winner || replay.Winner != bs.Winner {
+		t.Errorf("winner mismatch: frame %q, replay %q, battle %q", last.Winner, replay.Winner, bs.Winner)
+	}
+
+	// The first frame is the untouched starting position
+	first := frames[0].State
+	if first.PlayerDeck[0].HP != first.PlayerDeck[0].HPMax || first.AIDeck[0].HP != first.AIDeck[0].HPMax {
+		t.Error("first frame should show both Pokemon at full HP")
+	}
+}
+
+func TestReplayDetectsDivergence(t *testing.T) {
+	bs := newSeededBattle(t, 7)
+	playScript(t, bs, testScript)
+
+	replay := *bs.Replay
+	replay.Seed++ // a different seed can't reproduce the recorded log
+
+	if _, err := replay.Frames(); err == nil {
+		t.Error("expected replay with a tampered seed to diverge")
+	}
+}
+
+func TestReplayNeedsTheRulesetItWasPlayedUnder(t *testing.T) {
+	variant, err := ruleset.Parse([]byte(`{"name": "cheap-defence", "defend_cost_divisor": 4}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	ruleset.Use(variant)
+	defer ruleset.Use(ruleset.Default())
+
+	if cost := defendCost(100, ""); cost != 25 {
+		t.Errorf("defending costs %d under the variant, want 25", cost)
+	}
+	bs := newSeededBattle(t, 7)
+	playScript(t, bs, testScript)
+	if bs.Replay.Ruleset != "cheap-defence" {
+		t.Errorf("replay recorded ruleset %q, want the variant", bs.Replay.Ruleset)
+	}
+	if _, err := bs.Replay.Frames(); err != nil {
+		t.Errorf("Frames failed under the same ruleset: %v", err)
+	}
+
+	ruleset.Use(ruleset.Default())
+	if _, err := bs.Replay.Frames(); err == nil {
+		t.Error("expected a replay played under another ruleset to be refused")
+	}
+}
+
+func TestDecodeReplayRejectsUnknownVersion(t *testing.T) {
+	if _, err := DecodeReplay([]byte(`{"version": 999}`)); err == nil {
+		t.Error("expected error for a future replay version")
+	}
+	if _, err := DecodeReplay([]byte(`{}`)); err == nil {
+		t.Error("expected error for a replay without a version")
+	}
+}
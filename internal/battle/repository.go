@@ -3,11 +3,14 @@ package battle
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"pokemon-cli/internal/database"
+	"pokemon-cli/pkg/glicko2"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,23 +24,46 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 	return &Repository{db: db}
 }
 
+// Begin starts a transaction for saving a battle together with what it changed
+func (r *Repository) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// execer runs a statement on the pool or inside a transaction
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
 // SaveBattleSession saves a battle state to the database
 func (r *Repository) SaveBattleSession(ctx context.Context, state *BattleState) error {
+	return saveBattleSession(ctx, r.db, state)
+}
+
+// SaveBattleSessionInTx saves a battle state as part of tx
+func (r *Repository) SaveBattleSessionInTx(ctx context.Context, tx pgx.Tx, state *BattleState) error {
+	return saveBattleSession(ctx, tx, state)
+}
+
+func saveBattleSession(ctx context.Context, db execer, state *BattleState) error {
 	stateJSON, err := json.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("failed to marshal battle state: %w", err)
 	}
 
 	query := `
-		INSERT INTO battle_sessions (session_id, user_id, state_json, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO battle_sessions (session_id, user_id, opponent_user_id, state_json, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6)
 		ON CONFLICT (session_id) 
 		DO UPDATE SET 
 			state_json = EXCLUDED.state_json,
 			updated_at = EXCLUDED.updated_at
 	`
 
-	_, err = r.db.Exec(ctx, query, state.ID, state.UserID, stateJSON, state.CreatedAt, state.UpdatedAt)
+	_, err = db.Exec(ctx, query, state.ID, state.UserID, state.OpponentUserID, stateJSON, state.CreatedAt, state.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to save battle session: %w", err)
 	}
@@ -45,6 +71,11 @@ func (r *Repository) SaveBattleSession(ctx context.Context, state *BattleState)
 	return nil
 }
 
+// querier runs a query on the pool or inside a transaction
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 // GetBattleSession retrieves a battle state from the database
 func (r *Repository) GetBattleSession(ctx context.Context, sessionID string) (*BattleState, error) {
 	query := `
@@ -52,9 +83,25 @@ func (r *Repository) GetBattleSession(ctx context.Context, sessionID string) (*B
 		FROM battle_sessions 
 		WHERE session_id = $1
 	`
+	return getBattleSession(ctx, r.db, query, sessionID)
+}
+
+// GetBattleSessionForUpdateInTx retrieves a battle state as part of tx and
+// locks its session until tx ends, so no other update to the battle, from this
+// server or another, can come in between
+func (r *Repository) GetBattleSessionForUpdateInTx(ctx context.Context, tx pgx.Tx, sessionID string) (*BattleState, error) {
+	query := `
+		SELECT state_json 
+		FROM battle_sessions 
+		WHERE session_id = $1
+		FOR UPDATE
+	`
+	return getBattleSession(ctx, tx, query, sessionID)
+}
 
+func getBattleSession(ctx context.Context, db querier, query, sessionID string) (*BattleState, error) {
 	var stateJSON []byte
-	err := r.db.QueryRow(ctx, query, sessionID).Scan(&stateJSON)
+	err := db.QueryRow(ctx, query, sessionID).Scan(&stateJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get battle session: %w", err)
 	}
@@ -70,9 +117,18 @@ func (r *Repository) GetBattleSession(ctx context.Context, sessionID string) (*B
 
 // DeleteBattleSession removes a battle session from the database
 func (r *Repository) DeleteBattleSession(ctx context.Context, sessionID string) error {
+	return deleteBattleSession(ctx, r.db, sessionID)
+}
+
+// DeleteBattleSessionInTx removes a battle session as part of tx
+func (r *Repository) DeleteBattleSessionInTx(ctx context.Context, tx pgx.Tx, sessionID string) error {
+	return deleteBattleSession(ctx, tx, sessionID)
+}
+
+func deleteBattleSession(ctx context.Context, db execer, sessionID string) error {
 	query := `DELETE FROM battle_sessions WHERE session_id = $1`
 
-	_, err := r.db.Exec(ctx, query, sessionID)
+	_, err := db.Exec(ctx, query, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to delete battle session: %w", err)
 	}
@@ -117,6 +173,53 @@ func (r *Repository) GetUserBattleSessions(ctx context.Context, userID int) ([]*
 	return sessions, nil
 }
 
+// GetPendingChallenges retrieves the PvP challenges waiting for userID to accept them
+func (r *Repository) GetPendingChallenges(ctx context.Context, userID int) ([]*BattleState, error) {
+	query := `
+		SELECT state_json 
+		FROM battle_sessions 
+		WHERE opponent_user_id = $1 AND state_json->>'pvp_status' = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, PvPPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending challenges: %w", err)
+	}
+	defer rows.Close()
+
+	challenges := []*BattleState{}
+	for rows.Next() {
+		var stateJSON []byte
+		if err := rows.Scan(&stateJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan battle session: %w", err)
+		}
+
+		var state BattleState
+		if err := json.Unmarshal(stateJSON, &state); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal battle state: %w", err)
+		}
+
+		challenges = append(challenges, &state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending challenges: %w", err)
+	}
+
+	return challenges, nil
+}
+
+// GetUserIDByUsername looks up the ID of the user called username
+func (r *Repository) GetUserIDByUsername(ctx context.Context, username string) (int, error) {
+	var userID int
+	err := r.db.QueryRow(ctx, `SELECT id FROM users WHERE username = $1`, username).Scan(&userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	return userID, nil
+}
+
 // CleanupExpiredSessions removes battle sessions older than the specified duration
 func (r *Repository) CleanupExpiredSessions(ctx context.Context, expiryDuration time.Duration) (int64, error) {
 	expiryTime := time.Now().Add(-expiryDuration)
@@ -139,7 +242,8 @@ func (r *Repository) CleanupExpiredSessions(ctx context.Context, expiryDuration
 func (r *Repository) GetUserDeck(ctx context.Context, userID int) ([]database.PlayerCard, error) {
 	query := `
 		SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
-			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, created_at, updated_at
+			base_sp_attack, base_sp_defense,
+			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, held_item, created_at, updated_at
 		FROM player_cards
 		WHERE user_id = $1 AND in_deck = TRUE
 		ORDER BY deck_position ASC
@@ -157,8 +261,9 @@ func (r *Repository) GetUserDeck(ctx context.Context, userID int) ([]database.Pl
 		err := rows.Scan(
 			&card.ID, &card.UserID, &card.PokemonName, &card.Level, &card.XP,
 			&card.BaseHP, &card.BaseAttack, &card.BaseDefense, &card.BaseSpeed,
+			&card.BaseSpAttack, &card.BaseSpDefense,
 			&card.Types, &card.Moves, &card.Sprite,
-			&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition,
+			&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition, &card.HeldItem,
 			&card.CreatedAt, &card.UpdatedAt,
 		)
 		if err != nil {
@@ -174,6 +279,50 @@ func (r *Repository) GetUserDeck(ctx context.Context, userID int) ([]database.Pl
 	return cards, nil
 }
 
+// GetUserBag retrieves the battle items in the user's bag, by name
+func (r *Repository) GetUserBag(ctx context.Context, userID int) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, `SELECT item, quantity FROM user_items WHERE user_id = $1 AND quantity > 0`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user bag: %w", err)
+	}
+	defer rows.Close()
+
+	bag := make(map[string]int)
+	for rows.Next() {
+		var item database.UserItem
+		if err := rows.Scan(&item.Item, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan item: %w", err)
+		}
+		bag[item.Item] = item.Quantity
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating items: %w", err)
+	}
+
+	return bag, nil
+}
+
+// ErrItemUsedUp is returned when a battle uses an item the user's bag no
+// longer holds, such as one spent in another battle since this one started
+var ErrItemUsedUp = errors.New("item is no longer in your bag")
+
+// UseUserItemInTx takes one of item out of the user's bag as part of tx
+func (r *Repository) UseUserItemInTx(ctx context.Context, tx pgx.Tx, userID int, item string) error {
+	result, err := tx.Exec(ctx, `
+		UPDATE user_items
+		SET quantity = quantity - 1
+		WHERE user_id = $1 AND item = $2 AND quantity > 0
+	`, userID, item)
+	if err != nil {
+		return fmt.Errorf("failed to use item: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrItemUsedUp
+	}
+	return nil
+}
+
 func (r *Repository) RecordBattleHistory(ctx context.Context, userID int, mode, result string, coinsEarned, duration int) error {
 	query := `
 		INSERT INTO battle_history (user_id, mode, result, coins_earned, duration)
@@ -385,3 +534,98 @@ func (r *Repository) UpdatePlayerStatsInTx(ctx context.Context, tx pgx.Tx, userI
 
 	return nil
 }
+
+// UpdateSeasonStatsInTx counts a battle result towards the current season
+func (r *Repository) UpdateSeasonStatsInTx(ctx context.Context, tx pgx.Tx, userID int, result string) error {
+	var query string
+	switch result {
+	case "win":
+		query = `
+			INSERT INTO player_stats (user_id, season_wins, season_win_streak, season_best_win_streak, updated_at)
+			VALUES ($1, 1, 1, 1, NOW())
+			ON CONFLICT (user_id) DO UPDATE
+			SET season_wins = player_stats.season_wins + 1,
+			    season_win_streak = player_stats.season_win_streak + 1,
+			    season_best_win_streak = GREATEST(player_stats.season_best_win_streak, player_stats.season_win_streak + 1),
+			    updated_at = NOW()
+		`
+	case "loss":
+		query = `
+			INSERT INTO player_stats (user_id, season_losses, updated_at)
+			VALUES ($1, 1, NOW())
+			ON CONFLICT (user_id) DO UPDATE
+			SET season_losses = player_stats.season_losses + 1,
+			    season_win_streak = 0,
+			    updated_at = NOW()
+		`
+	case "draw":
+		query = `
+			INSERT INTO player_stats (user_id, season_draws, updated_at)
+			VALUES ($1, 1, NOW())
+			ON CONFLICT (user_id) DO UPDATE
+			SET season_draws = player_stats.season_draws + 1,
+			    season_win_streak = 0,
+			    updated_at = NOW()
+		`
+	default:
+		return fmt.Errorf("invalid battle result: %s", result)
+	}
+
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to update season stats: %w", err)
+	}
+	return nil
+}
+
+// GetRating returns the user's rating on a ladder in mode, or the starting
+// rating if they haven't played a rated battle there yet
+func (r *Repository) GetRating(ctx context.Context, userID int, mode, ladder string) (glicko2.Rating, error) {
+	rating := glicko2.Default()
+	err := r.db.QueryRow(ctx, `
+		SELECT rating, deviation, volatility
+		FROM player_ratings
+		WHERE user_id = $1 AND mode = $2 AND ladder = $3
+	`, userID, mode, ladder).Scan(&rating.Rating, &rating.Deviation, &rating.Volatility)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return rating, fmt.Errorf("failed to get rating: %w", err)
+	}
+	return rating, nil
+}
+
+// UpdateRatingInTx rates the user's battle result against an opponent's
+// rating, treating the battle as a rating period of its own
+func (r *Repository) UpdateRatingInTx(ctx context.Context, tx pgx.Tx, userID int, mode, ladder, result string, opponent glicko2.Rating) (*RatingChange, error) {
+	old := glicko2.Default()
+	err := tx.QueryRow(ctx, `
+		SELECT rating, deviation, volatility
+		FROM player_ratings
+		WHERE user_id = $1 AND mode = $2 AND ladder = $3
+		FOR UPDATE
+	`, userID, mode, ladder).Scan(&old.Rating, &old.Deviation, &old.Volatility)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get rating: %w", err)
+	}
+
+	updated := glicko2.Update(old, []glicko2.Result{{Opponent: opponent, Score: ratingScore(result)}})
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO player_ratings (user_id, mode, ladder, rating, deviation, volatility, games, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 1, NOW())
+		ON CONFLICT (user_id, mode, ladder) DO UPDATE
+		SET rating = $4,
+		    deviation = $5,
+		    volatility = $6,
+		    games = player_ratings.games + 1,
+		    updated_at = NOW()
+	`, userID, mode, ladder, updated.Rating, updated.Deviation, updated.Volatility)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rating: %w", err)
+	}
+
+	return &RatingChange{
+		Ladder:    ladder,
+		OldRating: old.Rating,
+		NewRating: updated.Rating,
+		Deviation: updated.Deviation,
+	}, nil
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/progression"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/pkg/glicko2"
 	"strings"
 	"time"
 
@@ -16,7 +19,9 @@ type ComprehensiveRewards struct {
 	XPGains                   []PokemonXPGain                  `json:"xp_gains"`
 	NewlyUnlockedAchievements []database.AchievementWithStatus `json:"newly_unlocked_achievements,omitempty"`
 	BattleHistoryRecorded     bool                             `json:"battle_history_recorded"`
+	BattleHistoryID           int                              `json:"battle_history_id,omitempty"`
 	StatsUpdated              bool                             `json:"stats_updated"`
+	Rating                    *RatingChange                    `json:"rating,omitempty"`
 }
 
 type BattleRewards struct {
@@ -27,14 +32,16 @@ type BattleRewards struct {
 
 // LevelUpInfo contains information about a Pokemon that leveled up (legacy)
 type LevelUpInfo struct {
-	CardID     int    `json:"card_id"`
-	Name       string `json:"name"`
-	OldLevel   int    `json:"old_level"`
-	NewLevel   int    `json:"new_level"`
-	NewHP      int    `json:"new_hp"`
-	NewAttack  int    `json:"new_attack"`
-	NewDefense int    `json:"new_defense"`
-	NewSpeed   int    `json:"new_speed"`
+	CardID       int    `json:"card_id"`
+	Name         string `json:"name"`
+	OldLevel     int    `json:"old_level"`
+	NewLevel     int    `json:"new_level"`
+	NewHP        int    `json:"new_hp"`
+	NewAttack    int    `json:"new_attack"`
+	NewDefense   int    `json:"new_defense"`
+	NewSpAttack  int    `json:"new_sp_attack"`
+	NewSpDefense int    `json:"new_sp_defense"`
+	NewSpeed     int    `json:"new_speed"`
 }
 
 func CalculateAllRewards(bs *BattleState) *ComprehensiveRewards {
@@ -45,28 +52,12 @@ func CalculateAllRewards(bs *BattleState) *ComprehensiveRewards {
 		StatsUpdated:              false,
 	}
 
-	// Calculate coins based on mode and outcome
-	switch bs.Winner {
-	case "player":
-		// Win rewards
-		switch bs.Mode {
-		case "1v1":
-			rewards.CoinsEarned = 50
-		case "5v5":
-			rewards.CoinsEarned = 150
-		}
-	case "draw":
-		// Draw rewards (more than loss, less than win)
-		switch bs.Mode {
-		case "1v1":
-			rewards.CoinsEarned = 25
-		case "5v5":
-			rewards.CoinsEarned = 75
-		}
-	default:
-		// Loss consolation coins
-		rewards.CoinsEarned = 10
-	}
+	// Calculate coins based on mode and outcome (a draw pays more than a
+	// loss, less than a win)
+	rewards.CoinsEarned = ruleset.Current().Coins(bs.Mode, bs.Winner)
+
+	// Harder AI opponents pay out more
+	rewards.CoinsEarned = ScaleReward(rewards.CoinsEarned, bs.Difficulty())
 
 	// Note: XP gains will be populated after applying XP and level ups
 	// Note: Achievements will be populated after checking achievements
@@ -81,33 +72,30 @@ func CalculateRewards(bs *BattleState) *BattleRewards {
 		LevelUps: []LevelUpInfo{},
 	}
 
-	// Calculate coins based on mode and outcome
+	// Calculate coins based on mode and outcome: anything but a win pays
+	// consolation coins
+	rules := ruleset.Current()
 	if bs.Winner == "player" {
-		switch bs.Mode {
-		case "1v1":
-			rewards.CoinsEarned = 50
-		case "5v5":
-			rewards.CoinsEarned = 150
-		}
+		rewards.CoinsEarned = rules.Coins(bs.Mode, "player")
 	} else {
-		// Consolation coins for losing
-		rewards.CoinsEarned = 10
+		rewards.CoinsEarned = rules.Coins(bs.Mode, "ai")
 	}
 
 	// Calculate XP for participating Pokemon
 	if bs.Winner == "player" {
+		xp := rules.Progression.BattleXP(bs.Mode, "player")
 		switch bs.Mode {
 		case "1v1":
-			// Award 20 XP to the winning Pokemon
+			// Award XP to the winning Pokemon
 			playerCard := bs.GetActivePlayerCard()
 			if playerCard != nil {
-				rewards.XPGained[playerCard.CardID] = 20
+				rewards.XPGained[playerCard.CardID] = xp
 			}
 		case "5v5":
-			// Award 15 XP to each Pokemon that participated (had HP > 0 at some point)
+			// Award XP to each Pokemon that participated (had HP > 0 at some point)
 			for _, card := range bs.PlayerDeck {
 				// Award XP to all cards (they all participated in 5v5)
-				rewards.XPGained[card.CardID] = 15
+				rewards.XPGained[card.CardID] = xp
 			}
 		}
 	}
@@ -135,74 +123,24 @@ func ApplyRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *BattleS
 	}
 
 	// Apply XP to each card and check for level ups
-	for cardID, xp := range rewards.XPGained {
-		// Get current card stats
-		var currentLevel, currentXP, baseHP, baseAttack, baseDefense, baseSpeed int
-		var pokemonName string
-		err = tx.QueryRow(ctx, `
-			SELECT level, xp, base_hp, base_attack, base_defense, base_speed, pokemon_name
-			FROM player_cards
-			WHERE id = $1 AND user_id = $2
-		`, cardID, userID).Scan(&currentLevel, &currentXP, &baseHP, &baseAttack, &baseDefense, &baseSpeed, &pokemonName)
-		if err != nil {
-			return fmt.Errorf("failed to get card stats: %w", err)
-		}
-
-		// Add XP
-		newXP := currentXP + xp
-		newLevel := currentLevel
-
-		// Check for level ups (max level 50)
-		for newLevel < 50 {
-			xpRequired := 100 * newLevel
-			if newXP >= xpRequired {
-				newXP -= xpRequired
-				newLevel++
-			} else {
-				break
-			}
-		}
-
-		// If leveled up, calculate new stats
-		if newLevel > currentLevel {
-			// Calculate new stats based on level
-			multiplier := 1.0 + (float64(newLevel-1) * 0.03) // 3% per level for HP
-			newHP := int(float64(baseHP) * multiplier)
-			newAttack := int(float64(baseAttack) * (1.0 + float64(newLevel-1)*0.02))
-			newDefense := int(float64(baseDefense) * (1.0 + float64(newLevel-1)*0.02))
-			newSpeed := int(float64(baseSpeed) * (1.0 + float64(newLevel-1)*0.01))
-
-			// Update card in database
-			_, err = tx.Exec(ctx, `
-				UPDATE player_cards
-				SET level = $1, xp = $2
-				WHERE id = $3 AND user_id = $4
-			`, newLevel, newXP, cardID, userID)
-			if err != nil {
-				return fmt.Errorf("failed to update card level: %w", err)
-			}
-
-			// Add to level up info
+	gains, err := levelUpCardsInTx(ctx, tx, userID, rewards.XPGained)
+	if err != nil {
+		return err
+	}
+	for _, gain := range gains {
+		if gain.LeveledUp {
 			rewards.LevelUps = append(rewards.LevelUps, LevelUpInfo{
-				CardID:     cardID,
-				Name:       pokemonName,
-				OldLevel:   currentLevel,
-				NewLevel:   newLevel,
-				NewHP:      newHP,
-				NewAttack:  newAttack,
-				NewDefense: newDefense,
-				NewSpeed:   newSpeed,
+				CardID:       gain.CardID,
+				Name:         gain.PokemonName,
+				OldLevel:     gain.OldLevel,
+				NewLevel:     gain.NewLevel,
+				NewHP:        gain.NewHP,
+				NewAttack:    gain.NewAttack,
+				NewDefense:   gain.NewDefense,
+				NewSpAttack:  gain.NewSpAttack,
+				NewSpDefense: gain.NewSpDefense,
+				NewSpeed:     gain.NewSpeed,
 			})
-		} else {
-			// Just update XP
-			_, err = tx.Exec(ctx, `
-				UPDATE player_cards
-				SET xp = $1
-				WHERE id = $2 AND user_id = $3
-			`, newXP, cardID, userID)
-			if err != nil {
-				return fmt.Errorf("failed to update card XP: %w", err)
-			}
 		}
 	}
 
@@ -214,7 +152,9 @@ func ApplyRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *BattleS
 	return nil
 }
 
-func ApplyAllRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *BattleState, rewards *ComprehensiveRewards, statsService StatsService, repo *Repository) error {
+// ApplyAllRewards records a finished battle for userID, who played it from the
+// "player" side of bs, and rates the result against the opponent's rating
+func ApplyAllRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *BattleState, rewards *ComprehensiveRewards, opponentRating glicko2.Rating, statsService StatsService, repo *Repository) error {
 	// Start a transaction for consistency
 	tx, err := db.Begin(ctx)
 	if err != nil {
@@ -231,92 +171,12 @@ func ApplyAllRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *Batt
 		return fmt.Errorf("failed to update coins: %w", err)
 	}
 
-	xpMap := CalculateXPForBattle(bs)
-
 	// Apply XP and handle level-ups within the transaction
-	for cardID, xpGained := range xpMap {
-		// Get current card data
-		query := `
-			SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed
-			FROM player_cards
-			WHERE id = $1 AND user_id = $2
-		`
-
-		var id, uid, level, xp, baseHP, baseAttack, baseDefense, baseSpeed int
-		var pokemonName string
-
-		err := tx.QueryRow(ctx, query, cardID, userID).Scan(
-			&id, &uid, &pokemonName, &level, &xp,
-			&baseHP, &baseAttack, &baseDefense, &baseSpeed,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to get card %d: %w", cardID, err)
-		}
-
-		// Calculate old stats
-		oldStats := calculateStatsForLevel(level, baseHP, baseAttack, baseDefense, baseSpeed)
-
-		// Track level ups
-		oldLevel := level
-		newXP := xp + xpGained
-		newLevel := level
-
-		// Process level ups
-		for newLevel < 50 {
-			xpRequired := 100 * newLevel
-			if newXP >= xpRequired {
-				newXP -= xpRequired
-				newLevel++
-			} else {
-				break
-			}
-		}
-
-		// Cap at level 50
-		if newLevel >= 50 {
-			newLevel = 50
-			newXP = 0
-		}
-
-		// Calculate new stats
-		newStats := calculateStatsForLevel(newLevel, baseHP, baseAttack, baseDefense, baseSpeed)
-
-		// Update database
-		updateQuery := `
-			UPDATE player_cards
-			SET level = $1, xp = $2, updated_at = $3
-			WHERE id = $4
-		`
-
-		_, err = tx.Exec(ctx, updateQuery, newLevel, newXP, time.Now(), cardID)
-		if err != nil {
-			return fmt.Errorf("failed to update card %d: %w", cardID, err)
-		}
-
-		// Record XP gain
-		xpGain := PokemonXPGain{
-			CardID:      cardID,
-			PokemonName: pokemonName,
-			XPGained:    xpGained,
-			OldLevel:    oldLevel,
-			NewLevel:    newLevel,
-			LeveledUp:   newLevel > oldLevel,
-		}
-
-		// Include stat changes if leveled up
-		if xpGain.LeveledUp {
-			xpGain.OldHP = oldStats.HP
-			xpGain.NewHP = newStats.HP
-			xpGain.OldAttack = oldStats.Attack
-			xpGain.NewAttack = newStats.Attack
-			xpGain.OldDefense = oldStats.Defense
-			xpGain.NewDefense = newStats.Defense
-			xpGain.OldSpeed = oldStats.Speed
-			xpGain.NewSpeed = newStats.Speed
-		}
-
-		rewards.XPGains = append(rewards.XPGains, xpGain)
+	xpGains, err := levelUpCardsInTx(ctx, tx, userID, CalculateXPForBattle(bs))
+	if err != nil {
+		return err
 	}
+	rewards.XPGains = append(rewards.XPGains, xpGains...)
 
 	duration := int(time.Since(bs.CreatedAt).Seconds())
 	result := "loss"
@@ -327,14 +187,42 @@ func ApplyAllRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *Batt
 		result = "draw"
 	}
 
-	_, err = tx.Exec(ctx, `
-		INSERT INTO battle_history (user_id, mode, result, coins_earned, duration)
-		VALUES ($1, $2, $3, $4, $5)
-	`, userID, bs.Mode, result, rewards.CoinsEarned, duration)
+	// PvP battles have no AI, so their difficulty is left NULL
+	var difficulty *string
+	if !bs.IsPvP() {
+		d := bs.Difficulty()
+		difficulty = &d
+	}
+
+	var historyID int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO battle_history (user_id, mode, result, coins_earned, duration, ai_difficulty)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, userID, bs.Mode, result, rewards.CoinsEarned, duration, difficulty).Scan(&historyID)
 	if err != nil {
 		return fmt.Errorf("failed to record battle history: %w", err)
 	}
 	rewards.BattleHistoryRecorded = true
+	rewards.BattleHistoryID = historyID
+
+	for seq, turn := range bs.Turns {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO battle_turns (
+				battle_history_id, seq, turn, round, player_pokemon, ai_pokemon,
+				player_move, player_move_name, ai_move, ai_move_name,
+				damage_dealt, damage_taken, player_hp_delta, player_stamina_delta,
+				ai_hp_delta, ai_stamina_delta, player_knocked_out, ai_knocked_out
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		`, historyID, seq, turn.Turn, turn.Round, turn.PlayerPokemon, turn.AIPokemon,
+			turn.PlayerMove, turn.PlayerMoveName, turn.AIMove, turn.AIMoveName,
+			turn.DamageDealt, turn.DamageTaken, turn.PlayerHPDelta, turn.PlayerStaminaDelta,
+			turn.AIHPDelta, turn.AIStaminaDelta, turn.PlayerKnockedOut, turn.AIKnockedOut)
+		if err != nil {
+			return fmt.Errorf("failed to record battle turn %d: %w", seq, err)
+		}
+	}
 
 	if repo != nil {
 		err = repo.UpdatePlayerStatsInTx(ctx, tx, userID, bs.Mode, result, rewards.CoinsEarned)
@@ -342,6 +230,15 @@ func ApplyAllRewards(ctx context.Context, db *pgxpool.Pool, userID int, bs *Batt
 			return fmt.Errorf("failed to update player stats: %w", err)
 		}
 		rewards.StatsUpdated = true
+
+		if err = repo.UpdateSeasonStatsInTx(ctx, tx, userID, result); err != nil {
+			return err
+		}
+
+		rewards.Rating, err = repo.UpdateRatingInTx(ctx, tx, userID, bs.Mode, bs.Ladder(), result, opponentRating)
+		if err != nil {
+			return fmt.Errorf("failed to update rating: %w", err)
+		}
 	}
 
 	for _, gain := range rewards.XPGains {
@@ -432,15 +329,12 @@ func RecordBattleHistory(ctx context.Context, db *pgxpool.Pool, userID int, bs *
 }
 
 // GetCurrentStats calculates current stats for a card based on level
-func GetCurrentStats(baseHP, baseAttack, baseDefense, baseSpeed, level int) database.CardStats {
-	multiplier := 1.0 + (float64(level-1) * 0.03) // 3% per level for HP
-	return database.CardStats{
-		HP:      int(float64(baseHP) * multiplier),
-		Attack:  int(float64(baseAttack) * (1.0 + float64(level-1)*0.02)),
-		Defense: int(float64(baseDefense) * (1.0 + float64(level-1)*0.02)),
-		Speed:   int(float64(baseSpeed) * (1.0 + float64(level-1)*0.01)),
-		Stamina: int(float64(baseSpeed) * 2 * (1.0 + float64(level-1)*0.01)),
-	}
+func GetCurrentStats(baseHP, baseAttack, baseDefense, baseSpAttack, baseSpDefense, baseSpeed, level int) database.CardStats {
+	stats := ruleset.Current().Progression.Stats(level, progression.Stats{
+		HP: baseHP, Attack: baseAttack, Defense: baseDefense,
+		SpAttack: baseSpAttack, SpDefense: baseSpDefense, Speed: baseSpeed,
+	})
+	return database.CardStats(stats)
 }
 
 func AddAIPokemonToCollection(ctx context.Context, db *pgxpool.Pool, userID int, aiCard BattleCard) (*database.PlayerCard, error) {
@@ -457,21 +351,23 @@ func AddAIPokemonToCollection(ctx context.Context, db *pgxpool.Pool, userID int,
 
 	// Create the player card at level 1 with 0 XP
 	playerCard := &database.PlayerCard{
-		UserID:       userID,
-		PokemonName:  aiCard.Name,
-		Level:        1,
-		XP:           0,
-		BaseHP:       aiCard.HPMax,
-		BaseAttack:   aiCard.Attack,
-		BaseDefense:  aiCard.Defense,
-		BaseSpeed:    aiCard.Speed,
-		Types:        typesJSON,
-		Moves:        movesJSON,
-		Sprite:       aiCard.Sprite,
-		IsLegendary:  false, // Will be determined by the Pokemon name
-		IsMythical:   false, // Will be determined by the Pokemon name
-		InDeck:       false, // Not added to deck automatically
-		DeckPosition: nil,
+		UserID:        userID,
+		PokemonName:   aiCard.Name,
+		Level:         1,
+		XP:            0,
+		BaseHP:        aiCard.HPMax,
+		BaseAttack:    aiCard.Attack,
+		BaseDefense:   aiCard.Defense,
+		BaseSpAttack:  aiCard.SpAttack,
+		BaseSpDefense: aiCard.SpDefense,
+		BaseSpeed:     aiCard.Speed,
+		Types:         typesJSON,
+		Moves:         movesJSON,
+		Sprite:        aiCard.Sprite,
+		IsLegendary:   false, // Will be determined by the Pokemon name
+		IsMythical:    false, // Will be determined by the Pokemon name
+		InDeck:        false, // Not added to deck automatically
+		DeckPosition:  nil,
 	}
 
 	// Check if legendary or mythical based on Pokemon name
@@ -498,15 +394,17 @@ func AddAIPokemonToCollection(ctx context.Context, db *pgxpool.Pool, userID int,
 	query := `
 		INSERT INTO player_cards (
 			user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
+			base_sp_attack, base_sp_defense,
 			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id, created_at, updated_at
 	`
 
 	err = db.QueryRow(ctx, query,
 		playerCard.UserID, playerCard.PokemonName, playerCard.Level, playerCard.XP,
 		playerCard.BaseHP, playerCard.BaseAttack, playerCard.BaseDefense, playerCard.BaseSpeed,
+		playerCard.BaseSpAttack, playerCard.BaseSpDefense,
 		playerCard.Types, playerCard.Moves, playerCard.Sprite,
 		playerCard.IsLegendary, playerCard.IsMythical, playerCard.InDeck, playerCard.DeckPosition,
 	).Scan(&playerCard.ID, &playerCard.CreatedAt, &playerCard.UpdatedAt)
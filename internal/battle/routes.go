@@ -1,6 +1,7 @@
 package battle
 
 import (
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -20,6 +21,16 @@ func RegisterRoutes(app *fiber.App, handler *Handler, authMiddleware func(*fiber
 	battleAuth.Get("/state", handler.GetBattleStateEnhanced)
 	battleAuth.Post("/switch", handler.SwitchPokemonHandler)
 	battleAuth.Post("/select-reward", handler.SelectRewardHandler)
+	battleAuth.Get("/:id/replay", handler.GetBattleReplay)
+
+	// PvP challenges; accepted battles are played through /move, /state and /switch
+	battleAuth.Post("/pvp/challenge", handler.ChallengeHandler)
+	battleAuth.Get("/pvp/challenges", handler.ListChallengesHandler)
+	battleAuth.Post("/pvp/accept", handler.AcceptChallengeHandler)
+	battleAuth.Post("/pvp/decline", handler.DeclineChallengeHandler)
+
+	// Live battle updates; browsers pass the JWT as the token query parameter
+	battle.Get("/ws", SocketToken, authMiddleware, handler.UpgradeBattleSocket, websocket.New(handler.BattleSocket))
 
 	// Cleanup endpoint (can be called by cron job or admin)
 	battle.Post("/cleanup-sessions", handler.CleanupExpiredSessions)
@@ -0,0 +1,159 @@
+package battle
+
+import (
+	"fmt"
+	"pokemon-cli/game/core"
+	"pokemon-cli/internal/pokemon"
+	"slices"
+	"strings"
+)
+
+// Rules are the optional rules a battle is played with. The zero value is the
+// standard game.
+type Rules struct {
+	// SpeedOrder makes the faster active Pokemon attack first when both sides
+	// attack. Its damage lands before the slower Pokemon acts, so a knockout
+	// stops the slower attack. Ties are broken by the battle's seed.
+	SpeedOrder bool `json:"speed_order,omitempty"`
+
+	// Damage is the damage model attacks are resolved with, DamageClassic when
+	// empty
+	Damage string `json:"damage,omitempty"`
+
+	// Field is the weather or terrain the battle starts with, which lasts the
+	// whole battle unless a move replaces it. FieldRandom draws one from the
+	// battle's seed; SetRules records the one drawn.
+	Field string `json:"field,omitempty"`
+
+	// ItemLimit caps how many items from their bag the player may use in the
+	// battle. 0 uses the default for the battle's mode; NoItems forbids them.
+	ItemLimit int `json:"item_limit,omitempty"`
+}
+
+// Damage models a battle can be played with
+const (
+	DamageClassic = "classic" // The original damage formula
+	DamageModern  = "modern"  // Moves can miss and land critical hits, and get a same-type attack bonus
+)
+
+// Validate checks that the rules are ones a battle can be played with
+func (r Rules) Validate() error {
+	switch r.Damage {
+	case "", DamageClassic, DamageModern:
+	default:
+		return fmt.Errorf("invalid damage model %q (must be %s or %s)", r.Damage, DamageClassic, DamageModern)
+	}
+	if r.Field != "" && r.Field != FieldRandom && !slices.Contains(fieldEffects, r.Field) {
+		return fmt.Errorf("invalid field %q (must be %s or one of %s)", r.Field, FieldRandom, strings.Join(fieldEffects, ", "))
+	}
+	if r.ItemLimit < NoItems {
+		return fmt.Errorf("invalid item limit %d (must be %d for no items, 0 for the default or more)", r.ItemLimit, NoItems)
+	}
+	return nil
+}
+
+// SetRules sets the optional rules of a battle that hasn't started playing yet
+func (bs *BattleState) SetRules(rules Rules) error {
+	if len(bs.Turns) > 0 {
+		return fmt.Errorf("rules can't change once the battle has started")
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	if rules.Field == FieldRandom {
+		rules.Field = randomField(bs.Seed)
+	}
+
+	bs.Rules = rules
+	bs.Field = startingField(rules)
+	if bs.Replay != nil {
+		bs.Replay.Rules = rules
+	}
+	return nil
+}
+
+// attacker is one side's attack in a turn where both sides attack
+type attacker struct {
+	side    string
+	card    *pokemon.Card
+	target  *pokemon.Card
+	moveIdx int
+}
+
+// resolveSpeedOrder resolves a turn where both sides attack under the speed
+// order rule: the faster Pokemon attacks first and the slower one only attacks
+// if it is still standing
+func resolveSpeedOrder(bs *BattleState, pCard, aCard *pokemon.Card, playerMoveIdx, aiMoveIdx int) []Event {
+	order := []attacker{
+		{side: "player", card: pCard, target: aCard, moveIdx: playerMoveIdx},
+		{side: "ai", card: aCard, target: pCard, moveIdx: aiMoveIdx},
+	}
+	if firstMover(bs, pCard, aCard) == "ai" {
+		order[0], order[1] = order[1], order[0]
+	}
+
+	events := []Event{{Type: EventMovedFirst, Side: order[0].side, Pokemon: order[0].card.Name}}
+	for _, a := range order {
+		if a.card.HP <= 0 {
+			break
+		}
+		hpBefore := a.target.HP
+		hit := bs.hit(a.card, a.target, false, a.moveIdx)
+		a.target.HP -= hit.Damage
+		a.card.Stamina -= a.card.Moves[a.moveIdx].StaminaCost
+		events = append(events, damageEvents(a.side, hit)...)
+		// A focus sash lets the slower Pokemon hang on and still attack
+		events = append(events, endure(opponent(a.side), a.target, hpBefore)...)
+	}
+	return events
+}
+
+// firstMover returns the side with the faster active Pokemon, flipping a coin
+// from the battle's random source on a tie
+func firstMover(bs *BattleState, pCard, aCard *pokemon.Card) string {
+	switch {
+	case pCard.Speed > aCard.Speed:
+		return "player"
+	case aCard.Speed > pCard.Speed:
+		return "ai"
+	case bs.Rand().Intn(2) == 0:
+		return "player"
+	default:
+		return "ai"
+	}
+}
+
+// hit works out an attack with the battle's damage model, boosted or weakened
+// by the weather and terrain, boosted by the attacker's held item and changed by
+// both sides' abilities
+func (bs *BattleState) hit(attacker, defender *pokemon.Card, defending bool, moveIdx int) core.Hit {
+	var hit core.Hit
+	if bs.Rules.Damage == DamageModern {
+		hit = core.CalculateHit(bs.Rand(), attacker, defender, defending, moveIdx)
+	} else {
+		hit = core.Hit{Damage: core.CalculateDamage(bs.Rand(), attacker, defender, defending, moveIdx)}
+	}
+	moveType := attacker.Moves[moveIdx].Type
+	hit.Damage = int(float64(hit.Damage) * bs.Field.multiplier(moveType) * itemMultiplier(attacker.HeldItem, moveType) * abilityMultiplier(attacker, defender, moveType))
+	return hit
+}
+
+// hitEvents reports a miss or a critical hit by side's attack
+func hitEvents(side string, hit core.Hit) []Event {
+	switch {
+	case hit.Missed:
+		return []Event{{Type: EventMissed, Side: side}}
+	case hit.Critical:
+		return []Event{{Type: EventCriticalHit, Side: side}}
+	}
+	return nil
+}
+
+// damageEvents reports side's attack on a Pokemon that isn't defending
+func damageEvents(side string, hit core.Hit) []Event {
+	events := hitEvents(side, hit)
+	if !hit.Missed {
+		events = append(events, Event{Type: EventDamageDealt, Side: side, Damage: hit.Damage})
+	}
+	return events
+}
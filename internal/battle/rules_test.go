@@ -0,0 +1,209 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+
+	"pokemon-cli/game/core"
+	"pokemon-cli/internal/pokemon"
+)
+
+// attackingAI always attacks with its first move
+var attackingAI = StrategyFunc(func(view *BattleState) Decision {
+	return Decision{Move: "attack", MoveIdx: 0}
+})
+
+func TestSpeedOrderKnockoutStopsSlowerAttack(t *testing.T) {
+	tests := []struct {
+		speedOrder bool
+		aiAttacks  bool
+	}{
+		{speedOrder: true, aiAttacks: false},
+		{speedOrder: false, aiAttacks: true},
+	}
+
+	for _, tt := range tests {
+		bs := newSeededBattle(t, 5)
+		if err := bs.SetRules(Rules{SpeedOrder: tt.speedOrder}); err != nil {
+			t.Fatalf("SetRules failed: %v", err)
+		}
+		if err := bs.SetStrategy("attacker", attackingAI); err != nil {
+			t.Fatalf("SetStrategy failed: %v", err)
+		}
+		// Pikachu is faster than Squirtle and knocks it out with any hit
+		bs.AIDeck[0].HP = 1
+		hp := bs.PlayerDeck[0].HP
+
+		events, err := ProcessMove(bs, "attack", intPtr(0))
+		if err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+
+		movedFirst, aiAttacked := "", false
+		for _, e := range events {
+			switch {
+			case e.Type == EventMovedFirst:
+				movedFirst = e.Side
+			case e.Type == EventDamageDealt && e.Side == "ai":
+				aiAttacked = true
+			}
+		}
+		if tt.speedOrder && movedFirst != "player" {
+			t.Errorf("speed order: %q moved first, want player", movedFirst)
+		}
+		if aiAttacked != tt.aiAttacks || (bs.PlayerDeck[0].HP < hp) != tt.aiAttacks {
+			t.Errorf("speed order %v: AI attacked = %v, want %v", tt.speedOrder, aiAttacked, tt.aiAttacks)
+		}
+		if !bs.BattleOver || bs.Winner != "player" {
+			t.Errorf("speed order %v: battle over %v with winner %q, want a player win", tt.speedOrder, bs.BattleOver, bs.Winner)
+		}
+	}
+}
+
+func TestSpeedTiesAreBrokenBySeed(t *testing.T) {
+	p := pokemon.Card{Name: "ditto", Speed: 48}
+	a := pokemon.Card{Name: "ditto", Speed: 48}
+	seen := map[string]bool{}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		first := firstMover(newSeededBattle(t, seed), &p, &a)
+		if again := firstMover(newSeededBattle(t, seed), &p, &a); again != first {
+			t.Errorf("seed %d: %s moved first, then %s", seed, first, again)
+		}
+		seen[first] = true
+	}
+	if !seen["player"] || !seen["ai"] {
+		t.Errorf("ties always went the same way: %v", seen)
+	}
+}
+
+func TestSpeedOrderBattleReplays(t *testing.T) {
+	bs := newBenchmarkBattle(t, 12)
+	if err := bs.SetRules(Rules{SpeedOrder: true}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		move, idx := "pass", (*int)(nil)
+		if actions := affordableActions(bs.GetActivePlayerCard()); len(actions) > 0 {
+			move = actions[0].Move
+			if move == "attack" {
+				idx = intPtr(actions[0].MoveIdx)
+			}
+		}
+		if _, err := ProcessMove(bs, move, idx); err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+	}
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	last := frames[len(frames)-1].State
+	if !last.Rules.SpeedOrder {
+		t.Error("replay lost the speed order rule")
+	}
+	if !reflect.DeepEqual(last.PlayerDeck, bs.PlayerDeck) || !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+		t.Error("replay does not reproduce the battle")
+	}
+}
+
+func TestSetRulesRejectsStartedBattle(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	if _, err := ProcessMove(bs, "pass", nil); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if err := bs.SetRules(Rules{SpeedOrder: true}); err == nil {
+		t.Error("expected an error changing the rules mid-battle")
+	}
+}
+
+// fixedRand always rolls the same values
+type fixedRand struct {
+	f float64
+	n int
+}
+
+func (r fixedRand) Float64() float64 { return r.f }
+func (r fixedRand) Intn(n int) int   { return min(r.n, n-1) }
+
+func TestCalculateHit(t *testing.T) {
+	attacker := pokemon.Card{Name: "pikachu", Attack: 55, Types: []string{"electric"}, Moves: []pokemon.Move{
+		{Name: "thunderbolt", Power: 90, Type: "electric", Accuracy: 90},
+		{Name: "swift", Power: 60, Type: "normal"},
+	}}
+	defender := pokemon.Card{Name: "rattata", Types: []string{"normal"}}
+	base := core.CalculateDamage(fixedRand{f: 0.5}, &attacker, &defender, false, 0)
+	swiftBase := core.CalculateDamage(fixedRand{f: 0.5}, &attacker, &defender, false, 1)
+
+	tests := []struct {
+		name    string
+		r       fixedRand
+		moveIdx int
+		want    core.Hit
+	}{
+		{"same type bonus", fixedRand{f: 0.5, n: 1}, 0, core.Hit{Damage: int(float64(base) * 1.5)}},
+		{"critical hit", fixedRand{f: 0.5, n: 0}, 0, core.Hit{Damage: int(float64(base) * 1.5 * 1.5), Critical: true}},
+		{"miss", fixedRand{f: 0.5, n: 95}, 0, core.Hit{Missed: true}},
+		{"never misses", fixedRand{f: 0.5, n: 99}, 1, core.Hit{Damage: swiftBase}},
+	}
+
+	for _, tt := range tests {
+		if got := core.CalculateHit(tt.r, &attacker, &defender, false, tt.moveIdx); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestModernDamageRules(t *testing.T) {
+	for _, damage := range []string{DamageClassic, DamageModern} {
+		bs := newSeededBattle(t, 21)
+		if err := bs.SetRules(Rules{Damage: damage}); err != nil {
+			t.Fatalf("SetRules failed: %v", err)
+		}
+		bs.PlayerDeck[0].Moves[1].Accuracy = 1
+
+		events, err := ProcessMove(bs, "attack", intPtr(1))
+		if err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+
+		missed := len(eventsOfType(events, EventMissed)) == 1
+		if missed != (damage == DamageModern) {
+			t.Errorf("%s damage: missed = %v", damage, missed)
+		}
+		for _, e := range eventsOfType(events, EventDamageDealt) {
+			if missed && e.Side == "player" {
+				t.Errorf("%s damage: a missed attack dealt damage", damage)
+			}
+		}
+	}
+}
+
+func TestModernDamageBattleReplays(t *testing.T) {
+	bs := newBenchmarkBattle(t, 5)
+	if err := bs.SetRules(Rules{Damage: DamageModern, SpeedOrder: true}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		if _, err := PlayTurn(bs, DifficultyStrategy(DifficultyNormal)); err != nil {
+			t.Fatalf("PlayTurn failed: %v", err)
+		}
+	}
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	if last := frames[len(frames)-1].State; !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+		t.Error("replay does not reproduce the battle")
+	}
+}
+
+func TestSetRulesRejectsUnknownDamageModel(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	if err := bs.SetRules(Rules{Damage: "realistic"}); err == nil {
+		t.Error("expected an error for an unknown damage model")
+	}
+}
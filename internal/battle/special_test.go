@@ -0,0 +1,125 @@
+package battle
+
+import (
+	"testing"
+
+	"pokemon-cli/game/core"
+	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/progression"
+)
+
+func TestMoveCategory(t *testing.T) {
+	tests := []struct {
+		move pokemon.Move
+		want bool
+	}{
+		{pokemon.Move{Name: "flamethrower", Type: "fire"}, true},
+		{pokemon.Move{Name: "fire-punch", Type: "fire"}, false},
+		{pokemon.Move{Name: "tackle", Type: "normal"}, false},
+		{pokemon.Move{Name: "shadow-ball", Type: "ghost"}, true},
+		{pokemon.Move{Name: "made-up-beam", Type: "water"}, false},
+	}
+	for _, tt := range tests {
+		// Moves saved without a category get the one of the move in the data
+		move := pokemon.WithMoveEffects([]pokemon.Move{tt.move})[0]
+		if got := move.IsSpecial(); got != tt.want {
+			t.Errorf("%s: special = %v, want %v", tt.move.Name, got, tt.want)
+		}
+	}
+}
+
+func TestSpecialMovesUseSpecialAttack(t *testing.T) {
+	special := pokemon.Move{Name: "swift", Power: 60, Type: "normal", Category: pokemon.CategorySpecial}
+	physical := special
+	physical.Category = pokemon.CategoryPhysical
+	defender := pokemon.Card{Name: "rattata", Types: []string{"normal"}}
+
+	// Rolls shift with the attack stat the move uses, so a special attacker
+	// hits with a special move as hard as a physical attacker with a physical one
+	strong, weak := 150, 10
+	specialAttacker := pokemon.Card{Name: "alakazam", Attack: weak, SpAttack: strong, Moves: []pokemon.Move{special, physical}}
+	physicalAttacker := pokemon.Card{Name: "machamp", Attack: strong, SpAttack: weak, Moves: []pokemon.Move{special, physical}}
+
+	r := fixedRand{f: 0.95}
+	if s, p := core.CalculateDamage(r, &specialAttacker, &defender, false, 0), core.CalculateDamage(r, &physicalAttacker, &defender, false, 1); s != p {
+		t.Errorf("special move from special attack dealt %d, physical move from attack %d", s, p)
+	}
+	if s, p := core.CalculateDamage(r, &specialAttacker, &defender, false, 0), core.CalculateDamage(r, &specialAttacker, &defender, false, 1); s <= p {
+		t.Errorf("a special attacker's special move dealt %d, no more than its physical move's %d", s, p)
+	}
+}
+
+func TestDefendBlocksWithSpecialDefense(t *testing.T) {
+	tests := []struct {
+		defense, spDefense int
+		blocked            bool
+	}{
+		{defense: 1, spDefense: 999, blocked: true},
+		{defense: 999, spDefense: 1, blocked: false},
+	}
+
+	for _, tt := range tests {
+		bs := newSeededBattle(t, 4)
+		if err := bs.SetStrategy("attacker", attackingAI); err != nil {
+			t.Fatalf("SetStrategy failed: %v", err)
+		}
+		bs.AIDeck[0].Moves[0] = pokemon.Move{Name: "water-gun", Power: 40, Type: "water", Category: pokemon.CategorySpecial, StaminaCost: 10}
+		bs.PlayerDeck[0].Defense, bs.PlayerDeck[0].SpDefense = tt.defense, tt.spDefense
+
+		events, err := ProcessMove(bs, "defend", nil)
+		if err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+		if blocked := len(eventsOfType(events, EventBlocked)) == 1; blocked != tt.blocked {
+			t.Errorf("defense %d, special defense %d: blocked = %v, want %v", tt.defense, tt.spDefense, blocked, tt.blocked)
+		}
+	}
+}
+
+func TestBattleCardSpecialStatsFallBack(t *testing.T) {
+	bc := ConvertToBattleCard(pokemon.Card{Name: "pikachu", Attack: 55, Defense: 40}, 1)
+	if bc.SpAttack != 55 || bc.SpDefense != 40 {
+		t.Errorf("card without special stats got %d/%d, want its attack and defense 55/40", bc.SpAttack, bc.SpDefense)
+	}
+
+	bc = ConvertToBattleCard(pokemon.Card{Name: "pikachu", Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50}, 1)
+	if bc.SpAttack != 50 || bc.SpDefense != 50 {
+		t.Errorf("special stats %d/%d, want 50/50", bc.SpAttack, bc.SpDefense)
+	}
+}
+
+func TestLevelUpReportsSpecialStats(t *testing.T) {
+	base := progression.Stats{HP: 100, Attack: 50, Defense: 40, SpAttack: 90, SpDefense: 80, Speed: 60}
+	gain, _ := GainXP(1, "alakazam", 1, 0, 1000, base)
+	if !gain.LeveledUp {
+		t.Fatal("expected a level up")
+	}
+	if gain.OldSpAttack != 90 || gain.OldSpDefense != 80 {
+		t.Errorf("old special stats %d/%d, want the base 90/80", gain.OldSpAttack, gain.OldSpDefense)
+	}
+	if gain.NewSpAttack <= gain.NewAttack || gain.NewSpDefense <= gain.NewDefense {
+		t.Errorf("new special stats %d/%d didn't grow from their own base", gain.NewSpAttack, gain.NewSpDefense)
+	}
+}
+
+func TestSpecialMovesUseSpecialStages(t *testing.T) {
+	special := pokemon.Move{Name: "swift", Power: 60, Type: "normal", Category: pokemon.CategorySpecial}
+	physical := special
+	physical.Category = pokemon.CategoryPhysical
+
+	card := pokemon.Card{Name: "alakazam", Attack: 50, Defense: 40, SpAttack: 100, SpDefense: 80}
+	applyStages(&card, StatStages{Defense: 2, SpAttack: 2, SpDefense: -1})
+
+	if got := card.AttackStat(special); got != 200 {
+		t.Errorf("special move attacks with %d, want special attack +2's 200", got)
+	}
+	if got := card.AttackStat(physical); got != 50 {
+		t.Errorf("physical move attacks with %d, want the unstaged attack's 50", got)
+	}
+	if got := card.DefenseStat(special); got != 53 {
+		t.Errorf("special move is defended with %d, want special defense -1's 53", got)
+	}
+	if got := card.DefenseStat(physical); got != 80 {
+		t.Errorf("physical move is defended with %d, want defense +2's 80", got)
+	}
+}
@@ -0,0 +1,105 @@
+package battle
+
+import (
+	"pokemon-cli/internal/pokemon"
+	"slices"
+)
+
+// How status conditions play out
+const (
+	burnDamageDivisor   = 16 // A burned Pokemon loses 1/16 of its max HP every turn, and its attack is halved
+	poisonDamageDivisor = 8  // A poisoned Pokemon loses 1/8 of its max HP every turn
+	paralysisSkipChance = 4  // A paralyzed Pokemon can't move one turn in 4, and its speed is halved
+	freezeThawChance    = 5  // A frozen Pokemon can't move until it thaws out, one turn in 5
+	maxSleepTurns       = 3  // A Pokemon sleeps through 1 to 3 turns
+)
+
+// statusImmunities lists the types that can't get each status condition
+var statusImmunities = map[string][]string{
+	pokemon.StatusBurn:      {"fire"},
+	pokemon.StatusPoison:    {"poison", "steel"},
+	pokemon.StatusParalysis: {"electric"},
+	pokemon.StatusFreeze:    {"ice"},
+}
+
+// checkStatus returns the move side's Pokemon actually makes this turn: "pass"
+// when its status stops it moving. Sleeping Pokemon count down to waking up
+// and frozen ones may thaw out, whatever move was chosen.
+func checkStatus(bs *BattleState, side string, card *BattleCard, move string) (string, []Event) {
+	stopped := Event{Type: EventCantMove, Side: side, Pokemon: card.Name, Status: card.Status}
+	cured := Event{Type: EventStatusCured, Side: side, Pokemon: card.Name, Status: card.Status}
+
+	switch card.Status {
+	case pokemon.StatusSleep:
+		if card.StatusTurns > 0 {
+			card.StatusTurns--
+			return "pass", []Event{stopped}
+		}
+		card.Status = ""
+		return move, []Event{cured}
+	case pokemon.StatusFreeze:
+		if bs.Rand().Intn(freezeThawChance) != 0 {
+			return "pass", []Event{stopped}
+		}
+		card.Status = ""
+		return move, []Event{cured}
+	case pokemon.StatusParalysis:
+		if move != "pass" && bs.Rand().Intn(paralysisSkipChance) == 0 {
+			return "pass", []Event{stopped}
+		}
+	}
+	return move, nil
+}
+
+// applyStatusStats lowers the stats a status condition weakens on the copy of
+// the card a turn is resolved with
+func applyStatusStats(card *pokemon.Card, status string) {
+	switch status {
+	case pokemon.StatusBurn:
+		card.Attack /= 2
+	case pokemon.StatusParalysis:
+		card.Speed /= 2
+	}
+}
+
+// inflictStatus gives side's Pokemon the secondary effect of the move that
+// just hit it, if the effect's chance comes up. A Pokemon only has one status
+// at a time, and knocked out Pokemon and immune types get none.
+func inflictStatus(bs *BattleState, side string, target *BattleCard, hp int, move pokemon.Move) []Event {
+	if !pokemon.IsStatusCondition(move.Effect) || move.EffectChance <= 0 || target.Status != "" || hp <= 0 {
+		return nil
+	}
+	for _, t := range target.Types {
+		if slices.Contains(statusImmunities[move.Effect], t) {
+			return nil
+		}
+	}
+	if bs.Rand().Intn(100) >= move.EffectChance {
+		return nil
+	}
+
+	target.Status = move.Effect
+	if move.Effect == pokemon.StatusSleep {
+		target.StatusTurns = 1 + bs.Rand().Intn(maxSleepTurns)
+	}
+	return []Event{{Type: EventStatusApplied, Side: side, Pokemon: target.Name, Status: move.Effect}}
+}
+
+// statusDamage takes the end of turn damage of burn and poison from the copy
+// of side's card the turn is resolved with
+func statusDamage(side string, status string, card *pokemon.Card) []Event {
+	divisor := 0
+	switch status {
+	case pokemon.StatusBurn:
+		divisor = burnDamageDivisor
+	case pokemon.StatusPoison:
+		divisor = poisonDamageDivisor
+	}
+	if divisor == 0 || card.HP <= 0 {
+		return nil
+	}
+
+	damage := max(card.HPMax/divisor, 1)
+	card.HP -= damage
+	return []Event{{Type: EventStatusDamage, Side: side, Pokemon: card.Name, Status: status, Damage: damage}}
+}
@@ -0,0 +1,104 @@
+package battle
+
+import (
+	"testing"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+// eventsOfType returns the events of type t
+func eventsOfType(events []Event, t EventType) []Event {
+	var found []Event
+	for _, e := range events {
+		if e.Type == t {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func TestMoveInflictsStatus(t *testing.T) {
+	bs := newSeededBattle(t, 4)
+	bs.PlayerDeck[0].Moves[1].Effect = pokemon.StatusBurn
+	bs.PlayerDeck[0].Moves[1].EffectChance = 100
+	if err := bs.SetStrategy("passer", StrategyFunc(func(*BattleState) Decision { return Decision{Move: "pass"} })); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	hp := bs.AIDeck[0].HP
+
+	// Quick attack hits without knocking Squirtle out
+	events, err := ProcessMove(bs, "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	if bs.AIDeck[0].Status != pokemon.StatusBurn {
+		t.Fatalf("AI status is %q, want burn", bs.AIDeck[0].Status)
+	}
+	if len(eventsOfType(events, EventStatusApplied)) != 1 {
+		t.Error("no status_applied event")
+	}
+	hit := eventsOfType(events, EventDamageDealt)[0].Damage
+	burn := eventsOfType(events, EventStatusDamage)
+	if len(burn) != 1 || burn[0].Damage != bs.AIDeck[0].HPMax/burnDamageDivisor {
+		t.Fatalf("burn damage events %+v, want one for 1/16 of max HP", burn)
+	}
+	if lost := hp - bs.AIDeck[0].HP; lost != hit+burn[0].Damage {
+		t.Errorf("AI lost %d HP, want %d from the hit and %d from the burn", lost, hit, burn[0].Damage)
+	}
+}
+
+func TestImmuneTypesDontGetStatus(t *testing.T) {
+	bs := newSeededBattle(t, 4)
+	target := &BattleCard{Name: "charmander", HP: 50, Types: []string{"fire"}}
+	move := pokemon.Move{Name: "scald", Effect: pokemon.StatusBurn, EffectChance: 100}
+
+	if events := inflictStatus(bs, "ai", target, target.HP, move); len(events) != 0 || target.Status != "" {
+		t.Errorf("fire type got status %q", target.Status)
+	}
+
+	target.Types = []string{"water"}
+	target.Status = pokemon.StatusPoison
+	if inflictStatus(bs, "ai", target, target.HP, move); target.Status != pokemon.StatusPoison {
+		t.Errorf("status replaced by %q, want the first status to stay", target.Status)
+	}
+}
+
+func TestSleepingPokemonCantMove(t *testing.T) {
+	bs := newSeededBattle(t, 8)
+	if err := bs.SetStrategy("attacker", attackingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	bs.AIDeck[0].Status = pokemon.StatusSleep
+	bs.AIDeck[0].StatusTurns = 2
+
+	for turn := 1; turn <= 3; turn++ {
+		events, err := ProcessMove(bs, "defend", nil)
+		if err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+
+		asleep := len(eventsOfType(events, EventCantMove)) == 1
+		attacked := len(eventsOfType(events, EventDamageDealt))+len(eventsOfType(events, EventBlocked)) > 0
+		if turn <= 2 && (!asleep || attacked) {
+			t.Errorf("turn %d: asleep %v, attacked %v; want it to sleep through the turn", turn, asleep, attacked)
+		}
+		if turn == 3 && (len(eventsOfType(events, EventStatusCured)) != 1 || !attacked) {
+			t.Errorf("turn 3: want the AI to wake up and attack, got %+v", events)
+		}
+	}
+	if bs.AIDeck[0].Status != "" {
+		t.Errorf("AI still has status %q after waking up", bs.AIDeck[0].Status)
+	}
+}
+
+func TestStatusInBattleResponse(t *testing.T) {
+	bs := newBenchmarkBattle(t, 1)
+	bs.AIDeck[bs.AIActiveIdx].Status = pokemon.StatusParalysis
+
+	response := BuildBattleResponse(bs, nil, true)
+	active := response["ai_deck"].([]map[string]any)[bs.AIActiveIdx]
+	if active["status"] != pokemon.StatusParalysis {
+		t.Errorf("active AI status %v, want paralysis", active["status"])
+	}
+}
@@ -0,0 +1,188 @@
+package battle
+
+import "fmt"
+
+// Decision is what a Strategy wants the AI to do next
+type Decision struct {
+	Move      string `json:"move"`                 // attack, defend, pass, sacrifice, switch or surrender
+	MoveIdx   int    `json:"move_idx,omitempty"`   // Move to attack with
+	SwitchIdx int    `json:"switch_idx,omitempty"` // Deck index to switch to
+}
+
+// Strategy plays the AI side of a battle. Decide is given a copy of the
+// battle, so changing it has no effect on the real one. The player's move for
+// the turn is already in PendingPlayerMove.
+//
+// Like the built-in AI, a strategy is asked again after sacrificing or
+// switching (which it may do once a turn, see AISwitched), until it commits to
+// an attack, defend, pass or surrender. Decisions the battle can't carry out
+// are played as a pass.
+type Strategy interface {
+	Decide(view *BattleState) Decision
+}
+
+// StrategyFunc adapts a function to the Strategy interface
+type StrategyFunc func(view *BattleState) Decision
+
+// Decide calls f(view)
+func (f StrategyFunc) Decide(view *BattleState) Decision {
+	return f(view)
+}
+
+// DifficultyStrategy returns the strategy the built-in AI plays with at difficulty
+func DifficultyStrategy(difficulty string) Strategy {
+	return StrategyFunc(func(view *BattleState) Decision {
+		view.AIDifficulty = difficulty
+		return decisionFor(chooseAIMove(view))
+	})
+}
+
+// SetStrategy hands the AI side of a battle that hasn't started playing yet to
+// s. name identifies the opponent in the battle's replay. The strategy is not
+// saved with the battle; it has to be set again on a battle that is reloaded.
+func (bs *BattleState) SetStrategy(name string, s Strategy) error {
+	if bs.IsPvP() {
+		return fmt.Errorf("PvP battles have no AI to replace")
+	}
+	if len(bs.Turns) > 0 {
+		return fmt.Errorf("the opponent can't change once the battle has started")
+	}
+
+	bs.strategy = s
+	bs.AIBot = name
+	if bs.Replay != nil {
+		bs.Replay.Bot = name
+	}
+	return nil
+}
+
+// PlayTurn lets s play the player's side for one turn, as it would the AI's:
+// it sees the battle mirrored so its side is the "ai" one, and is asked again
+// after sacrificing or switching until it commits to a move. Decisions the
+// battle can't carry out are played as a pass. It returns the events of every
+// action played.
+func PlayTurn(bs *BattleState, s Strategy) ([]Event, error) {
+	var events []Event
+	var switched *turnSnapshot
+	for {
+		view := bs.ForSide("ai")
+		view.aiSwitchedIn = switched
+		d := legalDecision(view, s.Decide(view))
+
+		switch d.Move {
+		case "switch":
+			if switched == nil {
+				if switchEvents, err := SwitchPokemon(bs, d.SwitchIdx); err == nil {
+					events = append(events, switchEvents...)
+					switched = &turnSnapshot{}
+					continue
+				}
+			}
+			d = Decision{Move: "pass"}
+		case "sacrifice":
+			sacrificeEvents, err := ProcessMove(bs, d.Move, nil)
+			if err != nil {
+				return events, err
+			}
+			events = append(events, sacrificeEvents...)
+			continue
+		}
+
+		var moveIdx *int
+		if d.Move == "attack" {
+			moveIdx = &d.MoveIdx
+		}
+		moveEvents, err := ProcessMove(bs, d.Move, moveIdx)
+		return append(events, moveEvents...), err
+	}
+}
+
+// AISwitched reports whether the AI has already switched Pokemon during the
+// turn being decided. It can't switch again until the next turn.
+func (bs *BattleState) AISwitched() bool {
+	return bs.aiSwitchedIn != nil
+}
+
+// decide asks the battle's strategy for the AI's next decision and remembers
+// it for the replay
+func (bs *BattleState) decide() Decision {
+	d := legalDecision(bs, bs.strategy.Decide(bs.Clone()))
+	bs.decisions = append(bs.decisions, d)
+	return d
+}
+
+// takeDecisions returns the decisions made by the battle's strategy since it
+// was last called
+func (bs *BattleState) takeDecisions() []Decision {
+	decisions := bs.decisions
+	bs.decisions = nil
+	return decisions
+}
+
+// legalDecision turns a decision the battle can't carry out into a pass.
+// Impossible switches are already handled by processAIMove.
+func legalDecision(bs *BattleState, d Decision) Decision {
+	pass := Decision{Move: "pass"}
+	card := bs.GetActiveAICard()
+	if card == nil {
+		return pass
+	}
+
+	switch d.Move {
+	case "attack":
+		if validateMove(card, d.Move, &d.MoveIdx) != nil {
+			return pass
+		}
+		return Decision{Move: d.Move, MoveIdx: d.MoveIdx}
+	case "defend":
+		if validateMove(card, d.Move, nil) != nil {
+			return pass
+		}
+		return Decision{Move: d.Move}
+	case "sacrifice":
+		if !canSacrifice(bs, "ai") {
+			return pass
+		}
+		return Decision{Move: d.Move}
+	case "switch":
+		return Decision{Move: d.Move, SwitchIdx: d.SwitchIdx}
+	case "surrender":
+		return Decision{Move: d.Move}
+	}
+	return pass
+}
+
+// decisionFor converts the (move, index) pair the built-in AIs answer with
+func decisionFor(move string, idx int) Decision {
+	switch move {
+	case "attack":
+		return Decision{Move: move, MoveIdx: idx}
+	case "switch":
+		return Decision{Move: move, SwitchIdx: idx}
+	}
+	return Decision{Move: move}
+}
+
+// index is the decision's move or deck index, as processAIMove takes it
+func (d Decision) index() int {
+	if d.Move == "switch" {
+		return d.SwitchIdx
+	}
+	return d.MoveIdx
+}
+
+// replayStrategy plays back the decisions a strategy made during one action
+// of a recorded battle
+type replayStrategy struct {
+	decisions []Decision
+}
+
+func (r *replayStrategy) Decide(*BattleState) Decision {
+	if len(r.decisions) == 0 {
+		// The replay asks for more than was recorded; its log won't match
+		return Decision{Move: "pass"}
+	}
+	d := r.decisions[0]
+	r.decisions = r.decisions[1:]
+	return d
+}
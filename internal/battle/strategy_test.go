@@ -0,0 +1,122 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrategyPlaysTheAI(t *testing.T) {
+	bs := newBenchmarkBattle(t, 9)
+	calls := 0
+	strategy := StrategyFunc(func(view *BattleState) Decision {
+		calls++
+		// Changes to the view must not reach the battle
+		view.AIDeck[view.AIActiveIdx].HP = 0
+		return Decision{Move: "defend"}
+	})
+	if err := bs.SetStrategy("defender", strategy); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+
+	hp := bs.AIDeck[0].HP
+	if _, err := ProcessMove(bs, "pass", nil); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("strategy asked %d times, want 1", calls)
+	}
+	if bs.Turns[0].AIMove != "defend" {
+		t.Errorf("AI played %q, want defend", bs.Turns[0].AIMove)
+	}
+	if bs.AIDeck[0].HP != hp {
+		t.Error("strategy changed the battle through its view")
+	}
+	if got := bs.Replay.Actions[0].AI; !reflect.DeepEqual(got, []Decision{{Move: "defend"}}) {
+		t.Errorf("recorded decisions %+v, want a single defend", got)
+	}
+}
+
+func TestIllegalDecisionsArePlayedAsPass(t *testing.T) {
+	bs := newBenchmarkBattle(t, 2)
+	bs.AIDeck[0].Stamina = 0
+	tests := []Decision{
+		{Move: "attack", MoveIdx: 0},
+		{Move: "attack", MoveIdx: 7},
+		{Move: "defend"},
+		{Move: "dance"},
+	}
+
+	for _, d := range tests {
+		if got := legalDecision(bs, d); got.Move != "pass" {
+			t.Errorf("legalDecision(%+v) = %+v, want pass", d, got)
+		}
+	}
+}
+
+func TestStrategyBattleReplays(t *testing.T) {
+	bs := newBenchmarkBattle(t, 31)
+	if err := bs.SetStrategy("expert", DifficultyStrategy(DifficultyExpert)); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		move, idx := "pass", (*int)(nil)
+		if actions := affordableActions(bs.GetActivePlayerCard()); len(actions) > 0 {
+			move = actions[0].Move
+			if move == "attack" {
+				idx = intPtr(actions[0].MoveIdx)
+			}
+		}
+		if _, err := ProcessMove(bs, move, idx); err != nil {
+			t.Fatalf("ProcessMove failed: %v", err)
+		}
+	}
+	if !bs.BattleOver {
+		t.Fatal("battle did not finish")
+	}
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	if last := frames[len(frames)-1].State; !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+		t.Error("replay does not reproduce the battle")
+	}
+}
+
+func TestSetStrategyRejectsStartedBattle(t *testing.T) {
+	bs := newSeededBattle(t, 3)
+	if _, err := ProcessMove(bs, "pass", nil); err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+	if err := bs.SetStrategy("late", DifficultyStrategy(DifficultyEasy)); err == nil {
+		t.Error("expected an error replacing the AI mid-battle")
+	}
+}
+
+func TestPlayTurnPlaysThePlayerSide(t *testing.T) {
+	bs := newBenchmarkBattle(t, 6)
+	switches := 0
+	strategy := StrategyFunc(func(view *BattleState) Decision {
+		// The player's Pokemon are on the view's AI side
+		if view.AIDeck[view.AIActiveIdx].Name != bs.GetActivePlayerCard().Name {
+			t.Error("view is not mirrored")
+		}
+		if !view.AISwitched() {
+			switches++
+			return Decision{Move: "switch", SwitchIdx: 2}
+		}
+		return Decision{Move: "attack", MoveIdx: 1}
+	})
+
+	if _, err := PlayTurn(bs, strategy); err != nil {
+		t.Fatalf("PlayTurn failed: %v", err)
+	}
+	if switches != 1 || bs.PlayerActiveIdx != 2 {
+		t.Errorf("switched %d times to %d, want once to 2", switches, bs.PlayerActiveIdx)
+	}
+	if last := bs.Turns[len(bs.Turns)-1]; last.PlayerMove != "attack" {
+		t.Errorf("player played %q, want attack", last.PlayerMove)
+	}
+}
@@ -0,0 +1,112 @@
+package battle
+
+import "pokemon-cli/internal/pokemon"
+
+// maxStatStage is how far a stat can be raised or lowered by support moves
+const maxStatStage = 6
+
+// StatStages are how many stages support moves have raised (positive) or
+// lowered (negative) a Pokemon's stats. They reset when it leaves the field.
+type StatStages struct {
+	Attack    int `json:"attack,omitempty"`
+	Defense   int `json:"defense,omitempty"`
+	SpAttack  int `json:"sp_attack,omitempty"`
+	SpDefense int `json:"sp_defense,omitempty"`
+	Speed     int `json:"speed,omitempty"`
+}
+
+// stage returns where the stage of stat is kept, or nil for stats without one
+func (s *StatStages) stage(stat string) *int {
+	switch stat {
+	case pokemon.StatAttack:
+		return &s.Attack
+	case pokemon.StatDefense:
+		return &s.Defense
+	case pokemon.StatSpAttack:
+		return &s.SpAttack
+	case pokemon.StatSpDefense:
+		return &s.SpDefense
+	case pokemon.StatSpeed:
+		return &s.Speed
+	}
+	return nil
+}
+
+// of returns the stage of stat
+func (s StatStages) of(stat string) int {
+	if stage := s.stage(stat); stage != nil {
+		return *stage
+	}
+	return 0
+}
+
+// change raises stat by stages within the limits and returns by how many
+// stages it actually changed
+func (s *StatStages) change(stat string, stages int) int {
+	stage := s.stage(stat)
+	if stage == nil {
+		return 0
+	}
+
+	old := *stage
+	*stage = min(max(old+stages, -maxStatStage), maxStatStage)
+	return *stage - old
+}
+
+// stagedStat returns stat raised or lowered by stage: each stage up adds half
+// the stat, each stage down divides it by one more half (+1 is x1.5, -1 x2/3)
+func stagedStat(stat, stage int) int {
+	if stage >= 0 {
+		return stat * (2 + stage) / 2
+	}
+	return stat * 2 / (2 - stage)
+}
+
+// applyStages raises and lowers the stats of the copy of the card a turn is
+// resolved with by its stat stages
+func applyStages(card *pokemon.Card, stages StatStages) {
+	card.Attack = stagedStat(card.Attack, stages.Attack)
+	card.Defense = stagedStat(card.Defense, stages.Defense)
+	card.SpAttack = stagedStat(card.SpAttack, stages.SpAttack)
+	card.SpDefense = stagedStat(card.SpDefense, stages.SpDefense)
+	card.Speed = stagedStat(card.Speed, stages.Speed)
+}
+
+// useSupportMove makes side's Pokemon use its move if it is a support move,
+// reporting whether it was one. The user neither attacks nor defends, so the
+// move it returns for the rest of the turn is "pass". Healing and stamina go to
+// user, the copy of the card the turn is resolved with.
+func useSupportMove(bs *BattleState, side, move string, moveIdx int, user *pokemon.Card) (string, bool, []Event) {
+	if move != "attack" || !user.Moves[moveIdx].IsSupport() {
+		return move, false, nil
+	}
+
+	m := user.Moves[moveIdx]
+	user.Stamina -= m.StaminaCost
+	if bs.Rules.Damage == DamageModern && m.Accuracy > 0 && bs.Rand().Intn(100) >= m.Accuracy {
+		return "pass", true, []Event{{Type: EventMissed, Side: side}}
+	}
+
+	events := []Event{}
+	if m.Healing > 0 && user.HP < user.HPMax {
+		healed := min(max(user.HPMax*m.Healing/100, 1), user.HPMax-user.HP)
+		user.HP += healed
+		events = append(events, Event{Type: EventHealed, Side: side, Pokemon: user.Name, HPGained: healed})
+	}
+	if staminaMax := bs.activeCard(side).StaminaMax; m.StaminaGain > 0 && user.Stamina < staminaMax {
+		restored := min(max(staminaMax*m.StaminaGain/100, 1), staminaMax-user.Stamina)
+		user.Stamina += restored
+		events = append(events, Event{Type: EventStaminaRestored, Side: side, Pokemon: user.Name, StaminaGained: restored})
+	}
+	for _, change := range m.StatChanges {
+		target := side
+		if change.Target == pokemon.TargetOpponent {
+			target = opponent(side)
+		}
+		card := bs.activeCard(target)
+		changed := card.Stages.change(change.Stat, change.Stages)
+		events = append(events, Event{Type: EventStatChanged, Side: target, Pokemon: card.Name, Stat: change.Stat, Stages: changed})
+	}
+	events = append(events, useFieldMove(bs, side, m)...)
+	return "pass", true, events
+}
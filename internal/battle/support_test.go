@@ -0,0 +1,153 @@
+package battle
+
+import (
+	"testing"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+// passingAI always passes
+var passingAI = StrategyFunc(func(*BattleState) Decision { return Decision{Move: "pass"} })
+
+var (
+	swordsDance = pokemon.Move{Name: "swords-dance", Type: "normal", StaminaCost: pokemon.SupportMoveStaminaCost,
+		StatChanges: []pokemon.StatChange{{Stat: pokemon.StatAttack, Stages: 2, Target: pokemon.TargetUser}}}
+	recoverMove = pokemon.Move{Name: "recover", Type: "normal", StaminaCost: pokemon.SupportMoveStaminaCost, Healing: 50}
+)
+
+func TestStagedStat(t *testing.T) {
+	tests := []struct{ stage, want int }{
+		{0, 90}, {1, 135}, {2, 180}, {6, 360}, {-1, 60}, {-2, 45}, {-6, 22},
+	}
+	for _, tt := range tests {
+		if got := stagedStat(90, tt.stage); got != tt.want {
+			t.Errorf("stagedStat(90, %d) = %d, want %d", tt.stage, got, tt.want)
+		}
+	}
+
+	stages := StatStages{Attack: 5}
+	if changed := stages.change(pokemon.StatAttack, 2); changed != 1 || stages.Attack != maxStatStage {
+		t.Errorf("raising attack from +5 by 2 changed it by %d to %d, want 1 to +6", changed, stages.Attack)
+	}
+}
+
+func TestSupportMoveRaisesStages(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	bs.PlayerDeck[0].Moves[1] = swordsDance
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	stamina := bs.PlayerDeck[0].Stamina
+
+	events, err := ProcessMove(bs, "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	if bs.PlayerDeck[0].Stages.Attack != 2 {
+		t.Errorf("attack stage %d, want +2", bs.PlayerDeck[0].Stages.Attack)
+	}
+	if changed := eventsOfType(events, EventStatChanged); len(changed) != 1 || changed[0].Stages != 2 || changed[0].Side != "player" {
+		t.Errorf("stat_changed events %+v, want one raising the player's attack by 2", changed)
+	}
+	if spent := stamina - bs.PlayerDeck[0].Stamina; spent != swordsDance.StaminaCost {
+		t.Errorf("spent %d stamina, want %d", spent, swordsDance.StaminaCost)
+	}
+	if len(eventsOfType(events, EventDamageDealt)) != 0 || bs.ConsecutivePasses != 0 {
+		t.Error("a support move against a pass should deal no damage and not count towards a stalemate")
+	}
+}
+
+func TestHealingMove(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	bs.PlayerDeck[0].Moves[1] = recoverMove
+	if err := bs.SetStrategy("passer", passingAI); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	card := &bs.PlayerDeck[0]
+	card.HP = card.HPMax - 5
+
+	events, err := ProcessMove(bs, "attack", intPtr(1))
+	if err != nil {
+		t.Fatalf("ProcessMove failed: %v", err)
+	}
+
+	// Healing stops at max HP
+	if card.HP != card.HPMax {
+		t.Errorf("HP %d after healing, want %d", card.HP, card.HPMax)
+	}
+	if healed := eventsOfType(events, EventHealed); len(healed) != 1 || healed[0].HPGained != 5 {
+		t.Errorf("healed events %+v, want one for 5 HP", healed)
+	}
+}
+
+func TestStagesResetOnSwitchOut(t *testing.T) {
+	bs := newBenchmarkBattle(t, 3)
+	out := bs.PlayerActiveIdx
+	bs.PlayerDeck[out].Stages = StatStages{Attack: 2, Speed: -1}
+
+	if _, err := SwitchPokemon(bs, (out+1)%len(bs.PlayerDeck)); err != nil {
+		t.Fatalf("SwitchPokemon failed: %v", err)
+	}
+	if bs.PlayerDeck[out].Stages != (StatStages{}) {
+		t.Errorf("stages %+v kept after switching out", bs.PlayerDeck[out].Stages)
+	}
+}
+
+func TestAIValuesSupportMoves(t *testing.T) {
+	bs := newSeededBattle(t, 2)
+	ai := &bs.AIDeck[0]
+	ai.Moves = append(ai.Moves, recoverMove, swordsDance)
+	healIdx, setupIdx := len(ai.Moves)-2, len(ai.Moves)-1
+
+	// Low on HP it heals
+	ai.HP = ai.HPMax / 5
+	if move, idx := GetEnhancedAIMove(bs, "defend"); move != "attack" || idx != healIdx {
+		t.Errorf("at 20%% HP the AI chose %s %d, want to heal", move, idx)
+	}
+
+	// Healthy and not under attack it sets up, until its attack is raised enough
+	ai.HP = ai.HPMax
+	if move, idx := GetEnhancedAIMove(bs, "defend"); move != "attack" || idx != setupIdx {
+		t.Errorf("at full HP the AI chose %s %d, want to raise its attack", move, idx)
+	}
+	ai.Stages.Attack = setupStages
+	if _, idx := GetEnhancedAIMove(bs, "defend"); idx == setupIdx {
+		t.Error("the AI kept raising an attack it had already raised")
+	}
+}
+
+func TestSpeciesLearnSupportMoves(t *testing.T) {
+	db, err := pokemon.LoadPokemonDatabase()
+	if err != nil {
+		t.Fatalf("LoadPokemonDatabase failed: %v", err)
+	}
+
+	// Offline cards draw their moves from the species data, so every kind of
+	// support move must be in some species' moves
+	var heals, stamina, stages int
+	staged := map[string]bool{}
+	for _, entry := range db.Pokemon {
+		for _, move := range entry.Moves {
+			switch {
+			case move.Healing > 0:
+				heals++
+			case move.StaminaGain > 0:
+				stamina++
+			case len(move.StatChanges) > 0:
+				stages++
+			}
+			for _, change := range move.StatChanges {
+				staged[change.Stat] = true
+			}
+		}
+	}
+	if heals == 0 || stamina == 0 || stages == 0 {
+		t.Errorf("species learn %d healing, %d stamina and %d stat support moves, want some of each", heals, stamina, stages)
+	}
+	for _, stat := range []string{pokemon.StatAttack, pokemon.StatDefense, pokemon.StatSpAttack, pokemon.StatSpDefense, pokemon.StatSpeed} {
+		if !staged[stat] {
+			t.Errorf("no species' support move changes %s", stat)
+		}
+	}
+}
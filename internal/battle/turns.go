@@ -0,0 +1,126 @@
+package battle
+
+// TurnRecord captures one action of a battle: what each side chose, the damage
+// exchanged and how both active Pokemon's HP and stamina changed
+type TurnRecord struct {
+	Turn               int    `json:"turn"`
+	Round              int    `json:"round"`
+	PlayerPokemon      string `json:"player_pokemon"`
+	AIPokemon          string `json:"ai_pokemon"`
+	PlayerMove         string `json:"player_move"` // attack, defend, pass, sacrifice, surrender or switch; "" when only the PvP opponent acted
+	PlayerMoveName     string `json:"player_move_name,omitempty"`
+	AIMove             string `json:"ai_move,omitempty"`
+	AIMoveName         string `json:"ai_move_name,omitempty"`
+	DamageDealt        int    `json:"damage_dealt"`
+	DamageTaken        int    `json:"damage_taken"`
+	PlayerHPDelta      int    `json:"player_hp_delta"`
+	PlayerStaminaDelta int    `json:"player_stamina_delta"`
+	AIHPDelta          int    `json:"ai_hp_delta"`
+	AIStaminaDelta     int    `json:"ai_stamina_delta"`
+	PlayerKnockedOut   bool   `json:"player_knocked_out"`
+	AIKnockedOut       bool   `json:"ai_knocked_out"`
+}
+
+// turnSnapshot remembers the active Pokemon before an action so deltas can be computed after it
+type turnSnapshot struct {
+	playerIdx     int
+	aiIdx         int
+	playerHP      int
+	playerStamina int
+	aiHP          int
+	aiStamina     int
+}
+
+// beginTurnRecord starts a record for an action about to be applied to bs
+func beginTurnRecord(bs *BattleState, move string) (*TurnRecord, turnSnapshot) {
+	rec := &TurnRecord{
+		Turn:       bs.TurnNumber,
+		Round:      bs.RoundNumber,
+		PlayerMove: move,
+	}
+	snap := turnSnapshot{playerIdx: bs.PlayerActiveIdx, aiIdx: bs.AIActiveIdx}
+
+	if card := bs.GetActivePlayerCard(); card != nil {
+		rec.PlayerPokemon = card.Name
+		snap.playerHP = card.HP
+		snap.playerStamina = card.Stamina
+	}
+	if card := bs.GetActiveAICard(); card != nil {
+		rec.AIPokemon = card.Name
+		snap.aiHP = card.HP
+		snap.aiStamina = card.Stamina
+	}
+
+	return rec, snap
+}
+
+// finishTurnRecord fills in what the action's events say happened and the deltas of the
+// Pokemon that were active when it began, then appends the record to the battle's timeline
+func finishTurnRecord(bs *BattleState, rec *TurnRecord, snap turnSnapshot, events []Event) {
+	// An AI that switched before moving is tracked through the Pokemon it brought in
+	if in := bs.aiSwitchedIn; in != nil {
+		snap.aiIdx, snap.aiHP, snap.aiStamina = in.aiIdx, in.aiHP, in.aiStamina
+		rec.AIPokemon = bs.AIDeck[in.aiIdx].Name
+		bs.aiSwitchedIn = nil
+	}
+
+	for _, e := range events {
+		switch e.Type {
+		case EventMoveChosen:
+			if e.Side == "player" {
+				rec.PlayerMove = e.Move
+				rec.PlayerMoveName = e.MoveName
+			} else {
+				rec.AIMove = e.Move
+				rec.AIMoveName = e.MoveName
+			}
+		case EventDamageDealt:
+			if e.Side == "player" {
+				rec.DamageDealt += e.Damage
+			} else {
+				rec.DamageTaken += e.Damage
+			}
+		case EventSacrificed:
+			if e.Side == "player" {
+				rec.PlayerMove = "sacrifice"
+			} else {
+				rec.AIMove = "sacrifice"
+			}
+		case EventSurrendered:
+			if e.Side == "player" {
+				rec.PlayerMove = "surrender"
+			} else {
+				rec.AIMove = "surrender"
+			}
+		case EventBattleEnded:
+			if e.Reason == EndSurrender && e.Winner == "player" {
+				rec.AIMove = "surrender"
+			} else if e.Reason == EndSurrender && e.Winner == "ai" {
+				rec.PlayerMove = "surrender"
+			}
+		case EventSwitched:
+			if e.Side == "player" {
+				rec.PlayerPokemon = e.Pokemon
+			} else if rec.PlayerMove == "" && rec.AIMove == "" {
+				// A PvP opponent switching is its own action
+				rec.AIMove = "switch"
+				rec.AIPokemon = e.Pokemon
+			}
+		}
+	}
+
+	if snap.playerIdx >= 0 && snap.playerIdx < len(bs.PlayerDeck) {
+		card := bs.PlayerDeck[snap.playerIdx]
+		rec.PlayerHPDelta = card.HP - snap.playerHP
+		rec.PlayerStaminaDelta = card.Stamina - snap.playerStamina
+		rec.PlayerKnockedOut = snap.playerHP > 0 && card.HP <= 0
+	}
+	if snap.aiIdx >= 0 && snap.aiIdx < len(bs.AIDeck) {
+		card := bs.AIDeck[snap.aiIdx]
+		rec.AIHPDelta = card.HP - snap.aiHP
+		rec.AIStaminaDelta = card.Stamina - snap.aiStamina
+		rec.AIKnockedOut = snap.aiHP > 0 && card.HP <= 0
+	}
+
+	bs.Turns = append(bs.Turns, *rec)
+}
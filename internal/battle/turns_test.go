@@ -0,0 +1,44 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTurnRecordsGolden pins the timeline of the scripted battle used by TestProcessMoveGolden
+func TestTurnRecordsGolden(t *testing.T) {
+	bs := newSeededBattle(t, 42)
+	playScript(t, bs, testScript)
+
+	want := []TurnRecord{
+		{Turn: 1, Round: 1, PlayerPokemon: "pikachu", AIPokemon: "squirtle", PlayerMove: "attack", PlayerMoveName: "thunderbolt", AIMove: "defend",
+			PlayerStaminaDelta: -30, AIStaminaDelta: -33},
+		{Turn: 2, Round: 1, PlayerPokemon: "pikachu", AIPokemon: "squirtle", PlayerMove: "defend", AIMove: "attack", AIMoveName: "bite",
+			PlayerStaminaDelta: -26, AIStaminaDelta: -20},
+		{Turn: 3, Round: 1, PlayerPokemon: "pikachu", AIPokemon: "squirtle", PlayerMove: "attack", PlayerMoveName: "quick-attack", AIMove: "defend",
+			PlayerStaminaDelta: -13, AIStaminaDelta: -33},
+		// The AI sacrifices HP for stamina before attacking, so its HP drops without damage being dealt
+		{Turn: 4, Round: 1, PlayerPokemon: "pikachu", AIPokemon: "squirtle", PlayerMove: "pass", AIMove: "attack", AIMoveName: "bite",
+			DamageTaken: 18, PlayerHPDelta: -18, AIHPDelta: -10, AIStaminaDelta: 23},
+		// Damage beyond the remaining HP is reported in full but the HP delta stops at zero
+		{Turn: 5, Round: 1, PlayerPokemon: "pikachu", AIPokemon: "squirtle", PlayerMove: "attack", PlayerMoveName: "quick-attack", AIMove: "attack", AIMoveName: "bite",
+			DamageDealt: 8, DamageTaken: 48, PlayerHPDelta: -34, PlayerStaminaDelta: -13, AIHPDelta: -8, AIStaminaDelta: -20, PlayerKnockedOut: true},
+	}
+
+	if !reflect.DeepEqual(bs.Turns, want) {
+		t.Errorf("turn records mismatch\ngot:  %+v\nwant: %+v", bs.Turns, want)
+	}
+}
+
+func TestCloneCopiesTurnRecords(t *testing.T) {
+	bs := newSeededBattle(t, 7)
+	if _, err := ProcessMove(bs, "defend", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	clone := bs.Clone()
+	clone.Turns[0].DamageTaken = -1
+	if bs.Turns[0].DamageTaken == -1 {
+		t.Error("clone shares its turn records with the original")
+	}
+}
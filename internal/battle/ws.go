@@ -0,0 +1,436 @@
+package battle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"pokemon-cli/pkg/logger"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	socketWriteTimeout = 5 * time.Second // Bounds how long a slow client can hold up an update
+
+	// sessionChannel is the Postgres channel battle session changes are
+	// notified on (see migration 000022)
+	sessionChannel = "battle_sessions"
+	// listenRetryInterval is how long the session listener waits to reconnect
+	listenRetryInterval = 5 * time.Second
+)
+
+// socketCommand is a message sent by a client over the battle socket
+type socketCommand struct {
+	Type    string `json:"type"` // "move" or "switch"
+	Move    string `json:"move,omitempty"`
+	MoveIdx *int   `json:"move_idx,omitempty"`
+	NewIdx  int    `json:"new_idx,omitempty"`
+}
+
+// socketMessage is a message pushed to a client over the battle socket
+type socketMessage struct {
+	Type     string                     `json:"type"` // "state", "update", "rewards", "error" or "closed"
+	BattleID string                     `json:"battle_id"`
+	State    map[string]any             `json:"state,omitempty"`   // Full battle state, sent when the socket opens
+	Diff     map[string]json.RawMessage `json:"diff,omitempty"`    // State fields that changed since the last message
+	Events   []Event                    `json:"events,omitempty"`  // What happened to cause the update
+	Log      []string                   `json:"log,omitempty"`     // Events rendered as battle log lines
+	Rewards  map[string]any             `json:"rewards,omitempty"` // The client's rewards once its action ended the battle
+	Error    string                     `json:"error,omitempty"`
+}
+
+// socketConn is the part of a WebSocket connection the hub writes to
+type socketConn interface {
+	WriteJSON(v any) error
+}
+
+// timedConn gives up on writes to a client that stops reading
+type timedConn struct {
+	*websocket.Conn
+}
+
+func (c timedConn) WriteJSON(v any) error {
+	if err := c.SetWriteDeadline(time.Now().Add(socketWriteTimeout)); err != nil {
+		return err
+	}
+	return c.Conn.WriteJSON(v)
+}
+
+// watcher is one client watching a battle from one side
+type watcher struct {
+	battleID string
+	side     string
+	conn     socketConn
+	mu       sync.Mutex                 // Serializes writes to conn
+	last     map[string]json.RawMessage // State fields as last sent, to diff updates against
+}
+
+// Hub pushes battle updates to the WebSocket clients watching each battle.
+// This process's handlers publish their changes with the events behind them;
+// changes made anywhere else reach it through ListenSessionChanges.
+type Hub struct {
+	mu       sync.RWMutex
+	watchers map[string]map[*watcher]struct{} // Battle ID -> watchers
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{watchers: make(map[string]map[*watcher]struct{})}
+}
+
+// watch starts pushing updates of a battle to conn
+func (hub *Hub) watch(battleID, side string, conn socketConn) *watcher {
+	w := &watcher{battleID: battleID, side: side, conn: conn}
+	hub.add(w)
+	return w
+}
+
+// watchFrom starts pushing updates of a battle to conn, first sending it the
+// battle's state as load reads it. Updates published while the state loads
+// wait for it to be sent and are diffed against it, rather than being
+// overwritten by an older state.
+func (hub *Hub) watchFrom(battleID, side string, conn socketConn, load func() (*BattleState, error)) (*watcher, error) {
+	w := &watcher{battleID: battleID, side: side, conn: conn}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	hub.add(w)
+
+	bs, err := load()
+	if err != nil {
+		hub.unwatch(w)
+		return nil, err
+	}
+	w.writeState(bs)
+	return w, nil
+}
+
+// add starts pushing updates of w's battle to w
+func (hub *Hub) add(w *watcher) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if hub.watchers[w.battleID] == nil {
+		hub.watchers[w.battleID] = make(map[*watcher]struct{})
+	}
+	hub.watchers[w.battleID][w] = struct{}{}
+}
+
+// unwatch stops pushing updates to w
+func (hub *Hub) unwatch(w *watcher) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	delete(hub.watchers[w.battleID], w)
+	if len(hub.watchers[w.battleID]) == 0 {
+		delete(hub.watchers, w.battleID)
+	}
+}
+
+// watchersOf returns the watchers of a battle
+func (hub *Hub) watchersOf(battleID string) []*watcher {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	watchers := make([]*watcher, 0, len(hub.watchers[battleID]))
+	for w := range hub.watchers[battleID] {
+		watchers = append(watchers, w)
+	}
+	return watchers
+}
+
+// Publish pushes the changes to a battle, and the events that caused them, to
+// everyone watching it. Each client gets the battle as seen from its side.
+func (hub *Hub) Publish(bs *BattleState, events []Event) {
+	for _, w := range hub.watchersOf(bs.ID) {
+		w.update(bs, events)
+	}
+}
+
+// refresh pushes the changes to a battle its watchers haven't been sent yet,
+// such as ones made by matchmaking or another server. It sends nothing to
+// watchers already up to date.
+func (hub *Hub) refresh(bs *BattleState) {
+	for _, w := range hub.watchersOf(bs.ID) {
+		w.refresh(bs)
+	}
+}
+
+// Close tells everyone watching a battle that it no longer exists, and stops
+// pushing it to them
+func (hub *Hub) Close(battleID string) {
+	for _, w := range hub.watchersOf(battleID) {
+		w.send(socketMessage{Type: "closed", BattleID: battleID})
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	delete(hub.watchers, battleID)
+}
+
+// send writes msg to the client. A failed write means the client is gone;
+// its read loop notices and unwatches it.
+func (w *watcher) send(msg socketMessage) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = w.conn.WriteJSON(msg)
+}
+
+// sendState sends the full battle state, which later updates are diffed against
+func (w *watcher) sendState(bs *BattleState) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeState(bs)
+}
+
+// writeState sends the full battle state; the caller holds w.mu
+func (w *watcher) writeState(bs *BattleState) {
+	state := buildSideResponse(bs, w.side, []Event{})
+	_, w.last = stateDiff(nil, state)
+	delete(state, "events")
+	delete(state, "log")
+	_ = w.conn.WriteJSON(socketMessage{Type: "state", BattleID: bs.ID, State: state})
+}
+
+// update sends the state fields that changed since the last message, along with events
+func (w *watcher) update(bs *BattleState, events []Event) {
+	state := buildSideResponse(bs, w.side, events)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var diff map[string]json.RawMessage
+	diff, w.last = stateDiff(w.last, state)
+	_ = w.conn.WriteJSON(socketMessage{
+		Type:     "update",
+		BattleID: bs.ID,
+		Diff:     diff,
+		Events:   state["events"].([]Event),
+		Log:      state["log"].([]string),
+	})
+}
+
+// refresh sends the state fields that changed since the last message, if any
+func (w *watcher) refresh(bs *BattleState) {
+	state := buildSideResponse(bs, w.side, []Event{})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	diff, encoded := stateDiff(w.last, state)
+	if len(diff) == 0 {
+		return
+	}
+	w.last = encoded
+	_ = w.conn.WriteJSON(socketMessage{Type: "update", BattleID: bs.ID, Diff: diff})
+}
+
+// stateDiff encodes the fields of a battle response and returns the ones that
+// differ from prev, along with all encoded fields. Fields missing from state
+// are reported as null. The events and log are not part of the state.
+func stateDiff(prev map[string]json.RawMessage, state map[string]any) (diff, encoded map[string]json.RawMessage) {
+	diff = make(map[string]json.RawMessage)
+	encoded = make(map[string]json.RawMessage, len(state))
+
+	for field, value := range state {
+		if field == "events" || field == "log" {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		encoded[field] = data
+		if old, ok := prev[field]; !ok || string(old) != string(data) {
+			diff[field] = data
+		}
+	}
+
+	for field := range prev {
+		if _, ok := encoded[field]; !ok {
+			diff[field] = json.RawMessage("null")
+		}
+	}
+
+	return diff, encoded
+}
+
+// sessionChange is the payload of a battle session change notification
+type sessionChange struct {
+	SessionID string `json:"session_id"`
+	Deleted   bool   `json:"deleted"`
+}
+
+// ListenSessionChanges pushes every change to a battle session to the
+// WebSocket clients watching the battle, until ctx is canceled. Changes come
+// from Postgres notifications, so it sees the ones made by matchmaking and by
+// other servers too. It reconnects whenever its connection is lost.
+func (h *Handler) ListenSessionChanges(ctx context.Context, db *pgxpool.Pool, log *logger.Logger) {
+	for {
+		err := h.listenSessionChanges(ctx, db)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn("Battle session listener disconnected", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// listenSessionChanges listens on a connection of its own until it fails
+func (h *Handler) listenSessionChanges(ctx context.Context, db *pgxpool.Pool) error {
+	conn, err := pgx.ConnectConfig(ctx, db.Config().ConnConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+sessionChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var change sessionChange
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			continue
+		}
+		h.pushSessionChange(ctx, change)
+	}
+}
+
+// pushSessionChange sends the watchers of a changed battle what changed
+func (h *Handler) pushSessionChange(ctx context.Context, change sessionChange) {
+	if len(h.hub.watchersOf(change.SessionID)) == 0 {
+		return
+	}
+	if change.Deleted {
+		h.hub.Close(change.SessionID)
+		return
+	}
+
+	battleState, err := h.repo.GetBattleSession(ctx, change.SessionID)
+	if err != nil {
+		return
+	}
+	h.hub.refresh(battleState)
+}
+
+// SocketToken lets browsers, which can't set headers on WebSocket requests,
+// pass their JWT as the token query parameter. It must run before the auth middleware.
+func SocketToken(c *fiber.Ctx) error {
+	if token := c.Query("token"); token != "" && c.Get("Authorization") == "" {
+		c.Request().Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.Next()
+}
+
+// UpgradeBattleSocket checks GET /api/battle/ws?battle_id=... before it is upgraded to a WebSocket
+func (h *Handler) UpgradeBattleSocket(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{"error": "WebSocket upgrade required"})
+	}
+
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	battleID := strings.TrimSpace(c.Query("battle_id"))
+	if battleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "battle_id required"})
+	}
+
+	battleState, err := h.GetBattleState(c, battleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Battle not found"})
+	}
+
+	// Verify user plays in this battle
+	side := battleState.SideOf(userID)
+	if side == "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not your battle"})
+	}
+
+	c.Locals("battle_id", battleID)
+	c.Locals("side", side)
+	return c.Next()
+}
+
+// BattleSocket serves a battle's WebSocket. It sends the full state when it
+// opens, then pushes an update whenever the battle changes, and accepts the
+// same moves and switches as POST /api/battle/move and /api/battle/switch.
+func (h *Handler) BattleSocket(conn *websocket.Conn) {
+	userID, _ := conn.Locals("user_id").(int)
+	battleID, _ := conn.Locals("battle_id").(string)
+	side, _ := conn.Locals("side").(string)
+	db, _ := conn.Locals("db").(*pgxpool.Pool)
+	ctx := context.Background()
+
+	w, err := h.hub.watchFrom(battleID, side, timedConn{conn}, func() (*BattleState, error) {
+		return h.repo.GetBattleSession(ctx, battleID)
+	})
+	if err != nil {
+		_ = timedConn{conn}.WriteJSON(socketMessage{Type: "error", BattleID: battleID, Error: "Battle not found"})
+		return
+	}
+	defer h.hub.unwatch(w)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var cmd socketCommand
+		if err := json.Unmarshal(data, &cmd); err != nil {
+			w.send(socketMessage{Type: "error", BattleID: battleID, Error: "Invalid command"})
+			continue
+		}
+
+		action, err := socketAction(cmd)
+		if err != nil {
+			w.send(socketMessage{Type: "error", BattleID: battleID, Error: err.Error()})
+			continue
+		}
+
+		// Watchers, this one included, are sent the update by applyAction
+		result, err := h.applyAction(ctx, db, userID, battleID, action)
+		if err != nil {
+			w.send(socketMessage{Type: "error", BattleID: battleID, Error: err.Error()})
+			continue
+		}
+		if len(result.Rewards) > 0 {
+			w.send(socketMessage{Type: "rewards", BattleID: battleID, Rewards: result.Rewards})
+		}
+	}
+}
+
+// socketAction turns a socket command into the battle action it asks for
+func socketAction(cmd socketCommand) (battleAction, error) {
+	switch cmd.Type {
+	case "move":
+		if !validMoves[cmd.Move] {
+			return nil, fmt.Errorf("invalid move. Must be one of: attack, defend, pass, sacrifice, surrender, item")
+		}
+		if cmd.Move == "attack" && (cmd.MoveIdx == nil || *cmd.MoveIdx < 0) {
+			return nil, fmt.Errorf("move index is required for attack moves")
+		}
+		if cmd.Move == "item" && (cmd.MoveIdx == nil || *cmd.MoveIdx < 0) {
+			return nil, fmt.Errorf("move index is required for items")
+		}
+		return moveAction(cmd.Move, cmd.MoveIdx), nil
+	case "switch":
+		return switchAction(cmd.NewIdx), nil
+	}
+	return nil, fmt.Errorf("unknown command type %q", cmd.Type)
+}
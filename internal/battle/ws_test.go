@@ -0,0 +1,156 @@
+package battle
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// recordingConn keeps every message written to it
+type recordingConn struct {
+	messages []socketMessage
+}
+
+func (c *recordingConn) WriteJSON(v any) error {
+	c.messages = append(c.messages, v.(socketMessage))
+	return nil
+}
+
+func TestStateDiffOnlyReportsChangedFields(t *testing.T) {
+	_, prev := stateDiff(nil, map[string]any{"turn_number": 1, "whose_turn": "player", "log": []string{"x"}})
+
+	diff, _ := stateDiff(prev, map[string]any{"turn_number": 2, "whose_turn": "player", "seed": 7})
+
+	want := map[string]string{"turn_number": "2", "seed": "7"}
+	if len(diff) != len(want) {
+		t.Fatalf("diff = %s, want %v", diff, want)
+	}
+	for field, value := range want {
+		if string(diff[field]) != value {
+			t.Errorf("diff[%s] = %s, want %s", field, diff[field], value)
+		}
+	}
+
+	// A field that disappears is reported as null
+	diff, _ = stateDiff(map[string]json.RawMessage{"seed": json.RawMessage("7")}, map[string]any{})
+	if string(diff["seed"]) != "null" {
+		t.Errorf("removed field diff = %s, want null", diff["seed"])
+	}
+}
+
+func TestHubPushesEachSideItsOwnView(t *testing.T) {
+	bs := newPvPBattle(t, 3)
+	hub := NewHub()
+
+	challenger, opponent := &recordingConn{}, &recordingConn{}
+	hub.watch(bs.ID, "player", challenger).sendState(bs)
+	w := hub.watch(bs.ID, "ai", opponent)
+	w.sendState(bs)
+
+	if _, err := ProcessPvPMove(bs, "player", "defend", nil); err != nil {
+		t.Fatal(err)
+	}
+	hub.Publish(bs, []Event{})
+	events, err := ProcessPvPMove(bs, "ai", "defend", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub.Publish(bs, events)
+
+	if len(challenger.messages) != 3 || len(opponent.messages) != 3 {
+		t.Fatalf("got %d and %d messages, want 3 each", len(challenger.messages), len(opponent.messages))
+	}
+
+	first := opponent.messages[1]
+	if first.Type != "update" || string(first.Diff["whose_turn"]) != `"player"` || len(first.Diff) != 1 {
+		t.Errorf("opponent should only be told it's their turn, got %s", first.Diff)
+	}
+
+	last := opponent.messages[2]
+	if last.Events[0].Side != "ai" || last.Events[1].Side != "player" {
+		t.Errorf("opponent's events are not mirrored: %+v", last.Events)
+	}
+	if string(last.Diff["turn_number"]) != "2" {
+		t.Errorf("turn_number diff = %s, want 2", last.Diff["turn_number"])
+	}
+
+	hub.unwatch(w)
+	hub.Close(bs.ID)
+	if len(opponent.messages) != 3 || challenger.messages[3].Type != "closed" {
+		t.Error("close should only reach clients still watching")
+	}
+}
+
+func TestHubRefreshOnlySendsUnseenChanges(t *testing.T) {
+	bs := newPvPBattle(t, 3)
+	hub := NewHub()
+
+	conn := &recordingConn{}
+	hub.watch(bs.ID, "player", conn).sendState(bs)
+
+	// A change this process already published isn't sent again
+	events, err := ProcessPvPMove(bs, "player", "defend", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub.Publish(bs, events)
+	hub.refresh(bs)
+	if len(conn.messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(conn.messages))
+	}
+
+	// A change made elsewhere arrives as a diff without events
+	if _, err := ProcessPvPMove(bs, "ai", "defend", nil); err != nil {
+		t.Fatal(err)
+	}
+	hub.refresh(bs)
+	if len(conn.messages) != 3 {
+		t.Fatalf("got %d messages, want 3", len(conn.messages))
+	}
+	if last := conn.messages[2]; last.Type != "update" || len(last.Events) != 0 || string(last.Diff["turn_number"]) != "2" {
+		t.Errorf("refresh sent %+v, want the turn_number diff", last)
+	}
+
+	// Once closed, a battle is no longer pushed
+	hub.Close(bs.ID)
+	hub.Close(bs.ID)
+	hub.refresh(bs)
+	if len(conn.messages) != 4 || conn.messages[3].Type != "closed" {
+		t.Errorf("got %d messages after closing, want a single closed message", len(conn.messages)-3)
+	}
+}
+
+func TestHubDiffsUpdatesAgainstTheStateBeingSent(t *testing.T) {
+	bs := newPvPBattle(t, 3)
+	hub := NewHub()
+	read := bs.Clone()
+
+	// The battle moves on while a new client's state is being read
+	if _, err := ProcessPvPMove(bs, "player", "defend", nil); err != nil {
+		t.Fatal(err)
+	}
+	events, err := ProcessPvPMove(bs, "ai", "defend", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := &recordingConn{}
+	published := make(chan struct{})
+	_, err = hub.watchFrom(bs.ID, "player", conn, func() (*BattleState, error) {
+		go func() {
+			hub.Publish(bs, events)
+			close(published)
+		}()
+		return read, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-published
+
+	if len(conn.messages) != 2 || conn.messages[0].Type != "state" {
+		t.Fatalf("got %+v, want the state then an update", conn.messages)
+	}
+	if diff := conn.messages[1].Diff; string(diff["turn_number"]) != "2" {
+		t.Errorf("update diff = %s, want the turn the client missed", diff)
+	}
+}
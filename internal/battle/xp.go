@@ -3,78 +3,123 @@ package battle
 import (
 	"context"
 	"fmt"
+	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/progression"
+	"pokemon-cli/internal/ruleset"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // PokemonXPGain represents XP gained by a Pokemon after battle
 type PokemonXPGain struct {
-	CardID      int    `json:"card_id"`
-	PokemonName string `json:"pokemon_name"`
-	XPGained    int    `json:"xp_gained"`
-	OldLevel    int    `json:"old_level"`
-	NewLevel    int    `json:"new_level"`
-	LeveledUp   bool   `json:"leveled_up"`
-	OldHP       int    `json:"old_hp,omitempty"`
-	NewHP       int    `json:"new_hp,omitempty"`
-	OldAttack   int    `json:"old_attack,omitempty"`
-	NewAttack   int    `json:"new_attack,omitempty"`
-	OldDefense  int    `json:"old_defense,omitempty"`
-	NewDefense  int    `json:"new_defense,omitempty"`
-	OldSpeed    int    `json:"old_speed,omitempty"`
-	NewSpeed    int    `json:"new_speed,omitempty"`
+	CardID       int    `json:"card_id"`
+	PokemonName  string `json:"pokemon_name"`
+	XPGained     int    `json:"xp_gained"`
+	OldLevel     int    `json:"old_level"`
+	NewLevel     int    `json:"new_level"`
+	LeveledUp    bool   `json:"leveled_up"`
+	OldHP        int    `json:"old_hp,omitempty"`
+	NewHP        int    `json:"new_hp,omitempty"`
+	OldAttack    int    `json:"old_attack,omitempty"`
+	NewAttack    int    `json:"new_attack,omitempty"`
+	OldDefense   int    `json:"old_defense,omitempty"`
+	NewDefense   int    `json:"new_defense,omitempty"`
+	OldSpAttack  int    `json:"old_sp_attack,omitempty"`
+	NewSpAttack  int    `json:"new_sp_attack,omitempty"`
+	OldSpDefense int    `json:"old_sp_defense,omitempty"`
+	NewSpDefense int    `json:"new_sp_defense,omitempty"`
+	OldSpeed     int    `json:"old_speed,omitempty"`
+	NewSpeed     int    `json:"new_speed,omitempty"`
 }
 
-// CalculateXPForBattle calculates XP for all Pokemon that participated in battle
+// XPEarned returns the XP each Pokemon of the player's deck earned in the
+// finished battle, by deck position. Pokemon that took part (took damage or
+// were knocked out) earn the ruleset's XP for the mode and result, scaled by
+// AI difficulty; the others earn none.
+func XPEarned(bs *BattleState) []int {
+	// A draw earns more than a loss, less than a win
+	baseXP := ScaleReward(ruleset.Current().Progression.BattleXP(bs.Mode, bs.Winner), bs.Difficulty())
+
+	earned := make([]int, len(bs.PlayerDeck))
+	for i, card := range bs.PlayerDeck {
+		if card.HP < card.HPMax || card.IsKnockedOut {
+			earned[i] = baseXP
+			if bs.Mode == "1v1" {
+				break // Only one Pokemon participates in 1v1
+			}
+		}
+	}
+	return earned
+}
+
+// CalculateXPForBattle calculates XP for all Pokemon that participated in battle, by card ID
 func CalculateXPForBattle(bs *BattleState) map[int]int {
 	xpMap := make(map[int]int)
-
-	// Determine base XP based on mode and result
-	var baseXP int
-	
-	switch bs.Winner {
-	case "player":
-		// Win XP
-		if bs.Mode == "1v1" {
-			baseXP = 20
-		} else {
-			baseXP = 15
+	for i, xp := range XPEarned(bs) {
+		if xp > 0 {
+			xpMap[bs.PlayerDeck[i].CardID] = xp
 		}
-	case "draw":
-		// Draw XP (more than loss, less than win)
-		if bs.Mode == "1v1" {
-			baseXP = 10
-		} else {
-			baseXP = 8
-		}
-	default:
-		// Loss XP
-		baseXP = 5
 	}
+	return xpMap
+}
 
-	switch bs.Mode {
-	case "1v1":
-		// In 1v1, find the Pokemon that actually participated
-		// This is the Pokemon that took damage or was knocked out
-		for _, card := range bs.PlayerDeck {
-			if card.HP < card.HPMax || card.IsKnockedOut {
-				xpMap[card.CardID] = baseXP
-				break // Only one Pokemon participates in 1v1
-			}
-		}
-	case "5v5":
-		// In 5v5, all Pokemon that participated get XP
-		// A Pokemon participated if it took damage or was knocked out
-		for _, card := range bs.PlayerDeck {
-			// Check if Pokemon participated (HP changed from max or was knocked out)
-			if card.HP < card.HPMax || card.IsKnockedOut {
-				xpMap[card.CardID] = baseXP
-			}
-		}
+// GainXP works out what gaining xpGained XP does to a card at level with xp
+// and base stats, returning the gain to report and the card's new XP towards
+// its next level. The CLI levels its cards up through it too.
+func GainXP(cardID int, name string, level, xp, xpGained int, base progression.Stats) (PokemonXPGain, int) {
+	rules := ruleset.Current().Progression
+	newLevel, newXP := rules.AddXP(level, xp, xpGained)
+
+	gain := PokemonXPGain{
+		CardID:      cardID,
+		PokemonName: name,
+		XPGained:    xpGained,
+		OldLevel:    level,
+		NewLevel:    newLevel,
+		LeveledUp:   newLevel > level,
 	}
 
-	return xpMap
+	// Include stat changes if leveled up
+	if gain.LeveledUp {
+		oldStats := rules.Stats(level, base)
+		newStats := rules.Stats(newLevel, base)
+		gain.OldHP = oldStats.HP
+		gain.NewHP = newStats.HP
+		gain.OldAttack = oldStats.Attack
+		gain.NewAttack = newStats.Attack
+		gain.OldDefense = oldStats.Defense
+		gain.NewDefense = newStats.Defense
+		gain.OldSpAttack = oldStats.SpAttack
+		gain.NewSpAttack = newStats.SpAttack
+		gain.OldSpDefense = oldStats.SpDefense
+		gain.NewSpDefense = newStats.SpDefense
+		gain.OldSpeed = oldStats.Speed
+		gain.NewSpeed = newStats.Speed
+	}
+	return gain, newXP
+}
+
+// LevelUpCards gives each card the XP xpMap awards it by card ID, levelling
+// it up, and returns what each gained. Cards xpMap awards nothing are left
+// alone. The server levels its cards up through it wherever it awards XP.
+func LevelUpCards(cards []*database.PlayerCard, xpMap map[int]int) []PokemonXPGain {
+	gains := []PokemonXPGain{}
+	for _, card := range cards {
+		xpGained, ok := xpMap[card.ID]
+		if !ok {
+			continue
+		}
+
+		gain, newXP := GainXP(card.ID, card.PokemonName, card.Level, card.XP, xpGained, progression.Stats{
+			HP: card.BaseHP, Attack: card.BaseAttack, Defense: card.BaseDefense,
+			SpAttack: card.BaseSpAttack, SpDefense: card.BaseSpDefense, Speed: card.BaseSpeed,
+		})
+		card.Level, card.XP = gain.NewLevel, newXP
+		gains = append(gains, gain)
+	}
+	return gains
 }
 
 // ApplyXPAndLevelUps applies XP to Pokemon and handles level-ups
@@ -83,8 +128,6 @@ func ApplyXPAndLevelUps(ctx context.Context, db *pgxpool.Pool, userID int, xpMap
 		return []PokemonXPGain{}, nil
 	}
 
-	results := []PokemonXPGain{}
-
 	// Start a transaction
 	tx, err := db.Begin(ctx)
 	if err != nil {
@@ -92,121 +135,59 @@ func ApplyXPAndLevelUps(ctx context.Context, db *pgxpool.Pool, userID int, xpMap
 	}
 	defer tx.Rollback(ctx)
 
-	// Process each Pokemon
-	for cardID, xpGained := range xpMap {
+	results, err := levelUpCardsInTx(ctx, tx, userID, xpMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// levelUpCardsInTx gives userID's cards the XP xpMap awards them by card ID
+// within tx, and returns what each gained
+func levelUpCardsInTx(ctx context.Context, tx pgx.Tx, userID int, xpMap map[int]int) ([]PokemonXPGain, error) {
+	cards := make([]*database.PlayerCard, 0, len(xpMap))
+	for cardID := range xpMap {
 		// Get current card data
 		query := `
-			SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed
+			SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
+				base_sp_attack, base_sp_defense
 			FROM player_cards
 			WHERE id = $1 AND user_id = $2
 		`
 
-		var id, uid, level, xp, baseHP, baseAttack, baseDefense, baseSpeed int
-		var pokemonName string
-
+		card := &database.PlayerCard{}
 		err := tx.QueryRow(ctx, query, cardID, userID).Scan(
-			&id, &uid, &pokemonName, &level, &xp,
-			&baseHP, &baseAttack, &baseDefense, &baseSpeed,
+			&card.ID, &card.UserID, &card.PokemonName, &card.Level, &card.XP,
+			&card.BaseHP, &card.BaseAttack, &card.BaseDefense, &card.BaseSpeed,
+			&card.BaseSpAttack, &card.BaseSpDefense,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get card %d: %w", cardID, err)
 		}
+		cards = append(cards, card)
+	}
 
-		// Calculate old stats
-		oldStats := calculateStatsForLevel(level, baseHP, baseAttack, baseDefense, baseSpeed)
-
-		// Track level ups
-		oldLevel := level
-		newXP := xp + xpGained
-		newLevel := level
-
-		// Process level ups
-		for newLevel < 50 {
-			xpRequired := 100 * newLevel
-			if newXP >= xpRequired {
-				newXP -= xpRequired
-				newLevel++
-			} else {
-				break
-			}
-		}
-
-		// Cap at level 50
-		if newLevel >= 50 {
-			newLevel = 50
-			newXP = 0
-		}
-
-		// Calculate new stats
-		newStats := calculateStatsForLevel(newLevel, baseHP, baseAttack, baseDefense, baseSpeed)
+	results := LevelUpCards(cards, xpMap)
 
-		// Update database
+	// Update database
+	for _, card := range cards {
 		updateQuery := `
 			UPDATE player_cards
 			SET level = $1, xp = $2, updated_at = $3
-			WHERE id = $4
+			WHERE id = $4 AND user_id = $5
 		`
 
-		_, err = tx.Exec(ctx, updateQuery, newLevel, newXP, time.Now(), cardID)
+		_, err := tx.Exec(ctx, updateQuery, card.Level, card.XP, time.Now(), card.ID, userID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update card %d: %w", cardID, err)
-		}
-
-		// Record result
-		result := PokemonXPGain{
-			CardID:      cardID,
-			PokemonName: pokemonName,
-			XPGained:    xpGained,
-			OldLevel:    oldLevel,
-			NewLevel:    newLevel,
-			LeveledUp:   newLevel > oldLevel,
-		}
-
-		// Include stat changes if leveled up
-		if result.LeveledUp {
-			result.OldHP = oldStats.HP
-			result.NewHP = newStats.HP
-			result.OldAttack = oldStats.Attack
-			result.NewAttack = newStats.Attack
-			result.OldDefense = oldStats.Defense
-			result.NewDefense = newStats.Defense
-			result.OldSpeed = oldStats.Speed
-			result.NewSpeed = newStats.Speed
+			return nil, fmt.Errorf("failed to update card %d: %w", card.ID, err)
 		}
-
-		results = append(results, result)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return results, nil
 }
-
-// Stats represents Pokemon stats at a given level
-type Stats struct {
-	HP      int
-	Attack  int
-	Defense int
-	Speed   int
-}
-
-// calculateStatsForLevel calculates stats for a Pokemon at a given level
-// Stat increases: HP +3%, Attack +2%, Defense +2%, Speed +1% per level
-func calculateStatsForLevel(level int, baseHP, baseAttack, baseDefense, baseSpeed int) Stats {
-	levelMultiplier := float64(level - 1)
-
-	hp := int(float64(baseHP) * (1.0 + levelMultiplier*0.03))
-	attack := int(float64(baseAttack) * (1.0 + levelMultiplier*0.02))
-	defense := int(float64(baseDefense) * (1.0 + levelMultiplier*0.02))
-	speed := int(float64(baseSpeed) * (1.0 + levelMultiplier*0.01))
-
-	return Stats{
-		HP:      hp,
-		Attack:  attack,
-		Defense: defense,
-		Speed:   speed,
-	}
-}
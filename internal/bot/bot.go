@@ -0,0 +1,255 @@
+// Package bot runs external programs as battle opponents. A bot is any
+// executable that speaks the line-based protocol described in
+// docs/bot-protocol.md on its stdin and stdout.
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"pokemon-cli/internal/battle"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProtocolVersion is the version of the bot protocol the engine speaks
+const ProtocolVersion = 1
+
+// DefaultTimeout is how long a bot gets to answer before it forfeits
+const DefaultTimeout = 10 * time.Second
+
+// exitTimeout is how long a bot gets to exit after being told to quit
+const exitTimeout = time.Second
+
+var errClosed = errors.New("bot closed its output")
+
+// Bot is a running bot program. It implements battle.Strategy.
+//
+// A bot that crashes, stops answering or breaks the protocol forfeits: from
+// then on every decision is a surrender, and Err reports what went wrong.
+type Bot struct {
+	Name    string        // Name the bot gave itself, or its file name
+	Timeout time.Duration // Time allowed for each answer
+
+	cmd   *exec.Cmd
+	in    io.WriteCloser
+	lines chan string
+	done  chan struct{}
+	err   error
+	close sync.Once
+}
+
+// Start runs the bot at path and performs the protocol handshake. Its stderr
+// is discarded.
+func Start(path string, args ...string) (*Bot, error) {
+	cmd := exec.Command(path, args...)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start bot: %w", err)
+	}
+
+	b := newBot(in, out)
+	b.Name = filepath.Base(path)
+	b.cmd = cmd
+	if err := b.handshake(); err != nil {
+		b.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// newBot talks the protocol over in and out
+func newBot(in io.WriteCloser, out io.Reader) *Bot {
+	b := &Bot{
+		Timeout: DefaultTimeout,
+		in:      in,
+		lines:   make(chan string),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(b.lines)
+		scanner := bufio.NewScanner(out)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case b.lines <- scanner.Text():
+			case <-b.done:
+				return
+			}
+		}
+	}()
+	return b
+}
+
+// handshake announces the protocol version and waits for the bot to be ready
+func (b *Bot) handshake() error {
+	if err := b.send(fmt.Sprintf("poketactix %d", ProtocolVersion)); err != nil {
+		return err
+	}
+
+	for {
+		fields, err := b.receive()
+		if err != nil {
+			return fmt.Errorf("bot handshake failed: %w", err)
+		}
+		switch fields[0] {
+		case "name":
+			if len(fields) > 1 {
+				b.Name = strings.Join(fields[1:], " ")
+			}
+		case "ready":
+			return nil
+		}
+	}
+}
+
+// Decide implements battle.Strategy by sending the bot the battle and waiting for its move
+func (b *Bot) Decide(view *battle.BattleState) battle.Decision {
+	if b.err == nil {
+		var d battle.Decision
+		if d, b.err = b.decide(view); b.err == nil {
+			return d
+		}
+	}
+	return battle.Decision{Move: "surrender"}
+}
+
+func (b *Bot) decide(view *battle.BattleState) (battle.Decision, error) {
+	state, err := json.Marshal(NewState(view))
+	if err != nil {
+		return battle.Decision{}, err
+	}
+	if err := b.send("state " + string(state)); err != nil {
+		return battle.Decision{}, err
+	}
+	if err := b.send("go"); err != nil {
+		return battle.Decision{}, err
+	}
+
+	for {
+		fields, err := b.receive()
+		if err != nil {
+			return battle.Decision{}, err
+		}
+		if fields[0] == "move" {
+			return ParseMove(fields[1:])
+		}
+	}
+}
+
+// ParseMove parses the arguments of a bot's move line, e.g. "attack 1" or "switch 3"
+func ParseMove(args []string) (battle.Decision, error) {
+	if len(args) == 0 {
+		return battle.Decision{}, fmt.Errorf("move line has no move")
+	}
+
+	move := strings.ToLower(args[0])
+	switch move {
+	case "attack", "switch":
+		if len(args) != 2 {
+			return battle.Decision{}, fmt.Errorf("%s needs exactly one index", move)
+		}
+		idx, err := strconv.Atoi(args[1])
+		if err != nil || idx < 0 {
+			return battle.Decision{}, fmt.Errorf("invalid %s index %q", move, args[1])
+		}
+		if move == "attack" {
+			return battle.Decision{Move: move, MoveIdx: idx}, nil
+		}
+		return battle.Decision{Move: move, SwitchIdx: idx}, nil
+	case "defend", "pass", "sacrifice", "surrender":
+		if len(args) != 1 {
+			return battle.Decision{}, fmt.Errorf("%s takes no arguments", move)
+		}
+		return battle.Decision{Move: move}, nil
+	}
+	return battle.Decision{}, fmt.Errorf("unknown move %q", args[0])
+}
+
+// Result tells the bot how the battle ended. winner is the battle's winner:
+// "ai" means the bot won.
+func (b *Bot) Result(winner string) {
+	result := "draw"
+	switch winner {
+	case "ai":
+		result = "win"
+	case "player":
+		result = "loss"
+	}
+	b.send("result " + result)
+}
+
+// Err returns what made the bot forfeit, or nil if it is still playing
+func (b *Bot) Err() error {
+	return b.err
+}
+
+// Close tells the bot to quit, killing it if it doesn't exit promptly
+func (b *Bot) Close() error {
+	b.close.Do(func() {
+		b.send("quit")
+		b.in.Close()
+		close(b.done)
+
+		if b.cmd == nil {
+			return
+		}
+		exited := make(chan struct{})
+		go func() {
+			b.cmd.Wait()
+			close(exited)
+		}()
+		select {
+		case <-exited:
+		case <-time.After(exitTimeout):
+			b.cmd.Process.Kill()
+			<-exited
+		}
+	})
+	return nil
+}
+
+// send writes one line to the bot
+func (b *Bot) send(line string) error {
+	if _, err := io.WriteString(b.in, line+"\n"); err != nil {
+		return fmt.Errorf("failed to write to bot: %w", err)
+	}
+	return nil
+}
+
+// receive waits for the bot's next non-blank line that isn't an info line and
+// splits it into fields
+func (b *Bot) receive() ([]string, error) {
+	timeout := time.NewTimer(b.Timeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case line, ok := <-b.lines:
+			if !ok {
+				return nil, errClosed
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 || fields[0] == "info" {
+				continue
+			}
+			return fields, nil
+		case <-timeout.C:
+			return nil, fmt.Errorf("bot did not answer within %s", b.Timeout)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/pokemon"
+)
+
+const helperEnv = "POKETACTIX_HELPER_BOT"
+
+// TestHelperBot isn't a test: it is the bot the other tests run, by starting
+// the test binary with helperEnv set to how it should behave
+func TestHelperBot(t *testing.T) {
+	behavior := os.Getenv(helperEnv)
+	if behavior == "" {
+		return
+	}
+
+	in := bufio.NewScanner(os.Stdin)
+	in.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var state State
+	for in.Scan() {
+		line := in.Text()
+		switch {
+		case strings.HasPrefix(line, "poketactix "):
+			fmt.Println("name helper")
+			fmt.Println("info warming up")
+			fmt.Println("ready")
+		case strings.HasPrefix(line, "state "):
+			json.Unmarshal([]byte(strings.TrimPrefix(line, "state ")), &state)
+		case line == "go":
+			switch behavior {
+			case "silent":
+			case "garbage":
+				fmt.Println("move fly 3")
+			default:
+				fmt.Println(firstAffordable(state))
+			}
+		case line == "quit":
+			os.Exit(0)
+		}
+	}
+	os.Exit(0)
+}
+
+// firstAffordable is the helper bot's strategy: the first attack it can pay for
+func firstAffordable(state State) string {
+	card := state.You.Deck[state.You.Active]
+	for i, move := range card.Moves {
+		if card.Stamina >= move.StaminaCost {
+			return fmt.Sprintf("move attack %d", i)
+		}
+	}
+	return "move pass"
+}
+
+func startHelper(t *testing.T, behavior string) *Bot {
+	t.Helper()
+	t.Setenv(helperEnv, behavior)
+	b, err := Start(os.Args[0], "-test.run=^TestHelperBot$")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+func newBattle(t *testing.T) *battle.BattleState {
+	t.Helper()
+	card := func(name string) pokemon.Card {
+		return pokemon.Card{
+			Name: name, HP: 60, HPMax: 60, Stamina: 120, Attack: 55, Defense: 45, Speed: 60,
+			Types: []string{"normal"}, Level: 1,
+			Moves: []pokemon.Move{
+				{Name: "body-slam", Power: 85, StaminaCost: 28, Type: "normal"},
+				{Name: "tackle", Power: 40, StaminaCost: 13, Type: "normal"},
+			},
+		}
+	}
+	player := []pokemon.Card{card("snorlax"), card("eevee"), card("ditto"), card("meowth"), card("rattata")}
+	ai := []pokemon.Card{card("tauros"), card("kangaskhan"), card("lickitung"), card("porygon"), card("pidgey")}
+
+	bs, err := battle.StartBattleWithSeed(1, "5v5", player, ai, 5)
+	if err != nil {
+		t.Fatalf("StartBattleWithSeed failed: %v", err)
+	}
+	return bs
+}
+
+func TestBotPlaysABattle(t *testing.T) {
+	b := startHelper(t, "attack")
+	if b.Name != "helper" {
+		t.Errorf("bot name = %q, want helper", b.Name)
+	}
+
+	bs := newBattle(t)
+	if err := bs.SetStrategy(b.Name, b); err != nil {
+		t.Fatalf("SetStrategy failed: %v", err)
+	}
+	for turn := 0; !bs.BattleOver && turn < 200; turn++ {
+		if _, err := battle.ProcessMove(bs, "defend", nil); err != nil {
+			if _, err := battle.ProcessMove(bs, "pass", nil); err != nil {
+				t.Fatalf("ProcessMove failed: %v", err)
+			}
+		}
+	}
+	if err := b.Err(); err != nil {
+		t.Fatalf("bot failed: %v", err)
+	}
+	if !bs.BattleOver {
+		t.Fatal("battle did not finish")
+	}
+	b.Result(bs.Winner)
+
+	frames, err := bs.Replay.Frames()
+	if err != nil {
+		t.Fatalf("Frames failed: %v", err)
+	}
+	if last := frames[len(frames)-1].State; !reflect.DeepEqual(last.AIDeck, bs.AIDeck) || last.Winner != bs.Winner {
+		t.Error("replay does not reproduce the battle")
+	}
+}
+
+func TestSilentBotForfeits(t *testing.T) {
+	b := startHelper(t, "silent")
+	b.Timeout = 100 * time.Millisecond
+
+	if d := b.Decide(newBattle(t)); d.Move != "surrender" {
+		t.Errorf("got %+v, want surrender", d)
+	}
+	if b.Err() == nil {
+		t.Error("expected an error for a bot that never answers")
+	}
+}
+
+func TestBotBreakingProtocolForfeits(t *testing.T) {
+	b := startHelper(t, "garbage")
+
+	if d := b.Decide(newBattle(t)); d.Move != "surrender" {
+		t.Errorf("got %+v, want surrender", d)
+	}
+	if b.Err() == nil {
+		t.Error("expected an error for an unknown move")
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    battle.Decision
+		wantErr bool
+	}{
+		{"attack 1", battle.Decision{Move: "attack", MoveIdx: 1}, false},
+		{"SWITCH 3", battle.Decision{Move: "switch", SwitchIdx: 3}, false},
+		{"defend", battle.Decision{Move: "defend"}, false},
+		{"sacrifice", battle.Decision{Move: "sacrifice"}, false},
+		{"attack", battle.Decision{}, true},
+		{"attack -1", battle.Decision{}, true},
+		{"pass now", battle.Decision{}, true},
+		{"", battle.Decision{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMove(strings.Fields(tt.line))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMove(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMove(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package bot
+
+import "pokemon-cli/internal/battle"
+
+// State is the battle as a bot sees it: it always plays the "you" side
+type State struct {
+	Mode            string       `json:"mode"` // "1v1" or "5v5"
+	Turn            int          `json:"turn"`
+	Round           int          `json:"round"`
+	You             Side         `json:"you"`
+	Opponent        Side         `json:"opponent"`
+	OpponentMove    string       `json:"opponent_move"`     // Move the opponent has committed to this turn
+	OpponentMoveIdx int          `json:"opponent_move_idx"` // Move index when OpponentMove is an attack
+	CanSwitch       bool         `json:"can_switch"`        // Whether switching is allowed right now
+	Rules           battle.Rules `json:"rules"`             // Optional rules the battle is played with
+	Field           battle.Field `json:"field"`             // Weather and terrain in play
+}
+
+// Side is one side's Pokemon
+type Side struct {
+	Active     int                 `json:"active"`     // Index of the active Pokemon in Deck
+	Sacrifices int                 `json:"sacrifices"` // Sacrifices the active Pokemon has already made
+	Deck       []battle.BattleCard `json:"deck"`
+}
+
+// NewState describes a battle from the AI side's point of view
+func NewState(bs *battle.BattleState) State {
+	return State{
+		Mode:  bs.Mode,
+		Turn:  bs.TurnNumber,
+		Round: bs.RoundNumber,
+		You: Side{
+			Active:     bs.AIActiveIdx,
+			Sacrifices: bs.AISacrificeCount[bs.AIActiveIdx],
+			Deck:       bs.AIDeck,
+		},
+		Opponent: Side{
+			Active:     bs.PlayerActiveIdx,
+			Sacrifices: bs.SacrificeCount[bs.PlayerActiveIdx],
+			Deck:       bs.PlayerDeck,
+		},
+		OpponentMove:    bs.PendingPlayerMove,
+		OpponentMoveIdx: bs.PendingPlayerMoveIdx,
+		CanSwitch:       bs.Mode == "5v5" && !bs.AISwitched(),
+		Rules:           bs.Rules,
+		Field:           bs.Field,
+	}
+}
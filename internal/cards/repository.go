@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/ruleset"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -25,15 +26,17 @@ func (r *Repository) Create(ctx context.Context, card *database.PlayerCard) (*da
 	query := `
 		INSERT INTO player_cards (
 			user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
+			base_sp_attack, base_sp_defense,
 			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id, created_at, updated_at
 	`
 
 	err := r.db.QueryRow(ctx, query,
 		card.UserID, card.PokemonName, card.Level, card.XP,
 		card.BaseHP, card.BaseAttack, card.BaseDefense, card.BaseSpeed,
+		card.BaseSpAttack, card.BaseSpDefense,
 		card.Types, card.Moves, card.Sprite,
 		card.IsLegendary, card.IsMythical, card.InDeck, card.DeckPosition,
 	).Scan(&card.ID, &card.CreatedAt, &card.UpdatedAt)
@@ -49,7 +52,8 @@ func (r *Repository) Create(ctx context.Context, card *database.PlayerCard) (*da
 func (r *Repository) GetByID(ctx context.Context, id int) (*database.PlayerCard, error) {
 	query := `
 		SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
-			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, created_at, updated_at
+			base_sp_attack, base_sp_defense,
+			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, held_item, created_at, updated_at
 		FROM player_cards
 		WHERE id = $1
 	`
@@ -58,8 +62,9 @@ func (r *Repository) GetByID(ctx context.Context, id int) (*database.PlayerCard,
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&card.ID, &card.UserID, &card.PokemonName, &card.Level, &card.XP,
 		&card.BaseHP, &card.BaseAttack, &card.BaseDefense, &card.BaseSpeed,
+		&card.BaseSpAttack, &card.BaseSpDefense,
 		&card.Types, &card.Moves, &card.Sprite,
-		&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition,
+		&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition, &card.HeldItem,
 		&card.CreatedAt, &card.UpdatedAt,
 	)
 
@@ -77,7 +82,8 @@ func (r *Repository) GetByID(ctx context.Context, id int) (*database.PlayerCard,
 func (r *Repository) GetUserCards(ctx context.Context, userID int) ([]database.PlayerCard, error) {
 	query := `
 		SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
-			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, created_at, updated_at
+			base_sp_attack, base_sp_defense,
+			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, held_item, created_at, updated_at
 		FROM player_cards
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -95,8 +101,9 @@ func (r *Repository) GetUserCards(ctx context.Context, userID int) ([]database.P
 		err := rows.Scan(
 			&card.ID, &card.UserID, &card.PokemonName, &card.Level, &card.XP,
 			&card.BaseHP, &card.BaseAttack, &card.BaseDefense, &card.BaseSpeed,
+			&card.BaseSpAttack, &card.BaseSpDefense,
 			&card.Types, &card.Moves, &card.Sprite,
-			&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition,
+			&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition, &card.HeldItem,
 			&card.CreatedAt, &card.UpdatedAt,
 		)
 		if err != nil {
@@ -112,7 +119,8 @@ func (r *Repository) GetUserCards(ctx context.Context, userID int) ([]database.P
 func (r *Repository) GetUserDeck(ctx context.Context, userID int) ([]database.PlayerCard, error) {
 	query := `
 		SELECT id, user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
-			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, created_at, updated_at
+			base_sp_attack, base_sp_defense,
+			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position, held_item, created_at, updated_at
 		FROM player_cards
 		WHERE user_id = $1 AND in_deck = TRUE
 		ORDER BY deck_position ASC
@@ -130,8 +138,9 @@ func (r *Repository) GetUserDeck(ctx context.Context, userID int) ([]database.Pl
 		err := rows.Scan(
 			&card.ID, &card.UserID, &card.PokemonName, &card.Level, &card.XP,
 			&card.BaseHP, &card.BaseAttack, &card.BaseDefense, &card.BaseSpeed,
+			&card.BaseSpAttack, &card.BaseSpDefense,
 			&card.Types, &card.Moves, &card.Sprite,
-			&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition,
+			&card.IsLegendary, &card.IsMythical, &card.InDeck, &card.DeckPosition, &card.HeldItem,
 			&card.CreatedAt, &card.UpdatedAt,
 		)
 		if err != nil {
@@ -189,21 +198,7 @@ func (r *Repository) AddXP(ctx context.Context, cardID int, xp int) (*database.P
 		return nil, err
 	}
 
-	card.XP += xp
-
-	for card.Level < 50 {
-		xpRequired := 100 * card.Level
-		if card.XP >= xpRequired {
-			card.XP -= xpRequired
-			card.Level++
-		} else {
-			break
-		}
-	}
-
-	if card.Level >= 50 {
-		card.XP = 0
-	}
+	card.Level, card.XP = ruleset.Current().Progression.AddXP(card.Level, card.XP, xp)
 
 	query := `
 		UPDATE player_cards
@@ -224,17 +219,19 @@ func (r *Repository) Update(ctx context.Context, card *database.PlayerCard) erro
 	query := `
 		UPDATE player_cards
 		SET pokemon_name = $1, level = $2, xp = $3, base_hp = $4, base_attack = $5,
-			base_defense = $6, base_speed = $7, types = $8, moves = $9, sprite = $10,
-			is_legendary = $11, is_mythical = $12, in_deck = $13, deck_position = $14, updated_at = $15
-		WHERE id = $16
+			base_defense = $6, base_speed = $7, base_sp_attack = $8, base_sp_defense = $9,
+			types = $10, moves = $11, sprite = $12, is_legendary = $13, is_mythical = $14,
+			in_deck = $15, deck_position = $16, held_item = $17, updated_at = $18
+		WHERE id = $19
 	`
 
 	card.UpdatedAt = time.Now()
 	_, err := r.db.Exec(ctx, query,
 		card.PokemonName, card.Level, card.XP,
 		card.BaseHP, card.BaseAttack, card.BaseDefense, card.BaseSpeed,
+		card.BaseSpAttack, card.BaseSpDefense,
 		card.Types, card.Moves, card.Sprite,
-		card.IsLegendary, card.IsMythical, card.InDeck, card.DeckPosition,
+		card.IsLegendary, card.IsMythical, card.InDeck, card.DeckPosition, card.HeldItem,
 		card.UpdatedAt, card.ID,
 	)
 
@@ -62,21 +62,23 @@ func (s *Service) GenerateStarterDeck(ctx context.Context, userID int) ([]databa
 
 		deckPosition := len(starterCards) + 1
 		playerCard := &database.PlayerCard{
-			UserID:       userID,
-			PokemonName:  card.Name,
-			Level:        1,
-			XP:           0,
-			BaseHP:       card.HPMax,
-			BaseAttack:   card.Attack,
-			BaseDefense:  card.Defense,
-			BaseSpeed:    card.Speed,
-			Types:        typesJSON,
-			Moves:        movesJSON,
-			Sprite:       card.Sprite,
-			IsLegendary:  false,
-			IsMythical:   false,
-			InDeck:       true,
-			DeckPosition: &deckPosition,
+			UserID:        userID,
+			PokemonName:   card.Name,
+			Level:         1,
+			XP:            0,
+			BaseHP:        card.HPMax,
+			BaseAttack:    card.Attack,
+			BaseDefense:   card.Defense,
+			BaseSpAttack:  card.SpAttack,
+			BaseSpDefense: card.SpDefense,
+			BaseSpeed:     card.Speed,
+			Types:         typesJSON,
+			Moves:         movesJSON,
+			Sprite:        card.Sprite,
+			IsLegendary:   false,
+			IsMythical:    false,
+			InDeck:        true,
+			DeckPosition:  &deckPosition,
 		}
 
 		// Create card in database
@@ -2,14 +2,19 @@ package commands
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 
 	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/bot"
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/pkg/rng"
 )
 
 type BattleCommand struct {
@@ -26,7 +31,27 @@ func NewBattleCommand(gameState *storage.GameState, renderer *ui.Renderer, scann
 	}
 }
 
+// BattleOptions are the options the battle command was given
+type BattleOptions struct {
+	Seed        int64  // Source of the AI deck and every roll
+	OpponentBot string // Program that plays the AI side instead of the built-in AI
+}
+
 func (bc *BattleCommand) StartBattle() error {
+	return bc.StartBattleWithSeed(rng.NewSeed())
+}
+
+// StartBattleWithSeed starts a battle whose AI deck and rolls all come from seed,
+// so a battle reported by its seed can be played again identically
+func (bc *BattleCommand) StartBattleWithSeed(seed int64) error {
+	return bc.StartBattleWithOptions(BattleOptions{Seed: seed})
+}
+
+// StartBattleWithOptions starts a battle. With an opponent bot the AI side is
+// played by that program (see docs/bot-protocol.md) and the battle is an
+// exhibition that pays no rewards.
+func (bc *BattleCommand) StartBattleWithOptions(opts BattleOptions) error {
+	seed := opts.Seed
 	if len(bc.gameState.Deck) == 0 {
 		return fmt.Errorf("you don't have any Pokemon in your deck. Use 'deck edit' to create a deck")
 	}
@@ -86,20 +111,56 @@ func (bc *BattleCommand) StartBattle() error {
 		break
 	}
 
+	difficulty := battle.DifficultyNormal
+	var opponent *bot.Bot
+	if opts.OpponentBot != "" {
+		var err error
+		opponent, err = bot.Start(opts.OpponentBot)
+		if err != nil {
+			return fmt.Errorf("failed to start opponent bot: %w", err)
+		}
+		defer opponent.Close()
+	} else {
+		var ok bool
+		difficulty, ok = bc.promptDifficulty()
+		if !ok {
+			fmt.Println("Battle cancelled.")
+			return nil
+		}
+	}
+
 	playerDeck, err := bc.loadPlayerDeck(mode)
 	if err != nil {
 		return fmt.Errorf("failed to load player deck: %w", err)
 	}
 
-	aiDeck, err := bc.generateAIDeck(mode)
+	aiDeck, err := bc.generateAIDeck(mode, rng.New(seed))
 	if err != nil {
 		return fmt.Errorf("failed to generate AI deck: %w", err)
 	}
 
-	battleState, err := battle.StartBattle(0, mode, playerDeck, aiDeck)
+	battleState, err := battle.StartBattleWithSeed(0, mode, playerDeck, aiDeck, seed)
 	if err != nil {
 		return fmt.Errorf("failed to start battle: %w", err)
 	}
+	if err := battleState.SetDifficulty(difficulty); err != nil {
+		return fmt.Errorf("failed to start battle: %w", err)
+	}
+	if opponent != nil {
+		if err := battleState.SetStrategy(opponent.Name, opponent); err != nil {
+			return fmt.Errorf("failed to start battle: %w", err)
+		}
+	}
+	rules := battle.Rules{SpeedOrder: bc.gameState.Settings.SpeedOrder, Damage: bc.gameState.Settings.DamageRules, ItemLimit: bc.gameState.Settings.ItemLimit}
+	if bc.gameState.Settings.RandomWeather {
+		rules.Field = battle.FieldRandom
+	}
+	if err := battleState.SetRules(rules); err != nil {
+		return fmt.Errorf("failed to start battle: %w", err)
+	}
+	if err := battleState.SetBag(bc.gameState.Bag); err != nil {
+		return fmt.Errorf("failed to start battle: %w", err)
+	}
 
 	fmt.Println()
 	if mode == "1v1" {
@@ -107,10 +168,93 @@ func (bc *BattleCommand) StartBattle() error {
 	} else {
 		fmt.Println("Starting 5v5 battle with your full deck!")
 	}
+	if opponent != nil {
+		fmt.Printf("Your opponent is the bot %q. Bot battles are exhibitions: no coins or XP are awarded.\n", opponent.Name)
+	}
+	if battleState.Rules.SpeedOrder {
+		fmt.Println("Speed Turn Order is on: the faster Pokemon attacks first.")
+	}
+	if battleState.Rules.Damage == battle.DamageModern {
+		fmt.Println("Modern Damage Rules are on: moves can miss and land critical hits, and get a same-type bonus.")
+	}
+	if battleState.Rules.Field != "" {
+		fmt.Printf("Random Weather is on: this battle is played in %s.\n", ui.FieldName(battleState.Rules.Field))
+	}
+	if len(battleState.Bag) > 0 {
+		fmt.Printf("You may use up to %d items from your bag this battle.\n", battleState.ItemLimit())
+	}
 	fmt.Println("Press Enter to begin...")
 	bc.scanner.Scan()
 
-	return bc.runBattleLoop(battleState, mode)
+	err = bc.runBattleLoop(battleState, mode, opponent)
+	if opponent != nil && battleState.BattleOver {
+		opponent.Result(battleState.Winner)
+	}
+	return err
+}
+
+// promptDifficulty asks which AI difficulty to battle against. It reports false if the player cancels.
+func (bc *BattleCommand) promptDifficulty() (string, bool) {
+	difficultyOptions := []ui.MenuOption{
+		{
+			Label:       "Easy",
+			Description: "The AI picks its moves at random (x0.5 rewards)",
+			Value:       battle.DifficultyEasy,
+		},
+		{
+			Label:       "Normal",
+			Description: "The AI weighs type matchups and stamina (x1 rewards)",
+			Value:       battle.DifficultyNormal,
+		},
+		{
+			Label:       "Hard",
+			Description: "The AI plays out each move a turn ahead (x1.5 rewards)",
+			Value:       battle.DifficultyHard,
+		},
+		{
+			Label:       "Expert",
+			Description: "The AI plans two turns ahead and anticipates you (x2 rewards)",
+			Value:       battle.DifficultyExpert,
+		},
+		{
+			Label:       "Master",
+			Description: "The AI searches every damage roll a few turns deep (x2.5 rewards)",
+			Value:       battle.DifficultyMaster,
+		},
+		{
+			Label:       "Champion",
+			Description: "The AI plays out thousands of battles, switching when it pays (x3 rewards)",
+			Value:       battle.DifficultyChampion,
+		},
+		{
+			Label:       "Cancel",
+			Description: "Return to main menu",
+			Value:       "cancel",
+		},
+	}
+
+	fmt.Println()
+	fmt.Println(bc.renderer.RenderBorderedMenu(difficultyOptions, 1, "SELECT AI DIFFICULTY"))
+	fmt.Printf("Enter your choice (1-%d): ", len(difficultyOptions))
+
+	for {
+		if !bc.scanner.Scan() {
+			return "", false
+		}
+
+		input := strings.TrimSpace(bc.scanner.Text())
+		choice, err := strconv.Atoi(input)
+		if err != nil || choice < 1 || choice > len(difficultyOptions) {
+			fmt.Printf("Invalid choice. Enter a number from 1 to %d: ", len(difficultyOptions))
+			continue
+		}
+
+		option := difficultyOptions[choice-1]
+		if option.Value == "cancel" {
+			return "", false
+		}
+		return option.Value, true
+	}
 }
 
 func (bc *BattleCommand) loadPlayerDeck(mode string) ([]pokemon.Card, error) {
@@ -147,7 +291,7 @@ func (bc *BattleCommand) loadPlayerDeck(mode string) ([]pokemon.Card, error) {
 	return playerDeck, nil
 }
 
-func (bc *BattleCommand) generateAIDeck(mode string) ([]pokemon.Card, error) {
+func (bc *BattleCommand) generateAIDeck(mode string, r rng.Rand) ([]pokemon.Card, error) {
 	var aiDeck []pokemon.Card
 	var count int
 
@@ -158,14 +302,15 @@ func (bc *BattleCommand) generateAIDeck(mode string) ([]pokemon.Card, error) {
 	}
 
 	for i := 0; i < count; i++ {
-		card := pokemon.FetchRandomPokemonCardOffline()
+		card := pokemon.FetchRandomPokemonCardOfflineWithRand(r)
 		aiDeck = append(aiDeck, card)
 	}
 
 	return aiDeck, nil
 }
 
-func (bc *BattleCommand) runBattleLoop(bs *battle.BattleState, mode string) error {
+// runBattleLoop plays the battle to the end. opponent is the bot playing the AI side, if any.
+func (bc *BattleCommand) runBattleLoop(bs *battle.BattleState, mode string, opponent *bot.Bot) error {
 	quickBattle := bc.gameState.Settings.QuickBattle
 
 	for !bs.BattleOver {
@@ -200,7 +345,7 @@ func (bc *BattleCommand) runBattleLoop(bs *battle.BattleState, mode string) erro
 			}
 		}
 
-		logEntries, err := battle.ProcessMove(bs, action, moveIdx)
+		events, err := battle.ProcessMove(bs, action, moveIdx)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			if !quickBattle {
@@ -210,6 +355,11 @@ func (bc *BattleCommand) runBattleLoop(bs *battle.BattleState, mode string) erro
 			continue
 		}
 
+		// Items used in battle are gone from the bag for good
+		if action == "item" {
+			bc.gameState.Bag = maps.Clone(bs.Bag)
+		}
+
 		bc.renderer.Clear()
 
 		if quickBattle {
@@ -220,10 +370,10 @@ func (bc *BattleCommand) runBattleLoop(bs *battle.BattleState, mode string) erro
 		fmt.Println()
 
 		if quickBattle {
-			fmt.Println(bc.renderer.RenderBattleLogSimple(logEntries, 5))
+			fmt.Println(bc.renderer.RenderBattleEvents(events, 5))
 			ui.Sleep(bc.gameState.Settings.BattleSpeed, "short")
 		} else {
-			fmt.Println(bc.renderer.RenderBattleLogSimple(logEntries, 10))
+			fmt.Println(bc.renderer.RenderBattleEvents(events, 10))
 			fmt.Println("Press Enter to continue...")
 			bc.scanner.Scan()
 		}
@@ -239,14 +389,14 @@ func (bc *BattleCommand) runBattleLoop(bs *battle.BattleState, mode string) erro
 		}
 	}
 
-	return bc.handleBattleEnd(bs, mode)
+	return bc.handleBattleEnd(bs, mode, opponent)
 }
 
 func (bc *BattleCommand) promptPlayerAction(bs *battle.BattleState) (string, *int, error) {
-	actions := []string{"Attack", "Defend", "Pass", "Sacrifice", "Surrender"}
+	actions := []string{"Attack", "Defend", "Pass", "Sacrifice", "Surrender", "Item"}
 
 	fmt.Println(bc.renderer.RenderBattleActions(actions, -1))
-	fmt.Print("Select action (1-5): ")
+	fmt.Print("Select action (1-6): ")
 
 	for {
 		if !bc.scanner.Scan() {
@@ -255,8 +405,8 @@ func (bc *BattleCommand) promptPlayerAction(bs *battle.BattleState) (string, *in
 
 		input := strings.TrimSpace(bc.scanner.Text())
 		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > 5 {
-			fmt.Print("Invalid choice. Enter 1-5: ")
+		if err != nil || choice < 1 || choice > 6 {
+			fmt.Print("Invalid choice. Enter 1-6: ")
 			continue
 		}
 
@@ -271,7 +421,45 @@ func (bc *BattleCommand) promptPlayerAction(bs *battle.BattleState) (string, *in
 			return "sacrifice", nil, nil
 		case 5:
 			return "surrender", nil, nil
+		case 6:
+			return bc.promptItemSelection(bs)
+		}
+	}
+}
+
+// promptItemSelection asks which battle item from the bag to use
+func (bc *BattleCommand) promptItemSelection(bs *battle.BattleState) (string, *int, error) {
+	fmt.Println()
+	fmt.Printf("BAG (items used: %d/%d)\n", bs.ItemsUsed, bs.ItemLimit())
+	fmt.Println(strings.Repeat("═", 60))
+	for i, item := range pokemon.BattleItems {
+		fmt.Printf("  [%d] %-14s x%-3d %s\n", i+1, pokemon.HeldItemName(item.Name), bs.Bag[item.Name], item.Description)
+	}
+	fmt.Printf("\nSelect item (1-%d) or 0 to go back: ", len(pokemon.BattleItems))
+
+	for {
+		if !bc.scanner.Scan() {
+			return "", nil, fmt.Errorf("failed to read input")
+		}
+
+		input := strings.TrimSpace(bc.scanner.Text())
+		choice, err := strconv.Atoi(input)
+		if err != nil || choice < 0 || choice > len(pokemon.BattleItems) {
+			fmt.Printf("Invalid choice. Enter 0-%d: ", len(pokemon.BattleItems))
+			continue
 		}
+
+		if choice == 0 {
+			return bc.promptPlayerAction(bs)
+		}
+
+		itemIdx := choice - 1
+		if bs.Bag[pokemon.BattleItems[itemIdx].Name] <= 0 {
+			fmt.Print("You don't have any left. Choose another item: ")
+			continue
+		}
+
+		return "item", &itemIdx, nil
 	}
 }
 
@@ -374,7 +562,7 @@ func (bc *BattleCommand) handlePokemonSwitch(bs *battle.BattleState, forced bool
 			continue
 		}
 
-		err = battle.SwitchPokemon(bs, newIdx)
+		_, err = battle.SwitchPokemon(bs, newIdx)
 		if err != nil {
 			fmt.Printf("Error switching Pokemon: %v\n", err)
 			fmt.Print("Choose another Pokemon: ")
@@ -388,7 +576,7 @@ func (bc *BattleCommand) handlePokemonSwitch(bs *battle.BattleState, forced bool
 	}
 }
 
-func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) error {
+func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string, opponent *bot.Bot) error {
 	bc.renderer.Clear()
 	fmt.Println(bc.renderer.RenderBattleScreen(bs))
 	fmt.Println()
@@ -398,38 +586,17 @@ func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) er
 	fmt.Println()
 
 	var result string
-	var coinsEarned int
-	var xpPerPokemon int
+	coinsEarned := ruleset.Current().OfflineCoins(mode, bs.Winner)
 
 	switch bs.Winner {
 	case "player":
 		result = "VICTORY"
-		if mode == "1v1" {
-			coinsEarned = 50
-			xpPerPokemon = 20
-		} else {
-			coinsEarned = 150
-			xpPerPokemon = 15
-		}
 		fmt.Println(ui.Colorize("🎉 VICTORY! 🎉", ui.Bold+ui.ColorBrightGreen))
 	case "ai":
 		result = "DEFEAT"
-		if mode == "1v1" {
-			coinsEarned = 10
-		} else {
-			coinsEarned = 25
-		}
-		xpPerPokemon = 0
 		fmt.Println(ui.Colorize("💀 DEFEAT 💀", ui.Bold+ui.ColorRed))
 	case "draw":
 		result = "DRAW"
-		if mode == "1v1" {
-			coinsEarned = 25
-			xpPerPokemon = 10
-		} else {
-			coinsEarned = 75
-			xpPerPokemon = 8
-		}
 		fmt.Println(ui.Colorize("⚖️  DRAW ⚖️", ui.Bold+ui.ColorYellow))
 	}
 
@@ -437,51 +604,36 @@ func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) er
 	fmt.Println(strings.Repeat("═", 60))
 	fmt.Println()
 
-	bc.gameState.Coins += coinsEarned
-	fmt.Printf("Coins earned: +%d (Total: %d)\n", coinsEarned, bc.gameState.Coins)
+	fmt.Printf("Battle seed: %d\n", bs.Seed)
+	if opponent != nil {
+		fmt.Printf("Opponent bot: %s\n", opponent.Name)
+		if err := opponent.Err(); err != nil {
+			fmt.Println(ui.Colorize(fmt.Sprintf("The bot forfeited: %v", err), ui.ColorYellow))
+		}
+	} else {
+		fmt.Printf("AI difficulty: %s\n", bs.Difficulty())
+	}
 	fmt.Println()
 
-	if xpPerPokemon > 0 {
-		fmt.Println("Experience gained:")
-		leveledUp := false
-
-		for _, deckIdx := range bc.gameState.Deck {
-			if deckIdx < 0 || deckIdx >= len(bc.gameState.Collection) {
-				continue
-			}
-
-			card := &bc.gameState.Collection[deckIdx]
-			oldLevel := card.Level
-			card.XP += xpPerPokemon
-
-			xpNeeded := 100
-			if card.XP >= xpNeeded {
-				card.Level++
-				card.XP -= xpNeeded
-				leveledUp = true
-
-				fmt.Printf("  %s: +%d XP → ", card.Name, xpPerPokemon)
-				fmt.Println(ui.Colorize(fmt.Sprintf("LEVEL UP! %d → %d", oldLevel, card.Level), ui.Bold+ui.ColorBrightYellow))
-
-				newStats := card.GetCurrentStats()
-				fmt.Printf("    New stats: HP: %d, ATK: %d, DEF: %d, SPD: %d\n",
-					newStats.HP, newStats.Attack, newStats.Defense, newStats.Speed)
-			} else {
-				fmt.Printf("  %s: +%d XP (%d/%d to next level)\n", card.Name, xpPerPokemon, card.XP, xpNeeded)
-			}
+	// Harder AI opponents pay out more; bots, which anyone can write, pay nothing
+	coinsEarned = battle.ScaleReward(coinsEarned, bs.Difficulty())
+	exhibition := opponent != nil
+	if exhibition {
+		coinsEarned = 0
+	}
 
-			// Update highest level
-			if card.Level > bc.gameState.Stats.HighestLevel {
-				bc.gameState.Stats.HighestLevel = card.Level
-			}
-		}
+	bc.gameState.Coins += coinsEarned
+	fmt.Printf("Coins earned: +%d (Total: %d)\n", coinsEarned, bc.gameState.Coins)
+	fmt.Println()
 
-		if leveledUp {
-			fmt.Println()
-		}
+	if !exhibition {
+		bc.showXPGains(awardBattleXP(bc.gameState, bs))
 	}
 
-	if mode == "1v1" {
+	switch {
+	case exhibition:
+		// Exhibitions don't count toward the player's record
+	case mode == "1v1":
 		bc.gameState.Stats.TotalBattles1v1++
 		switch result {
 		case "VICTORY":
@@ -491,7 +643,7 @@ func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) er
 		case "DRAW":
 			bc.gameState.Stats.Draws1v1++
 		}
-	} else {
+	default:
 		bc.gameState.Stats.TotalBattles5v5++
 		switch result {
 		case "VICTORY":
@@ -506,19 +658,24 @@ func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) er
 	bc.gameState.Stats.TotalCoinsEarned += coinsEarned
 
 	battleRecord := storage.BattleRecord{
+		ID:          bs.ID,
 		Mode:        mode,
 		Result:      strings.ToLower(result),
 		CoinsEarned: coinsEarned,
+		Seed:        bs.Seed,
+		Difficulty:  bs.Difficulty(),
 		Timestamp:   bs.CreatedAt,
 	}
+	if exhibition {
+		battleRecord.Difficulty = "bot"
+	}
+	// SaveGameState trims the history, deleting the timelines of the battles it drops
 	bc.gameState.BattleHistory = append(bc.gameState.BattleHistory, battleRecord)
 
-	if len(bc.gameState.BattleHistory) > 20 {
-		bc.gameState.BattleHistory = bc.gameState.BattleHistory[len(bc.gameState.BattleHistory)-20:]
+	if !exhibition {
+		bc.gameState.ShopState.BattlesSinceRefresh++
 	}
 
-	bc.gameState.ShopState.BattlesSinceRefresh++
-
 	err := storage.SaveGameState(bc.gameState)
 	if err != nil {
 		fmt.Printf("Warning: Failed to save game state: %v\n", err)
@@ -527,6 +684,16 @@ func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) er
 		fmt.Println(ui.Colorize("✓ Game saved successfully", ui.ColorGreen))
 	}
 
+	if err := bc.saveTimeline(bs); err != nil {
+		fmt.Printf("Warning: Failed to save battle timeline: %v\n", err)
+	}
+
+	if replayPath, err := bc.saveReplay(bs); err != nil {
+		fmt.Printf("Warning: Failed to save replay: %v\n", err)
+	} else {
+		fmt.Printf("Replay saved to %s\n", replayPath)
+	}
+
 	if bc.gameState.ShopState.BattlesSinceRefresh >= 10 {
 		shopCmd := NewShopCommand(bc.gameState, bc.renderer, bc.scanner)
 		if err := shopCmd.CheckAndRefreshShop(); err != nil {
@@ -541,13 +708,109 @@ func (bc *BattleCommand) handleBattleEnd(bs *battle.BattleState, mode string) er
 	fmt.Println("Press Enter to continue...")
 	bc.scanner.Scan()
 
-	if mode == "5v5" && bs.Winner == "player" {
+	if mode == "5v5" && bs.Winner == "player" && !exhibition {
 		return bc.handlePostBattlePokemonSelection(bs)
 	}
 
 	return nil
 }
 
+// cardXPGain is the XP a card of the collection gained
+type cardXPGain struct {
+	battle.PokemonXPGain
+	CollectionIdx int // Index of the card in the collection
+}
+
+// awardBattleXP gives the Pokemon of the player's deck the XP they earned in
+// the finished battle, levelling them up the same way the server levels up its
+// cards, and returns what each gained
+func awardBattleXP(gameState *storage.GameState, bs *battle.BattleState) []cardXPGain {
+	var gains []cardXPGain
+	for pos, xp := range battle.XPEarned(bs) {
+		if xp == 0 || pos >= len(gameState.Deck) {
+			continue
+		}
+		deckIdx := gameState.Deck[pos]
+		if deckIdx < 0 || deckIdx >= len(gameState.Collection) {
+			continue
+		}
+
+		card := &gameState.Collection[deckIdx]
+		// Offline cards have no database ID to report
+		gain, newXP := battle.GainXP(0, card.Name, card.Level, card.XP, xp, card.BaseStats())
+		card.Level, card.XP = gain.NewLevel, newXP
+		gains = append(gains, cardXPGain{PokemonXPGain: gain, CollectionIdx: deckIdx})
+
+		// Update highest level
+		if card.Level > gameState.Stats.HighestLevel {
+			gameState.Stats.HighestLevel = card.Level
+		}
+	}
+	return gains
+}
+
+// showXPGains prints the XP the player's Pokemon gained and their level ups
+func (bc *BattleCommand) showXPGains(gains []cardXPGain) {
+	if len(gains) == 0 {
+		return
+	}
+
+	fmt.Println("Experience gained:")
+	leveledUp := false
+	for _, gain := range gains {
+		card := &bc.gameState.Collection[gain.CollectionIdx]
+		if gain.LeveledUp {
+			leveledUp = true
+			fmt.Printf("  %s: +%d XP → ", gain.PokemonName, gain.XPGained)
+			fmt.Println(ui.Colorize(fmt.Sprintf("LEVEL UP! %d → %d", gain.OldLevel, gain.NewLevel), ui.Bold+ui.ColorBrightYellow))
+
+			newStats := card.GetCurrentStats()
+			fmt.Printf("    New stats: HP: %d, ATK: %d, DEF: %d, SP.ATK: %d, SP.DEF: %d, SPD: %d\n",
+				newStats.HP, newStats.Attack, newStats.Defense, newStats.SpAttack, newStats.SpDefense, newStats.Speed)
+		} else {
+			fmt.Printf("  %s: +%d XP (XP: %s)\n", gain.PokemonName, gain.XPGained, formatXP(card))
+		}
+	}
+
+	if leveledUp {
+		fmt.Println()
+	}
+}
+
+// formatXP shows how far card is towards its next level, such as "30/200"
+func formatXP(card *storage.PlayerCard) string {
+	toNext := ruleset.Current().Progression.XPToNext(card.Level)
+	if toNext == 0 {
+		return "max"
+	}
+	return fmt.Sprintf("%d/%d", card.XP, toNext)
+}
+
+// saveReplay writes the finished battle's replay to the replay directory
+func (bc *BattleCommand) saveReplay(bs *battle.BattleState) (string, error) {
+	if bs.Replay == nil {
+		return "", fmt.Errorf("battle was not recorded")
+	}
+
+	data, err := battle.EncodeReplay(bs.Replay)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s_%s_%s_%s.json", bs.CreatedAt.Format("20060102_150405"), bs.Mode, bs.Winner, bs.ID[:8])
+	return storage.SaveReplay(name, data)
+}
+
+// saveTimeline writes the battle's turn-by-turn record to the history directory
+func (bc *BattleCommand) saveTimeline(bs *battle.BattleState) error {
+	data, err := json.MarshalIndent(bs.Turns, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode battle timeline: %w", err)
+	}
+
+	return storage.SaveBattleTimeline(bs.ID, data)
+}
+
 func (bc *BattleCommand) handlePostBattlePokemonSelection(bs *battle.BattleState) error {
 	bc.renderer.Clear()
 
@@ -583,8 +846,8 @@ func (bc *BattleCommand) handlePostBattlePokemonSelection(bs *battle.BattleState
 		fmt.Println()
 
 		// Stats
-		fmt.Printf("    HP: %d | ATK: %d | DEF: %d | SPD: %d\n",
-			aiCard.HPMax, aiCard.Attack, aiCard.Defense, aiCard.Speed)
+		fmt.Printf("    HP: %d | ATK: %d | DEF: %d | SP.ATK: %d | SP.DEF: %d | SPD: %d\n",
+			aiCard.HPMax, aiCard.Attack, aiCard.Defense, aiCard.SpAttack, aiCard.SpDefense, aiCard.Speed)
 
 		// Moves
 		fmt.Print("    Moves: ")
@@ -613,8 +876,8 @@ func (bc *BattleCommand) handlePostBattlePokemonSelection(bs *battle.BattleState
 
 		input := strings.TrimSpace(bc.scanner.Text())
 		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > 5 {
-			fmt.Print("Invalid choice. Enter 1-5: ")
+		if err != nil || choice < 1 || choice > 6 {
+			fmt.Print("Invalid choice. Enter 1-6: ")
 			continue
 		}
 
@@ -622,21 +885,23 @@ func (bc *BattleCommand) handlePostBattlePokemonSelection(bs *battle.BattleState
 		selectedCard := bs.AIDeck[selectedIdx]
 
 		newCard := storage.PlayerCard{
-			ID:          len(bc.gameState.Collection), // Assign new ID
-			PokemonID:   selectedCard.CardID,
-			Name:        selectedCard.Name,
-			Level:       1, // Add at level 1
-			XP:          0,
-			BaseHP:      selectedCard.HPMax,
-			BaseAttack:  selectedCard.Attack,
-			BaseDefense: selectedCard.Defense,
-			BaseSpeed:   selectedCard.Speed,
-			Types:       selectedCard.Types,
-			Moves:       selectedCard.Moves,
-			Sprite:      selectedCard.Sprite,
-			IsLegendary: false, // Will be set correctly if needed
-			IsMythical:  false,
-			AcquiredAt:  bs.CreatedAt,
+			ID:            len(bc.gameState.Collection), // Assign new ID
+			PokemonID:     selectedCard.CardID,
+			Name:          selectedCard.Name,
+			Level:         1, // Add at level 1
+			XP:            0,
+			BaseHP:        selectedCard.HPMax,
+			BaseAttack:    selectedCard.Attack,
+			BaseDefense:   selectedCard.Defense,
+			BaseSpAttack:  selectedCard.SpAttack,
+			BaseSpDefense: selectedCard.SpDefense,
+			BaseSpeed:     selectedCard.Speed,
+			Types:         selectedCard.Types,
+			Moves:         selectedCard.Moves,
+			Sprite:        selectedCard.Sprite,
+			IsLegendary:   false, // Will be set correctly if needed
+			IsMythical:    false,
+			AcquiredAt:    bs.CreatedAt,
 		}
 
 		bc.gameState.Collection = append(bc.gameState.Collection, newCard)
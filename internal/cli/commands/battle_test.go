@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"testing"
+
+	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/cli/storage"
+	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/pokemon"
+)
+
+// progressionTestState returns a game state with a 5v5 deck of Pokemon at
+// different levels, some of them close to levelling up, whose special stats
+// differ from their physical ones
+func progressionTestState() *storage.GameState {
+	gameState := createTestGameState()
+	for i := range gameState.Collection {
+		card := &gameState.Collection[i]
+		card.Level = []int{1, 2, 5, 49, 50}[i]
+		card.XP = []int{90, 195, 0, 4890, 0}[i]
+		card.BaseSpAttack, card.BaseSpDefense = card.BaseAttack+20, card.BaseDefense+10
+		card.Moves = []pokemon.Move{{Name: "tackle", Power: 40, StaminaCost: 10, Type: "normal"}}
+	}
+	gameState.Deck = []int{0, 1, 2, 3, 4}
+	return gameState
+}
+
+// progressionTestBattle starts a battle from gameState's deck the way the CLI
+// does and finishes it with winner, the Pokemon at hurt deck positions having
+// taken damage
+func progressionTestBattle(t *testing.T, gameState *storage.GameState, mode, winner string, hurt ...int) *battle.BattleState {
+	t.Helper()
+	bc := &BattleCommand{gameState: gameState}
+	playerDeck, err := bc.loadPlayerDeck(mode)
+	if err != nil {
+		t.Fatalf("loadPlayerDeck failed: %v", err)
+	}
+	bs, err := battle.StartBattleWithSeed(0, mode, playerDeck, playerDeck, 1)
+	if err != nil {
+		t.Fatalf("StartBattleWithSeed failed: %v", err)
+	}
+	for _, pos := range hurt {
+		bs.PlayerDeck[pos].HP /= 2
+	}
+	bs.AIDifficulty = battle.DifficultyHard
+	bs.BattleOver, bs.Winner = true, winner
+	return bs
+}
+
+func TestCLIAndAPIProgressIdentically(t *testing.T) {
+	tests := []struct {
+		mode, winner string
+		hurt         []int
+	}{
+		{"1v1", "player", []int{0}},
+		{"1v1", "ai", []int{0}},
+		{"5v5", "player", []int{0, 1, 3, 4}},
+		{"5v5", "draw", []int{1, 2}},
+		{"5v5", "ai", []int{0, 1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.winner, func(t *testing.T) {
+			cli := progressionTestState()
+			bs := progressionTestBattle(t, cli, tt.mode, tt.winner, tt.hurt...)
+
+			// The server's copy of the same cards, whose battle cards carry their
+			// database IDs
+			var server []*database.PlayerCard
+			api := bs.Clone()
+			for pos := range api.PlayerDeck {
+				card := cli.Collection[cli.Deck[pos]]
+				api.PlayerDeck[pos].CardID = 100 + pos
+				server = append(server, &database.PlayerCard{
+					ID: 100 + pos, PokemonName: card.Name, Level: card.Level, XP: card.XP,
+					BaseHP: card.BaseHP, BaseAttack: card.BaseAttack, BaseDefense: card.BaseDefense,
+					BaseSpAttack: card.BaseSpAttack, BaseSpDefense: card.BaseSpDefense, BaseSpeed: card.BaseSpeed,
+				})
+			}
+
+			cliGains := awardBattleXP(cli, bs)
+			apiGains := battle.LevelUpCards(server, battle.CalculateXPForBattle(api))
+			if len(cliGains) != len(apiGains) || len(apiGains) == 0 {
+				t.Fatalf("CLI awarded XP to %d Pokemon, the API to %d", len(cliGains), len(apiGains))
+			}
+			for i, gain := range apiGains {
+				cliGain := cliGains[i].PokemonXPGain
+				cliGain.CardID = gain.CardID
+				if cliGain != gain {
+					t.Errorf("CLI reported %+v, the API %+v", cliGain, gain)
+				}
+			}
+
+			for pos := range api.PlayerDeck {
+				cliCard, serverCard := cli.Collection[cli.Deck[pos]], server[pos]
+				if cliCard.Level != serverCard.Level || cliCard.XP != serverCard.XP {
+					t.Errorf("position %d: CLI level %d with %d XP, API level %d with %d XP",
+						pos, cliCard.Level, cliCard.XP, serverCard.Level, serverCard.XP)
+				}
+				if got, want := cliCard.GetCurrentStats(), serverCard.GetCurrentStats(); got != storage.CardStats(want) {
+					t.Errorf("position %d: CLI stats %+v, API stats %+v", pos, got, want)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
+	"pokemon-cli/internal/pokemon"
 )
 
 // CollectionCommand handles collection-related commands
@@ -276,14 +277,14 @@ func (cc *CollectionCommand) displayPaginated(collection []storage.PlayerCard, f
 }
 
 // displayPokemonTable displays Pokemon in a formatted table
-func (cc *CollectionCommand) displayPokemonTable(pokemon []storage.PlayerCard, startIdx int) {
+func (cc *CollectionCommand) displayPokemonTable(cards []storage.PlayerCard, startIdx int) {
 	// Table header
-	fmt.Printf("%-4s %-15s %-6s %-8s %-6s %-6s %-6s %-6s %-20s\n",
-		"#", "NAME", "LEVEL", "XP", "HP", "ATK", "DEF", "SPD", "TYPES")
-	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%-4s %-15s %-6s %-8s %-6s %-6s %-6s %-6s %-14s %-20s\n",
+		"#", "NAME", "LEVEL", "XP", "HP", "ATK", "DEF", "SPD", "ABILITY", "TYPES")
+	fmt.Println(strings.Repeat("-", 95))
 
 	// Table rows
-	for i, card := range pokemon {
+	for i, card := range cards {
 		stats := card.GetCurrentStats()
 		
 		// Format number
@@ -300,7 +301,7 @@ func (cc *CollectionCommand) displayPokemonTable(pokemon []storage.PlayerCard, s
 		}
 		
 		// Format XP progress
-		xpProgress := fmt.Sprintf("%d/100", card.XP)
+		xpProgress := formatXP(&card)
 		
 		// Format types
 		typeStr := ""
@@ -315,10 +316,10 @@ func (cc *CollectionCommand) displayPokemonTable(pokemon []storage.PlayerCard, s
 			}
 		}
 
-		fmt.Printf("%-4s %-15s %-6d %-8s %-6d %-6d %-6d %-6d %s\n",
+		fmt.Printf("%-4s %-15s %-6d %-8s %-6d %-6d %-6d %-6d %-14s %s\n",
 			num, name, card.Level, xpProgress,
 			stats.HP, stats.Attack, stats.Defense, stats.Speed,
-			typeStr)
+			pokemon.AbilityName(card.Ability()), typeStr)
 	}
 }
 
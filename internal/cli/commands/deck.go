@@ -8,6 +8,7 @@ import (
 
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
+	"pokemon-cli/internal/pokemon"
 )
 
 // DeckCommand handles deck-related commands
@@ -92,7 +93,7 @@ func (dc *DeckCommand) ViewDeck() error {
 		fmt.Printf("%s ", name)
 
 		// Display level
-		fmt.Printf("(Lv %d, XP: %d/100)\n", card.Level, card.XP)
+		fmt.Printf("(Lv %d, XP: %s)\n", card.Level, formatXP(&card))
 
 		// Display types
 		fmt.Print("    Types: ")
@@ -109,8 +110,17 @@ func (dc *DeckCommand) ViewDeck() error {
 		fmt.Println()
 
 		// Display stats
-		fmt.Printf("    Stats: HP: %d | ATK: %d | DEF: %d | SPD: %d | STA: %d\n",
-			stats.HP, stats.Attack, stats.Defense, stats.Speed, stats.Stamina)
+		fmt.Printf("    Stats: HP: %d | ATK: %d | DEF: %d | SP.ATK: %d | SP.DEF: %d | SPD: %d | STA: %d\n",
+			stats.HP, stats.Attack, stats.Defense, stats.SpAttack, stats.SpDefense, stats.Speed, stats.Stamina)
+
+		// Display ability and held item
+		if ability, ok := pokemon.GetAbility(card.Ability()); ok {
+			fmt.Printf("    Ability: %s - %s\n", pokemon.AbilityName(ability.Name), ability.Description)
+		}
+		if card.HeldItem != "" {
+			item, _ := pokemon.GetHeldItem(card.HeldItem)
+			fmt.Printf("    Item: %s - %s\n", pokemon.HeldItemName(card.HeldItem), item.Description)
+		}
 
 		// Display moves
 		fmt.Print("    Moves: ")
@@ -190,12 +200,13 @@ func (dc *DeckCommand) EditDeck() error {
 			{Label: "Add Pokemon", Description: "Add a Pokemon to your deck", Value: "add"},
 			{Label: "Remove Pokemon", Description: "Remove a Pokemon from your deck", Value: "remove"},
 			{Label: "Reorder Pokemon", Description: "Change Pokemon positions in deck", Value: "reorder"},
+			{Label: "Held Items", Description: "Give a held item to a Pokemon or take it back", Value: "items"},
 			{Label: "Save Deck", Description: "Save changes and exit", Value: "save"},
 			{Label: "Cancel", Description: "Discard changes and exit", Value: "cancel"},
 		}
 
 		fmt.Println(dc.renderer.RenderBorderedMenu(options, -1, "DECK EDITOR OPTIONS"))
-		fmt.Print("Enter your choice (1-5): ")
+		fmt.Print("Enter your choice (1-6): ")
 
 		// Get user input
 		if !dc.scanner.Scan() {
@@ -204,7 +215,7 @@ func (dc *DeckCommand) EditDeck() error {
 
 		input := strings.TrimSpace(dc.scanner.Text())
 		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > 5 {
+		if err != nil || choice < 1 || choice > 6 {
 			fmt.Println(ui.Colorize("Invalid choice. Press Enter to continue...", ui.ColorRed))
 			dc.scanner.Scan()
 			continue
@@ -236,9 +247,17 @@ func (dc *DeckCommand) EditDeck() error {
 				dc.scanner.Scan()
 			}
 		case 4:
+			// Held items
+			err := dc.editHeldItem()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				fmt.Println("Press Enter to continue...")
+				dc.scanner.Scan()
+			}
+		case 5:
 			// Save deck - will be handled in task 7.3
 			return dc.saveDeck(originalDeck)
-		case 5:
+		case 6:
 			// Cancel - restore original deck
 			dc.gameState.Deck = originalDeck
 			fmt.Println()
@@ -296,9 +315,97 @@ func (dc *DeckCommand) displayDeckEditor() {
 			}
 		}
 
-		fmt.Printf(" - HP: %d | ATK: %d | DEF: %d | SPD: %d\n",
+		fmt.Printf(" - HP: %d | ATK: %d | DEF: %d | SPD: %d",
 			stats.HP, stats.Attack, stats.Defense, stats.Speed)
+		if card.HeldItem != "" {
+			fmt.Printf(" | Item: %s", pokemon.HeldItemName(card.HeldItem))
+		}
+		fmt.Println()
+	}
+}
+
+// editHeldItem gives a held item from the player's stock to a Pokemon in the
+// deck, or takes its item back
+func (dc *DeckCommand) editHeldItem() error {
+	if len(dc.gameState.Deck) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Colorize("Deck is empty!", ui.ColorYellow))
+		fmt.Println("Press Enter to continue...")
+		dc.scanner.Scan()
+		return nil
 	}
+
+	fmt.Printf("\nSelect Pokemon (1-%d) or 0 to cancel: ", len(dc.gameState.Deck))
+	if !dc.scanner.Scan() {
+		return fmt.Errorf("failed to read input")
+	}
+	choice, err := strconv.Atoi(strings.TrimSpace(dc.scanner.Text()))
+	if err != nil || choice < 0 || choice > len(dc.gameState.Deck) {
+		return fmt.Errorf("invalid choice")
+	}
+	if choice == 0 {
+		return nil
+	}
+	cardIdx := dc.gameState.Deck[choice-1]
+	if cardIdx < 0 || cardIdx >= len(dc.gameState.Collection) {
+		return fmt.Errorf("invalid card")
+	}
+	card := &dc.gameState.Collection[cardIdx]
+
+	// Items in stock, in the order the shop lists them
+	var stock []string
+	for _, item := range pokemon.HeldItems {
+		if dc.gameState.HeldItems[item.Name] > 0 {
+			stock = append(stock, item.Name)
+		}
+	}
+
+	fmt.Println()
+	if card.HeldItem != "" {
+		fmt.Printf("%s holds %s.\n", card.Name, ui.Colorize(pokemon.HeldItemName(card.HeldItem), ui.Bold))
+	} else {
+		fmt.Printf("%s holds no item.\n", card.Name)
+	}
+	if len(stock) == 0 {
+		fmt.Println(ui.Colorize("You have no held items in stock. Buy them in the shop.", ui.ColorYellow))
+	}
+	for i, name := range stock {
+		item, _ := pokemon.GetHeldItem(name)
+		fmt.Printf("  [%d] %s x%d - %s\n", i+1, pokemon.HeldItemName(name), dc.gameState.HeldItems[name], item.Description)
+	}
+	if card.HeldItem != "" {
+		fmt.Println("  [R] Take the item back")
+	}
+	fmt.Print("\nSelect an item, or 0 to cancel: ")
+
+	if !dc.scanner.Scan() {
+		return fmt.Errorf("failed to read input")
+	}
+	input := strings.ToUpper(strings.TrimSpace(dc.scanner.Text()))
+	if input == "R" && card.HeldItem != "" {
+		taken := card.HeldItem
+		dc.gameState.TakeHeldItem(cardIdx)
+		fmt.Println(ui.Colorize(fmt.Sprintf("✓ Took %s back from %s.", pokemon.HeldItemName(taken), card.Name), ui.ColorGreen))
+		fmt.Println("Press Enter to continue...")
+		dc.scanner.Scan()
+		return nil
+	}
+
+	itemChoice, err := strconv.Atoi(input)
+	if err != nil || itemChoice < 0 || itemChoice > len(stock) {
+		return fmt.Errorf("invalid choice")
+	}
+	if itemChoice == 0 {
+		return nil
+	}
+	if err := dc.gameState.GiveHeldItem(cardIdx, stock[itemChoice-1]); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.Colorize(fmt.Sprintf("✓ %s now holds %s!", card.Name, pokemon.HeldItemName(card.HeldItem)), ui.ColorGreen))
+	fmt.Println("Press Enter to continue...")
+	dc.scanner.Scan()
+	return nil
 }
 
 // addPokemonToDeck adds a Pokemon to the deck
@@ -3,10 +3,12 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
+	"pokemon-cli/pkg/rng"
 )
 
 // CommandHandler handles routing of commands to appropriate handlers
@@ -22,6 +24,7 @@ type CommandHandler struct {
 	shopCmd       *ShopCommand
 	statsCmd      *StatsCommand
 	settingsCmd   *SettingsCommand
+	replayCmd     *ReplayCommand
 }
 
 // NewCommandHandler creates a new command handler with all sub-handlers
@@ -36,12 +39,13 @@ func NewCommandHandler(gameState *storage.GameState, renderer *ui.Renderer, scan
 		shopCmd:       NewShopCommand(gameState, renderer, scanner),
 		statsCmd:      NewStatsCommand(gameState, renderer, scanner),
 		settingsCmd:   NewSettingsCommand(gameState, renderer, scanner),
+		replayCmd:     NewReplayCommand(gameState, renderer, scanner),
 	}
 }
 
 // HandleCommand routes commands to appropriate handlers
-// Supports commands: battle, collection, deck, shop, stats, save, help, quit
-// Also supports aliases: b, c, d, s, st, h, q
+// Supports commands: battle, collection, deck, shop, stats, replay, save, help, quit
+// Also supports aliases: b, c, d, s, st, r, h, q
 func (ch *CommandHandler) HandleCommand(cmd string, args []string) error {
 	// Normalize command to lowercase
 	cmd = strings.ToLower(strings.TrimSpace(cmd))
@@ -49,7 +53,11 @@ func (ch *CommandHandler) HandleCommand(cmd string, args []string) error {
 	// Route to appropriate handler
 	switch cmd {
 	case "battle", "b":
-		return ch.battleCmd.StartBattle()
+		opts, err := parseBattleOptions(args)
+		if err != nil {
+			return err
+		}
+		return ch.battleCmd.StartBattleWithOptions(opts)
 
 	case "collection", "c":
 		if len(args) > 0 && args[0] == "filter" {
@@ -70,6 +78,9 @@ func (ch *CommandHandler) HandleCommand(cmd string, args []string) error {
 	case "stats", "st":
 		return ch.statsCmd.ViewStats()
 
+	case "replay", "r":
+		return ch.replayCmd.ViewReplay(args)
+
 	case "settings", "config":
 		return ch.settingsCmd.ViewSettings()
 
@@ -90,6 +101,33 @@ func (ch *CommandHandler) HandleCommand(cmd string, args []string) error {
 	}
 }
 
+// parseBattleOptions parses the battle command's flags: --seed <n> and --opponent-bot <path>
+func parseBattleOptions(args []string) (BattleOptions, error) {
+	opts := BattleOptions{Seed: rng.NewSeed()}
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if i+1 >= len(args) {
+			return opts, fmt.Errorf("%s needs a value", flag)
+		}
+		value := args[i+1]
+		i++
+
+		switch flag {
+		case "--seed":
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return opts, fmt.Errorf("invalid seed: %s", value)
+			}
+			opts.Seed = seed
+		case "--opponent-bot":
+			opts.OpponentBot = value
+		default:
+			return opts, fmt.Errorf("unknown battle option: %s", flag)
+		}
+	}
+	return opts, nil
+}
+
 // handleSave manually saves the game state
 func (ch *CommandHandler) handleSave() error {
 	fmt.Println()
@@ -183,8 +221,8 @@ func (ch *CommandHandler) ShowHelp() error {
 				{
 					Name:        "battle",
 					Aliases:     "b",
-					Description: "Start a battle (1v1 or 5v5 mode)",
-					Usage:       "battle",
+					Description: "Start a battle (1v1 or 5v5 mode), optionally from a fixed seed or against a bot program",
+					Usage:       "battle [--seed <n>] [--opponent-bot <path>]",
 				},
 				{
 					Name:        "collection",
@@ -216,6 +254,12 @@ func (ch *CommandHandler) ShowHelp() error {
 					Description: "View your battle statistics and history",
 					Usage:       "stats",
 				},
+				{
+					Name:        "replay",
+					Aliases:     "r",
+					Description: "Step through a recorded battle turn by turn",
+					Usage:       "replay [file]",
+				},
 			},
 		},
 		{
@@ -308,6 +352,7 @@ func (ch *CommandHandler) ShowHelp() error {
 		{"collection", "Browse your Pokemon with filtering options"},
 		{"shop", "Buy new Pokemon with your earned coins"},
 		{"stats", "Check your win rate and battle history"},
+		{"replay", "Watch one of your recent battles again"},
 		{"save", "Manually save your progress (auto-saves after battles)"},
 	}
 
@@ -240,3 +240,20 @@ func createTestGameState() *storage.GameState {
 		Version:       "0.1.0-test",
 	}
 }
+
+// TestParseBattleOptions tests the battle command's flags
+func TestParseBattleOptions(t *testing.T) {
+	opts, err := parseBattleOptions([]string{"--opponent-bot", "./mybot", "--seed", "42"})
+	if err != nil {
+		t.Fatalf("parseBattleOptions failed: %v", err)
+	}
+	if opts.Seed != 42 || opts.OpponentBot != "./mybot" {
+		t.Errorf("got %+v, want seed 42 against ./mybot", opts)
+	}
+
+	for _, args := range [][]string{{"--seed"}, {"--seed", "many"}, {"--speed", "3"}} {
+		if _, err := parseBattleOptions(args); err == nil {
+			t.Errorf("parseBattleOptions(%q) succeeded, want an error", args)
+		}
+	}
+}
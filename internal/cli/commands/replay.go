@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/cli/storage"
+	"pokemon-cli/internal/cli/ui"
+)
+
+// maxReplaysListed is how many recent replays the replay picker shows
+const maxReplaysListed = 10
+
+// ReplayCommand handles playback of recorded battles
+type ReplayCommand struct {
+	gameState *storage.GameState
+	renderer  *ui.Renderer
+	scanner   *bufio.Scanner
+}
+
+// NewReplayCommand creates a new replay command handler
+func NewReplayCommand(gameState *storage.GameState, renderer *ui.Renderer, scanner *bufio.Scanner) *ReplayCommand {
+	return &ReplayCommand{
+		gameState: gameState,
+		renderer:  renderer,
+		scanner:   scanner,
+	}
+}
+
+// ViewReplay plays back the replay named in args, or lets the player pick a recent one
+func (rc *ReplayCommand) ViewReplay(args []string) error {
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	} else {
+		picked, err := rc.pickReplay()
+		if err != nil || picked == "" {
+			return err
+		}
+		name = picked
+	}
+
+	data, err := storage.LoadReplay(name)
+	if err != nil {
+		return err
+	}
+
+	replay, err := battle.DecodeReplay(data)
+	if err != nil {
+		return err
+	}
+
+	frames, err := replay.Frames()
+	if err != nil {
+		return fmt.Errorf("cannot play replay: %w", err)
+	}
+
+	return rc.playFrames(replay, frames)
+}
+
+// pickReplay lists recent replays and returns the chosen file name, or "" if cancelled
+func (rc *ReplayCommand) pickReplay() (string, error) {
+	replays, err := storage.ListReplays()
+	if err != nil {
+		return "", err
+	}
+
+	if len(replays) == 0 {
+		fmt.Println("No replays recorded yet. Every battle you finish is saved as a replay.")
+		return "", nil
+	}
+
+	if len(replays) > maxReplaysListed {
+		replays = replays[:maxReplaysListed]
+	}
+
+	rc.renderer.Clear()
+	fmt.Println(ui.Colorize("RECENT REPLAYS", ui.Bold+ui.ColorBrightCyan))
+	fmt.Println(strings.Repeat("─", 60))
+	for i, replay := range replays {
+		fmt.Printf("  [%d] %s  %s\n", i+1, replay.ModTime.Format("2006-01-02 15:04"), replay.Name)
+	}
+	fmt.Println()
+	fmt.Printf("Select replay (1-%d) or 0 to cancel: ", len(replays))
+
+	for {
+		if !rc.scanner.Scan() {
+			return "", fmt.Errorf("failed to read input")
+		}
+
+		choice, err := strconv.Atoi(strings.TrimSpace(rc.scanner.Text()))
+		if err != nil || choice < 0 || choice > len(replays) {
+			fmt.Printf("Invalid choice. Enter 0-%d: ", len(replays))
+			continue
+		}
+
+		if choice == 0 {
+			return "", nil
+		}
+		return replays[choice-1].Name, nil
+	}
+}
+
+// playFrames steps through replay frames with forward/back/jump controls
+func (rc *ReplayCommand) playFrames(replay *battle.Replay, frames []battle.ReplayFrame) error {
+	idx := 0
+	message := ""
+
+	for {
+		frame := frames[idx]
+
+		rc.renderer.Clear()
+		fmt.Println(rc.renderer.RenderBattleScreen(frame.State))
+		fmt.Println()
+
+		fmt.Println(ui.Colorize(fmt.Sprintf("REPLAY  %s  seed %d  |  step %d/%d  |  turn %d",
+			replay.Mode, replay.Seed, idx, len(frames)-1, frame.State.TurnNumber), ui.Bold+ui.ColorBrightCyan))
+		fmt.Println(rc.renderer.RenderBattleEvents(frame.Events, 10))
+
+		if idx == len(frames)-1 && replay.Winner != "" {
+			fmt.Printf("Battle over. Winner: %s\n", replay.Winner)
+		}
+		if message != "" {
+			fmt.Println(ui.Colorize(message, ui.ColorYellow))
+			message = ""
+		}
+
+		fmt.Print("[n]ext  [p]revious  [j <turn>] jump to turn  [q]uit: ")
+		if !rc.scanner.Scan() {
+			return fmt.Errorf("failed to read input")
+		}
+
+		fields := strings.Fields(strings.ToLower(rc.scanner.Text()))
+		command := "n"
+		if len(fields) > 0 {
+			command = fields[0]
+		}
+
+		switch command {
+		case "n", "next":
+			if idx < len(frames)-1 {
+				idx++
+			} else {
+				message = "Already at the end of the battle."
+			}
+		case "p", "prev", "previous":
+			if idx > 0 {
+				idx--
+			} else {
+				message = "Already at the start of the battle."
+			}
+		case "j", "jump":
+			if len(fields) < 2 {
+				message = "Usage: j <turn>"
+				continue
+			}
+			target, err := strconv.Atoi(fields[1])
+			if err != nil {
+				message = "Turn must be a number."
+				continue
+			}
+			found := findTurnFrame(frames, target)
+			if found < 0 {
+				message = fmt.Sprintf("Turn %d was not played in this battle.", target)
+				continue
+			}
+			idx = found
+		case "q", "quit":
+			return nil
+		default:
+			message = "Unknown control. Use n, p, j <turn> or q."
+		}
+	}
+}
+
+// findTurnFrame returns the index of the first frame showing the start of turn, or -1
+func findTurnFrame(frames []battle.ReplayFrame, turn int) int {
+	for i, frame := range frames {
+		if frame.State.TurnNumber == turn {
+			return i
+		}
+	}
+	return -1
+}
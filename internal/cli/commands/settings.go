@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"pokemon-cli/internal/battle"
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
 )
@@ -74,6 +75,38 @@ func (sc *SettingsCommand) ViewSettings() error {
 		fmt.Println("    Adjust animation and text display speed")
 		fmt.Println()
 
+		// Speed Turn Order
+		speedOrderStatus := ui.Colorize("OFF", ui.ColorRed)
+		if sc.gameState.Settings.SpeedOrder {
+			speedOrderStatus = ui.Colorize("ON", ui.ColorGreen)
+		}
+		fmt.Printf("  Speed Turn Order: %s\n", speedOrderStatus)
+		fmt.Println("    The faster Pokemon attacks first, and a knockout stops the slower attack")
+		fmt.Println()
+
+		// Damage Rules
+		damageRules := ui.Colorize("CLASSIC", ui.ColorYellow)
+		if sc.gameState.Settings.DamageRules == battle.DamageModern {
+			damageRules = ui.Colorize("MODERN", ui.ColorCyan)
+		}
+		fmt.Printf("  Damage Rules: %s\n", damageRules)
+		fmt.Println("    Modern rules add move accuracy, critical hits and same-type attack bonus")
+		fmt.Println()
+
+		// Random Weather
+		randomWeatherStatus := ui.Colorize("OFF", ui.ColorRed)
+		if sc.gameState.Settings.RandomWeather {
+			randomWeatherStatus = ui.Colorize("ON", ui.ColorGreen)
+		}
+		fmt.Printf("  Random Weather: %s\n", randomWeatherStatus)
+		fmt.Println("    Battles start with a random weather or terrain that lasts the whole battle")
+		fmt.Println()
+
+		// Item Limit
+		fmt.Printf("  Item Limit: %s\n", ui.Colorize(itemLimitText(sc.gameState.Settings.ItemLimit), ui.ColorYellow))
+		fmt.Println("    How many items from your bag you may use in a battle")
+		fmt.Println()
+
 		fmt.Println(strings.Repeat("─", 80))
 		fmt.Println()
 
@@ -81,6 +114,10 @@ func (sc *SettingsCommand) ViewSettings() error {
 		options := []ui.MenuOption{
 			{Label: "Toggle Quick Battle", Description: "Enable/disable quick battle mode", Value: "quick"},
 			{Label: "Change Battle Speed", Description: "Set battle speed (slow/normal/fast)", Value: "speed"},
+			{Label: "Toggle Speed Turn Order", Description: "Enable/disable the speed order rule for new battles", Value: "speed_order"},
+			{Label: "Toggle Damage Rules", Description: "Switch between classic and modern damage for new battles", Value: "damage"},
+			{Label: "Toggle Random Weather", Description: "Enable/disable a random weather or terrain for new battles", Value: "weather"},
+			{Label: "Change Item Limit", Description: "Cycle how many bag items new battles allow", Value: "item_limit"},
 			{Label: "Export Save", Description: "Export save file to a location", Value: "export"},
 			{Label: "Import Save", Description: "Import save file from a location", Value: "import"},
 			{Label: "Save & Exit", Description: "Save settings and return to menu", Value: "save"},
@@ -88,7 +125,7 @@ func (sc *SettingsCommand) ViewSettings() error {
 		}
 
 		fmt.Println(sc.renderer.RenderBorderedMenu(options, -1, "SETTINGS OPTIONS"))
-		fmt.Print("Enter your choice (1-6): ")
+		fmt.Print("Enter your choice (1-10): ")
 
 		// Get user input
 		if !sc.scanner.Scan() {
@@ -97,7 +134,7 @@ func (sc *SettingsCommand) ViewSettings() error {
 
 		input := strings.TrimSpace(sc.scanner.Text())
 		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > 6 {
+		if err != nil || choice < 1 || choice > 10 {
 			fmt.Println(ui.Colorize("Invalid choice. Press Enter to continue...", ui.ColorRed))
 			sc.scanner.Scan()
 			continue
@@ -126,6 +163,50 @@ func (sc *SettingsCommand) ViewSettings() error {
 			}
 
 		case 3:
+			// Toggle speed turn order
+			sc.gameState.Settings.SpeedOrder = !sc.gameState.Settings.SpeedOrder
+			status := "disabled"
+			if sc.gameState.Settings.SpeedOrder {
+				status = "enabled"
+			}
+			fmt.Println()
+			fmt.Println(ui.Colorize(fmt.Sprintf("Speed Turn Order %s!", status), ui.ColorGreen))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+
+		case 4:
+			// Toggle damage rules
+			if sc.gameState.Settings.DamageRules == battle.DamageModern {
+				sc.gameState.Settings.DamageRules = battle.DamageClassic
+			} else {
+				sc.gameState.Settings.DamageRules = battle.DamageModern
+			}
+			fmt.Println()
+			fmt.Println(ui.Colorize(fmt.Sprintf("Damage Rules set to %s!", strings.ToUpper(sc.gameState.Settings.DamageRules)), ui.ColorGreen))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+
+		case 5:
+			// Toggle random weather
+			sc.gameState.Settings.RandomWeather = !sc.gameState.Settings.RandomWeather
+			status := "disabled"
+			if sc.gameState.Settings.RandomWeather {
+				status = "enabled"
+			}
+			fmt.Println()
+			fmt.Println(ui.Colorize(fmt.Sprintf("Random Weather %s!", status), ui.ColorGreen))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+
+		case 6:
+			// Cycle the item limit
+			sc.gameState.Settings.ItemLimit = nextItemLimit(sc.gameState.Settings.ItemLimit)
+			fmt.Println()
+			fmt.Println(ui.Colorize(fmt.Sprintf("Item Limit set to %s!", itemLimitText(sc.gameState.Settings.ItemLimit)), ui.ColorGreen))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+
+		case 7:
 			// Export save
 			err := sc.exportSave()
 			if err != nil {
@@ -134,7 +215,7 @@ func (sc *SettingsCommand) ViewSettings() error {
 				sc.scanner.Scan()
 			}
 
-		case 4:
+		case 8:
 			// Import save
 			err := sc.importSave()
 			if err != nil {
@@ -143,7 +224,7 @@ func (sc *SettingsCommand) ViewSettings() error {
 				sc.scanner.Scan()
 			}
 
-		case 5:
+		case 9:
 			// Save and exit
 			err := storage.SaveGameState(sc.gameState)
 			if err != nil {
@@ -160,7 +241,7 @@ func (sc *SettingsCommand) ViewSettings() error {
 			sc.scanner.Scan()
 			return nil
 
-		case 6:
+		case 10:
 			// Cancel
 			fmt.Println()
 			fmt.Println(ui.Colorize("Settings changes discarded.", ui.ColorYellow))
@@ -171,6 +252,31 @@ func (sc *SettingsCommand) ViewSettings() error {
 	}
 }
 
+// itemLimits are the item limits the settings cycle through: the mode's
+// default, none, then a fixed number per battle
+var itemLimits = []int{0, battle.NoItems, 1, 2, 3, 5}
+
+// nextItemLimit returns the item limit that comes after limit in itemLimits
+func nextItemLimit(limit int) int {
+	for i, l := range itemLimits {
+		if l == limit {
+			return itemLimits[(i+1)%len(itemLimits)]
+		}
+	}
+	return itemLimits[0]
+}
+
+// itemLimitText is how an item limit is shown in the settings
+func itemLimitText(limit int) string {
+	switch limit {
+	case 0:
+		return "MODE DEFAULT"
+	case battle.NoItems:
+		return "NO ITEMS"
+	}
+	return fmt.Sprintf("%d PER BATTLE", limit)
+}
+
 // changeBattleSpeed allows the user to change battle speed
 func (sc *SettingsCommand) changeBattleSpeed() error {
 	fmt.Println()
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"bufio"
+	"cmp"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -65,19 +66,21 @@ func (sc *ShopCommand) GenerateShopInventory() error {
 
 		// Create shop item
 		shopItem := storage.ShopItem{
-			PokemonID:   pokemonEntry.ID,
-			Name:        pokemonEntry.Name,
-			Types:       pokemonEntry.Types,
-			BaseHP:      pokemonEntry.HP,
-			BaseAttack:  pokemonEntry.Attack,
-			BaseDefense: pokemonEntry.Defense,
-			BaseSpeed:   pokemonEntry.Speed,
-			Moves:       pokemonEntry.Moves,
-			Sprite:      pokemonEntry.Sprite,
-			Price:       price,
-			Rarity:      rarity,
-			IsLegendary: pokemonEntry.IsLegendary,
-			IsMythical:  pokemonEntry.IsMythical,
+			PokemonID:     pokemonEntry.ID,
+			Name:          pokemonEntry.Name,
+			Types:         pokemonEntry.Types,
+			BaseHP:        pokemonEntry.HP,
+			BaseAttack:    pokemonEntry.Attack,
+			BaseDefense:   pokemonEntry.Defense,
+			BaseSpAttack:  pokemonEntry.SpAttack,
+			BaseSpDefense: pokemonEntry.SpDefense,
+			BaseSpeed:     pokemonEntry.Speed,
+			Moves:         pokemonEntry.Moves,
+			Sprite:        pokemonEntry.Sprite,
+			Price:         price,
+			Rarity:        rarity,
+			IsLegendary:   pokemonEntry.IsLegendary,
+			IsMythical:    pokemonEntry.IsMythical,
 		}
 
 		inventory = append(inventory, shopItem)
@@ -147,6 +150,8 @@ func (sc *ShopCommand) ViewShop() error {
 		// Display options
 		fmt.Println("Options:")
 		fmt.Println("  [1-" + strconv.Itoa(len(sc.gameState.ShopState.Inventory)) + "] Buy Pokemon by number")
+		fmt.Println("  [I] Held items for your Pokemon")
+		fmt.Println("  [B] Battle items for your bag")
 		fmt.Println("  [R] Refresh shop (costs 50 coins)")
 		fmt.Println("  [Q] Back to menu")
 		fmt.Println()
@@ -162,6 +167,14 @@ func (sc *ShopCommand) ViewShop() error {
 		switch input {
 		case "Q":
 			return nil
+		case "I":
+			if err := sc.ViewHeldItems(); err != nil {
+				return err
+			}
+		case "B":
+			if err := sc.ViewBattleItems(); err != nil {
+				return err
+			}
 		case "R":
 			// Manual refresh for 50 coins
 			if sc.gameState.Coins < 50 {
@@ -320,8 +333,9 @@ func (sc *ShopCommand) BuyPokemon(index int) error {
 	}
 	fmt.Println(typeStr)
 	
-	fmt.Printf("Stats: HP=%d, ATK=%d, DEF=%d, SPD=%d\n",
-		item.BaseHP, item.BaseAttack, item.BaseDefense, item.BaseSpeed)
+	fmt.Printf("Stats: HP=%d, ATK=%d, DEF=%d, SP.ATK=%d, SP.DEF=%d, SPD=%d\n",
+		item.BaseHP, item.BaseAttack, item.BaseDefense, cmp.Or(item.BaseSpAttack, item.BaseAttack),
+		cmp.Or(item.BaseSpDefense, item.BaseDefense), item.BaseSpeed)
 	fmt.Printf("Rarity: %s\n", strings.ToUpper(item.Rarity))
 	fmt.Println()
 
@@ -346,21 +360,23 @@ func (sc *ShopCommand) BuyPokemon(index int) error {
 
 	// Add Pokemon to collection at level 1
 	newCard := storage.PlayerCard{
-		ID:          sc.getNextCardID(),
-		PokemonID:   item.PokemonID,
-		Name:        item.Name,
-		Level:       1,
-		XP:          0,
-		BaseHP:      item.BaseHP,
-		BaseAttack:  item.BaseAttack,
-		BaseDefense: item.BaseDefense,
-		BaseSpeed:   item.BaseSpeed,
-		Types:       item.Types,
-		Moves:       item.Moves,
-		Sprite:      item.Sprite,
-		IsLegendary: item.IsLegendary,
-		IsMythical:  item.IsMythical,
-		AcquiredAt:  time.Now(),
+		ID:            sc.getNextCardID(),
+		PokemonID:     item.PokemonID,
+		Name:          item.Name,
+		Level:         1,
+		XP:            0,
+		BaseHP:        item.BaseHP,
+		BaseAttack:    item.BaseAttack,
+		BaseDefense:   item.BaseDefense,
+		BaseSpAttack:  item.BaseSpAttack,
+		BaseSpDefense: item.BaseSpDefense,
+		BaseSpeed:     item.BaseSpeed,
+		Types:         item.Types,
+		Moves:         item.Moves,
+		Sprite:        item.Sprite,
+		IsLegendary:   item.IsLegendary,
+		IsMythical:    item.IsMythical,
+		AcquiredAt:    time.Now(),
 	}
 
 	sc.gameState.Collection = append(sc.gameState.Collection, newCard)
@@ -386,6 +402,196 @@ func (sc *ShopCommand) BuyPokemon(index int) error {
 	return nil
 }
 
+// ViewHeldItems displays the held items for sale and lets the player buy them.
+// Bought items go to the player's stock, to be given to a Pokemon from the deck editor.
+func (sc *ShopCommand) ViewHeldItems() error {
+	for {
+		sc.renderer.Clear()
+
+		fmt.Println(ui.RenderLogo())
+		fmt.Println()
+		fmt.Println(strings.Repeat("═", 80))
+		fmt.Println(ui.Colorize("HELD ITEMS", ui.Bold+ui.ColorBrightCyan))
+		fmt.Println(strings.Repeat("═", 80))
+		fmt.Println()
+
+		coinsText := fmt.Sprintf("Your Coins: %d", sc.gameState.Coins)
+		if sc.renderer.ColorSupport {
+			coinsText = ui.Colorize(coinsText, ui.Bold+ui.ColorYellow)
+		}
+		fmt.Println(coinsText)
+		fmt.Println()
+
+		fmt.Printf("%-4s %-16s %-8s %-6s %s\n", "#", "ITEM", "PRICE", "OWNED", "EFFECT")
+		fmt.Println(strings.Repeat("-", 80))
+		for i, item := range pokemon.HeldItems {
+			owned := ""
+			if count := sc.gameState.HeldItems[item.Name]; count > 0 {
+				owned = fmt.Sprintf("x%d", count)
+			}
+			fmt.Printf("%-4s %-16s %-8d %-6s %s\n", fmt.Sprintf("%d.", i+1), pokemon.HeldItemName(item.Name), item.Price, owned, item.Description)
+		}
+
+		fmt.Println()
+		fmt.Println("Give items to your Pokemon from the deck editor. A Pokemon holds one item at a time.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  [1-" + strconv.Itoa(len(pokemon.HeldItems)) + "] Buy item by number")
+		fmt.Println("  [Q] Back to shop")
+		fmt.Println()
+		fmt.Print("Enter your choice: ")
+
+		if !sc.scanner.Scan() {
+			return fmt.Errorf("failed to read input")
+		}
+		input := strings.ToUpper(strings.TrimSpace(sc.scanner.Text()))
+		if input == "Q" {
+			return nil
+		}
+
+		choice, err := strconv.Atoi(input)
+		if err != nil || choice < 1 || choice > len(pokemon.HeldItems) {
+			fmt.Println()
+			fmt.Println(ui.Colorize("Invalid choice. Please try again.", ui.ColorRed))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+			continue
+		}
+
+		if err := sc.BuyHeldItem(pokemon.HeldItems[choice-1]); err != nil {
+			fmt.Println()
+			fmt.Println(ui.Colorize(fmt.Sprintf("Error: %v", err), ui.ColorRed))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+		}
+	}
+}
+
+// BuyHeldItem handles the purchase of a held item
+func (sc *ShopCommand) BuyHeldItem(item pokemon.HeldItem) error {
+	if sc.gameState.Coins < item.Price {
+		return fmt.Errorf("not enough coins! You need %d coins but only have %d", item.Price, sc.gameState.Coins)
+	}
+
+	fmt.Println()
+	if !ui.ConfirmationPrompt(sc.scanner, fmt.Sprintf("Buy %s for %d coins?", pokemon.HeldItemName(item.Name), item.Price), true) {
+		fmt.Println(ui.Colorize("Purchase cancelled.", ui.ColorYellow))
+		time.Sleep(1 * time.Second)
+		return nil
+	}
+
+	sc.gameState.Coins -= item.Price
+	sc.gameState.AddHeldItem(item.Name)
+
+	if err := storage.SaveGameState(sc.gameState); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Colorize(fmt.Sprintf("%s bought! Give it to a Pokemon from the deck editor.", pokemon.HeldItemName(item.Name)), ui.ColorGreen))
+	fmt.Printf("Remaining coins: %s\n", ui.Colorize(fmt.Sprintf("%d", sc.gameState.Coins), ui.ColorYellow))
+	fmt.Println("Press Enter to continue...")
+	sc.scanner.Scan()
+
+	return nil
+}
+
+// ViewBattleItems displays the battle items for sale and lets the player buy
+// them. Bought items go to the player's bag, to be used in battle.
+func (sc *ShopCommand) ViewBattleItems() error {
+	for {
+		sc.renderer.Clear()
+
+		fmt.Println(ui.RenderLogo())
+		fmt.Println()
+		fmt.Println(strings.Repeat("═", 80))
+		fmt.Println(ui.Colorize("BATTLE ITEMS", ui.Bold+ui.ColorBrightCyan))
+		fmt.Println(strings.Repeat("═", 80))
+		fmt.Println()
+
+		coinsText := fmt.Sprintf("Your Coins: %d", sc.gameState.Coins)
+		if sc.renderer.ColorSupport {
+			coinsText = ui.Colorize(coinsText, ui.Bold+ui.ColorYellow)
+		}
+		fmt.Println(coinsText)
+		fmt.Println()
+
+		fmt.Printf("%-4s %-16s %-8s %-6s %s\n", "#", "ITEM", "PRICE", "IN BAG", "EFFECT")
+		fmt.Println(strings.Repeat("-", 80))
+		for i, item := range pokemon.BattleItems {
+			owned := ""
+			if count := sc.gameState.Bag[item.Name]; count > 0 {
+				owned = fmt.Sprintf("x%d", count)
+			}
+			fmt.Printf("%-4s %-16s %-8d %-6s %s\n", fmt.Sprintf("%d.", i+1), pokemon.HeldItemName(item.Name), item.Price, owned, item.Description)
+		}
+
+		fmt.Println()
+		fmt.Println("Use items in battle with the Item action. Using one takes up your turn.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fmt.Println("  [1-" + strconv.Itoa(len(pokemon.BattleItems)) + "] Buy item by number")
+		fmt.Println("  [Q] Back to shop")
+		fmt.Println()
+		fmt.Print("Enter your choice: ")
+
+		if !sc.scanner.Scan() {
+			return fmt.Errorf("failed to read input")
+		}
+		input := strings.ToUpper(strings.TrimSpace(sc.scanner.Text()))
+		if input == "Q" {
+			return nil
+		}
+
+		choice, err := strconv.Atoi(input)
+		if err != nil || choice < 1 || choice > len(pokemon.BattleItems) {
+			fmt.Println()
+			fmt.Println(ui.Colorize("Invalid choice. Please try again.", ui.ColorRed))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+			continue
+		}
+
+		if err := sc.BuyBattleItem(pokemon.BattleItems[choice-1]); err != nil {
+			fmt.Println()
+			fmt.Println(ui.Colorize(fmt.Sprintf("Error: %v", err), ui.ColorRed))
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+		}
+	}
+}
+
+// BuyBattleItem handles the purchase of a battle item
+func (sc *ShopCommand) BuyBattleItem(item pokemon.BattleItem) error {
+	if sc.gameState.Coins < item.Price {
+		return fmt.Errorf("not enough coins! You need %d coins but only have %d", item.Price, sc.gameState.Coins)
+	}
+
+	fmt.Println()
+	if !ui.ConfirmationPrompt(sc.scanner, fmt.Sprintf("Buy %s for %d coins?", pokemon.HeldItemName(item.Name), item.Price), true) {
+		fmt.Println(ui.Colorize("Purchase cancelled.", ui.ColorYellow))
+		time.Sleep(1 * time.Second)
+		return nil
+	}
+
+	if err := sc.gameState.AddBattleItems(item.Name, 1); err != nil {
+		return err
+	}
+	sc.gameState.Coins -= item.Price
+
+	if err := storage.SaveGameState(sc.gameState); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Colorize(fmt.Sprintf("%s added to your bag!", pokemon.HeldItemName(item.Name)), ui.ColorGreen))
+	fmt.Printf("Remaining coins: %s\n", ui.Colorize(fmt.Sprintf("%d", sc.gameState.Coins), ui.ColorYellow))
+	fmt.Println("Press Enter to continue...")
+	sc.scanner.Scan()
+
+	return nil
+}
+
 // countOwnedPokemon counts how many of a specific Pokemon the player owns
 func (sc *ShopCommand) countOwnedPokemon(pokemonID int) int {
 	count := 0
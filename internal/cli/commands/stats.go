@@ -2,10 +2,13 @@ package commands
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"pokemon-cli/internal/battle"
 	"pokemon-cli/internal/cli/storage"
 	"pokemon-cli/internal/cli/ui"
 )
@@ -111,10 +114,113 @@ func (sc *StatsCommand) ViewStats() error {
 
 	fmt.Println(strings.Repeat("═", 80))
 	fmt.Println()
-	fmt.Println("Press Enter to continue...")
-	sc.scanner.Scan()
 
-	return nil
+	for {
+		if len(sc.gameState.BattleHistory) == 0 {
+			fmt.Println("Press Enter to continue...")
+			sc.scanner.Scan()
+			return nil
+		}
+
+		fmt.Print("Enter a battle # to view its timeline, or press Enter to continue: ")
+		if !sc.scanner.Scan() {
+			return nil
+		}
+
+		input := strings.TrimSpace(sc.scanner.Text())
+		if input == "" {
+			return nil
+		}
+
+		record, ok := sc.recentBattle(input)
+		if !ok {
+			fmt.Println(ui.Colorize("Invalid battle number.", ui.ColorRed))
+			continue
+		}
+
+		sc.displayBattleTimeline(record)
+	}
+}
+
+// recentBattle returns the battle shown under number input in the recent history table
+func (sc *StatsCommand) recentBattle(input string) (storage.BattleRecord, bool) {
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > 10 || num > len(sc.gameState.BattleHistory) {
+		return storage.BattleRecord{}, false
+	}
+
+	return sc.gameState.BattleHistory[len(sc.gameState.BattleHistory)-num], true
+}
+
+// displayBattleTimeline prints the turn-by-turn timeline saved for a battle
+func (sc *StatsCommand) displayBattleTimeline(record storage.BattleRecord) {
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 80))
+	fmt.Println(ui.Colorize(fmt.Sprintf("BATTLE TIMELINE - %s %s", strings.ToUpper(record.Mode), sc.formatResult(record.Result)), ui.Bold))
+	fmt.Println(strings.Repeat("─", 80))
+	fmt.Println()
+
+	if record.ID == "" {
+		fmt.Println(ui.Colorize("No timeline was recorded for this battle.", ui.ColorYellow))
+		fmt.Println()
+		return
+	}
+
+	data, err := storage.LoadBattleTimeline(record.ID)
+	if err != nil {
+		fmt.Println(ui.Colorize("No timeline was recorded for this battle.", ui.ColorYellow))
+		fmt.Println()
+		return
+	}
+
+	var turns []battle.TurnRecord
+	if err := json.Unmarshal(data, &turns); err != nil {
+		fmt.Printf("Failed to read battle timeline: %v\n", err)
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("%-5s %-12s %-12s %-18s %-18s %-6s %-6s\n", "TURN", "YOURS", "FOE", "YOUR MOVE", "FOE MOVE", "DEALT", "TAKEN")
+	fmt.Println(strings.Repeat("-", 80))
+
+	for _, turn := range turns {
+		fmt.Printf("%-5d %-12s %-12s %-18s %-18s %-6d %-6d\n",
+			turn.Turn,
+			truncate(turn.PlayerPokemon, 12),
+			truncate(turn.AIPokemon, 12),
+			truncate(describeTurnMove(turn.PlayerMove, turn.PlayerMoveName), 18),
+			truncate(describeTurnMove(turn.AIMove, turn.AIMoveName), 18),
+			turn.DamageDealt,
+			turn.DamageTaken,
+		)
+		if turn.PlayerKnockedOut {
+			fmt.Println(ui.Colorize(fmt.Sprintf("      %s was knocked out!", turn.PlayerPokemon), ui.ColorRed))
+		}
+		if turn.AIKnockedOut {
+			fmt.Println(ui.Colorize(fmt.Sprintf("      %s was knocked out!", turn.AIPokemon), ui.ColorGreen))
+		}
+	}
+
+	fmt.Println()
+}
+
+// describeTurnMove names a move for the timeline, preferring the attack's name
+func describeTurnMove(move, moveName string) string {
+	if moveName != "" {
+		return moveName
+	}
+	if move == "" {
+		return "-"
+	}
+	return move
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
 }
 
 // formatWinRate formats the win rate with color coding
@@ -153,7 +259,7 @@ func (sc *StatsCommand) displayBattleHistory() {
 	recentBattles := sc.gameState.BattleHistory[startIdx:]
 
 	// Display table header
-	fmt.Printf("%-4s %-20s %-6s %-10s %-12s", "#", "DATE", "MODE", "RESULT", "COINS")
+	fmt.Printf("%-4s %-20s %-6s %-8s %-10s %-12s", "#", "DATE", "MODE", "AI", "RESULT", "COINS")
 	if sc.hasDurationData() {
 		fmt.Printf(" %-10s", "DURATION")
 	}
@@ -171,6 +277,12 @@ func (sc *StatsCommand) displayBattleHistory() {
 		// Format mode
 		modeStr := strings.ToUpper(battle.Mode)
 
+		// Battles recorded before difficulties existed were against the normal AI
+		difficultyStr := battle.Difficulty
+		if difficultyStr == "" {
+			difficultyStr = "normal"
+		}
+
 		// Format result with color
 		resultStr := sc.formatResult(battle.Result)
 
@@ -187,7 +299,7 @@ func (sc *StatsCommand) displayBattleHistory() {
 		}
 
 		// Print row
-		fmt.Printf("%-4d %-20s %-6s %-10s %-12s", num, dateStr, modeStr, resultStr, coinsStr)
+		fmt.Printf("%-4d %-20s %-6s %-8s %-10s %-12s", num, dateStr, modeStr, difficultyStr, resultStr, coinsStr)
 		if sc.hasDurationData() {
 			fmt.Printf(" %-10s", durationStr)
 		}
@@ -84,19 +84,21 @@ func generateInitialShopInventory() ([]storage.ShopItem, error) {
 		rarity, price := determineRarityAndPrice(pokemonEntry)
 
 		shopItem := storage.ShopItem{
-			PokemonID:   pokemonEntry.ID,
-			Name:        pokemonEntry.Name,
-			Types:       pokemonEntry.Types,
-			BaseHP:      pokemonEntry.HP,
-			BaseAttack:  pokemonEntry.Attack,
-			BaseDefense: pokemonEntry.Defense,
-			BaseSpeed:   pokemonEntry.Speed,
-			Moves:       pokemonEntry.Moves,
-			Sprite:      pokemonEntry.Sprite,
-			Price:       price,
-			Rarity:      rarity,
-			IsLegendary: pokemonEntry.IsLegendary,
-			IsMythical:  pokemonEntry.IsMythical,
+			PokemonID:     pokemonEntry.ID,
+			Name:          pokemonEntry.Name,
+			Types:         pokemonEntry.Types,
+			BaseHP:        pokemonEntry.HP,
+			BaseAttack:    pokemonEntry.Attack,
+			BaseDefense:   pokemonEntry.Defense,
+			BaseSpAttack:  pokemonEntry.SpAttack,
+			BaseSpDefense: pokemonEntry.SpDefense,
+			BaseSpeed:     pokemonEntry.Speed,
+			Moves:         pokemonEntry.Moves,
+			Sprite:        pokemonEntry.Sprite,
+			Price:         price,
+			Rarity:        rarity,
+			IsLegendary:   pokemonEntry.IsLegendary,
+			IsMythical:    pokemonEntry.IsMythical,
 		}
 
 		inventory = append(inventory, shopItem)
@@ -36,21 +36,23 @@ func GenerateStarterDeck() ([]storage.PlayerCard, error) {
 
 		// Create PlayerCard from PokemonEntry
 		card := storage.PlayerCard{
-			ID:          i, // Temporary ID, will be reassigned when added to collection
-			PokemonID:   pokemonEntry.ID,
-			Name:        pokemonEntry.Name,
-			Level:       1,
-			XP:          0,
-			BaseHP:      pokemonEntry.HP,
-			BaseAttack:  pokemonEntry.Attack,
-			BaseDefense: pokemonEntry.Defense,
-			BaseSpeed:   pokemonEntry.Speed,
-			Types:       pokemonEntry.Types,
-			Moves:       pokemonEntry.Moves,
-			Sprite:      pokemonEntry.Sprite,
-			IsLegendary: pokemonEntry.IsLegendary,
-			IsMythical:  pokemonEntry.IsMythical,
-			AcquiredAt:  time.Now(),
+			ID:            i, // Temporary ID, will be reassigned when added to collection
+			PokemonID:     pokemonEntry.ID,
+			Name:          pokemonEntry.Name,
+			Level:         1,
+			XP:            0,
+			BaseHP:        pokemonEntry.HP,
+			BaseAttack:    pokemonEntry.Attack,
+			BaseDefense:   pokemonEntry.Defense,
+			BaseSpAttack:  pokemonEntry.SpAttack,
+			BaseSpDefense: pokemonEntry.SpDefense,
+			BaseSpeed:     pokemonEntry.Speed,
+			Types:         pokemonEntry.Types,
+			Moves:         pokemonEntry.Moves,
+			Sprite:        pokemonEntry.Sprite,
+			IsLegendary:   pokemonEntry.IsLegendary,
+			IsMythical:    pokemonEntry.IsMythical,
+			AcquiredAt:    time.Now(),
 		}
 
 		starterCards = append(starterCards, card)
@@ -97,8 +99,8 @@ func DisplayStarterPokemon(starterCards []storage.PlayerCard) {
 		fmt.Println(typeDisplay)
 
 		// Stats
-		fmt.Printf("   Stats: HP: %d | Attack: %d | Defense: %d | Speed: %d | Stamina: %d\n",
-			stats.HP, stats.Attack, stats.Defense, stats.Speed, stats.Stamina)
+		fmt.Printf("   Stats: HP: %d | Attack: %d | Defense: %d | Sp. Attack: %d | Sp. Defense: %d | Speed: %d | Stamina: %d\n",
+			stats.HP, stats.Attack, stats.Defense, stats.SpAttack, stats.SpDefense, stats.Speed, stats.Stamina)
 
 		// Moves
 		fmt.Println("   Moves:")
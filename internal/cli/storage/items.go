@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+
+	"pokemon-cli/internal/pokemon"
+)
+
+// AddHeldItem puts a bought held item in the player's stock
+func (s *GameState) AddHeldItem(item string) {
+	if s.HeldItems == nil {
+		s.HeldItems = make(map[string]int)
+	}
+	s.HeldItems[item]++
+}
+
+// GiveHeldItem gives a held item from the stock to the card at cardIdx in the
+// collection. The item the card held goes back to the stock.
+func (s *GameState) GiveHeldItem(cardIdx int, item string) error {
+	if cardIdx < 0 || cardIdx >= len(s.Collection) {
+		return fmt.Errorf("invalid card index %d", cardIdx)
+	}
+	if _, ok := pokemon.GetHeldItem(item); !ok {
+		return fmt.Errorf("unknown held item %q", item)
+	}
+	if s.HeldItems[item] <= 0 {
+		return fmt.Errorf("you don't have a %s", item)
+	}
+
+	s.TakeHeldItem(cardIdx)
+	s.HeldItems[item]--
+	if s.HeldItems[item] == 0 {
+		delete(s.HeldItems, item)
+	}
+	s.Collection[cardIdx].HeldItem = item
+	return nil
+}
+
+// TakeHeldItem takes the held item of the card at cardIdx in the collection
+// back to the stock
+func (s *GameState) TakeHeldItem(cardIdx int) {
+	if cardIdx < 0 || cardIdx >= len(s.Collection) || s.Collection[cardIdx].HeldItem == "" {
+		return
+	}
+	s.AddHeldItem(s.Collection[cardIdx].HeldItem)
+	s.Collection[cardIdx].HeldItem = ""
+}
+
+// AddBattleItems puts quantity of a bought battle item in the player's bag
+func (s *GameState) AddBattleItems(item string, quantity int) error {
+	if _, _, ok := pokemon.GetBattleItem(item); !ok {
+		return fmt.Errorf("unknown battle item %q", item)
+	}
+	if quantity <= 0 {
+		return fmt.Errorf("invalid quantity %d", quantity)
+	}
+	if s.Bag == nil {
+		s.Bag = make(map[string]int)
+	}
+	s.Bag[item] += quantity
+	return nil
+}
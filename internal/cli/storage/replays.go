@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ReplayDirName is the directory (inside the save directory) holding battle replays
+	ReplayDirName = "replays"
+
+	// MaxReplays is the maximum number of replays to keep, as many as the battle history
+	MaxReplays = MaxBattleHistory
+)
+
+// ReplayFile describes a replay saved on disk
+type ReplayFile struct {
+	Name    string
+	Path    string
+	ModTime time.Time
+}
+
+// GetReplayDirectory returns the directory replays are saved to
+// Returns ~/.poketactix/replays/
+func GetReplayDirectory() (string, error) {
+	saveDir, err := GetSaveDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(saveDir, ReplayDirName), nil
+}
+
+// SaveReplay writes an encoded replay to the replay directory under name,
+// deleting the oldest replays beyond MaxReplays
+// Returns the path of the written file
+func SaveReplay(name string, data []byte) (string, error) {
+	replayDir, err := GetReplayDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(replayDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create replay directory: %w", err)
+	}
+
+	path := filepath.Join(replayDir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write replay: %w", err)
+	}
+
+	if err := cleanupOldReplays(); err != nil {
+		// Don't fail the save if cleanup fails
+		fmt.Printf("Warning: failed to cleanup old replays: %v\n", err)
+	}
+
+	return path, nil
+}
+
+// cleanupOldReplays removes old replays, keeping only the most recent MaxReplays
+func cleanupOldReplays() error {
+	replays, err := ListReplays()
+	if err != nil {
+		return err
+	}
+
+	for i := MaxReplays; i < len(replays); i++ {
+		if err := os.Remove(replays[i].Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete replay: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListReplays returns the saved replays, newest first
+func ListReplays() ([]ReplayFile, error) {
+	replayDir, err := GetReplayDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(replayDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay directory: %w", err)
+	}
+
+	var replays []ReplayFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		replays = append(replays, ReplayFile{
+			Name:    entry.Name(),
+			Path:    filepath.Join(replayDir, entry.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(replays, func(i, j int) bool {
+		return replays[i].ModTime.After(replays[j].ModTime)
+	})
+
+	return replays, nil
+}
+
+// LoadReplay reads a replay file. A bare file name is looked up in the replay directory.
+func LoadReplay(nameOrPath string) ([]byte, error) {
+	path := nameOrPath
+	if filepath.Base(nameOrPath) == nameOrPath {
+		replayDir, err := GetReplayDirectory()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(replayDir, nameOrPath)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay: %w", err)
+	}
+
+	return data, nil
+}
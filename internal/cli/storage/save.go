@@ -92,9 +92,16 @@ func SaveGameState(state *GameState) error {
 	state.LastSaved = time.Now()
 	state.Version = CurrentVersion
 
-	// Limit battle history to last 20 entries
+	// Limit battle history to last 20 entries. The timelines of the battles
+	// dropped from it can't be viewed anymore, so they are deleted too.
 	if len(state.BattleHistory) > MaxBattleHistory {
+		dropped := state.BattleHistory[:len(state.BattleHistory)-MaxBattleHistory]
 		state.BattleHistory = state.BattleHistory[len(state.BattleHistory)-MaxBattleHistory:]
+		for _, record := range dropped {
+			if err := DeleteBattleTimeline(record.ID); err != nil {
+				fmt.Printf("Warning: failed to delete battle timeline: %v\n", err)
+			}
+		}
 	}
 
 	// Marshal to JSON without indentation (more compact)
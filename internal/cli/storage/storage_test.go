@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -288,6 +289,20 @@ func TestPlayerCardGetCurrentStats(t *testing.T) {
 	if stats.HP != expectedHP {
 		t.Errorf("Expected HP %d at level 10, got: %d", expectedHP, stats.HP)
 	}
+
+	// Cards saved without special stats use their attack and defense
+	expectedSpAttack := int(float64(card.BaseAttack) * (1.0 + float64(9)*0.02))
+	if stats.SpAttack != expectedSpAttack {
+		t.Errorf("Expected special attack %d at level 10, got: %d", expectedSpAttack, stats.SpAttack)
+	}
+
+	// Special stats scale by 2% per level like attack and defense
+	card.BaseSpAttack, card.BaseSpDefense = 80, 70
+	stats = card.GetCurrentStats()
+	expectedSpDefense := int(float64(card.BaseSpDefense) * (1.0 + float64(9)*0.02))
+	if stats.SpDefense != expectedSpDefense {
+		t.Errorf("Expected special defense %d at level 10, got: %d", expectedSpDefense, stats.SpDefense)
+	}
 }
 
 func TestPlayerCardToCard(t *testing.T) {
@@ -329,4 +344,124 @@ func TestPlayerCardToCard(t *testing.T) {
 	if card.HP != stats.HP {
 		t.Errorf("HP mismatch: expected %d, got %d", stats.HP, card.HP)
 	}
+
+	// The ability comes from the species
+	if card.Ability != "lightning-rod" {
+		t.Errorf("Ability mismatch: expected lightning-rod, got %q", card.Ability)
+	}
+}
+
+func TestGiveHeldItem(t *testing.T) {
+	state := CreateNewGameState("TestPlayer")
+	state.Collection = []PlayerCard{{Name: "Pikachu"}}
+
+	if err := state.GiveHeldItem(0, pokemon.ItemLeftovers); err == nil {
+		t.Error("expected an error giving an item that isn't in stock")
+	}
+
+	state.AddHeldItem(pokemon.ItemLeftovers)
+	state.AddHeldItem("magnet")
+	if err := state.GiveHeldItem(0, pokemon.ItemLeftovers); err != nil {
+		t.Fatalf("GiveHeldItem failed: %v", err)
+	}
+	if state.Collection[0].HeldItem != pokemon.ItemLeftovers || state.HeldItems[pokemon.ItemLeftovers] != 0 {
+		t.Errorf("card holds %q with %d leftovers in stock, want leftovers and none", state.Collection[0].HeldItem, state.HeldItems[pokemon.ItemLeftovers])
+	}
+
+	// Giving another item puts the held one back in stock
+	if err := state.GiveHeldItem(0, "magnet"); err != nil {
+		t.Fatalf("GiveHeldItem failed: %v", err)
+	}
+	if state.Collection[0].HeldItem != "magnet" || state.HeldItems[pokemon.ItemLeftovers] != 1 {
+		t.Errorf("card holds %q with %d leftovers in stock, want magnet and 1", state.Collection[0].HeldItem, state.HeldItems[pokemon.ItemLeftovers])
+	}
+	if card := state.Collection[0]; card.ToCard().HeldItem != "magnet" {
+		t.Error("ToCard lost the held item")
+	}
+
+	state.TakeHeldItem(0)
+	if state.Collection[0].HeldItem != "" || state.HeldItems["magnet"] != 1 {
+		t.Error("taking the item back should return it to stock")
+	}
+}
+
+func TestAddBattleItems(t *testing.T) {
+	state := CreateNewGameState("TestPlayer")
+
+	if err := state.AddBattleItems(pokemon.ItemLeftovers, 1); err == nil {
+		t.Error("expected an error putting a held item in the bag")
+	}
+	if err := state.AddBattleItems("potion", 0); err == nil {
+		t.Error("expected an error adding no items")
+	}
+
+	for range 2 {
+		if err := state.AddBattleItems("potion", 2); err != nil {
+			t.Fatalf("AddBattleItems failed: %v", err)
+		}
+	}
+	if state.Bag["potion"] != 4 {
+		t.Errorf("bag has %d potions, want 4", state.Bag["potion"])
+	}
+}
+
+func TestTrimmedHistoryDeletesTimelines(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	state := CreateNewGameState("TestPlayer")
+	for i := range MaxBattleHistory + 2 {
+		id := fmt.Sprintf("battle-%d", i)
+		state.BattleHistory = append(state.BattleHistory, BattleRecord{ID: id, Mode: "1v1", Result: "win"})
+		if err := SaveBattleTimeline(id, []byte("{}")); err != nil {
+			t.Fatalf("SaveBattleTimeline failed: %v", err)
+		}
+	}
+
+	if err := SaveGameState(state); err != nil {
+		t.Fatalf("SaveGameState failed: %v", err)
+	}
+
+	if len(state.BattleHistory) != MaxBattleHistory {
+		t.Errorf("history has %d battles, want %d", len(state.BattleHistory), MaxBattleHistory)
+	}
+	for i := range 2 {
+		if _, err := LoadBattleTimeline(fmt.Sprintf("battle-%d", i)); err == nil {
+			t.Errorf("timeline of trimmed battle %d is still saved", i)
+		}
+	}
+	for _, record := range state.BattleHistory {
+		if _, err := LoadBattleTimeline(record.ID); err != nil {
+			t.Errorf("timeline of kept battle %s is gone: %v", record.ID, err)
+		}
+	}
+}
+
+func TestReplayCleanup(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	for i := range MaxReplays + 2 {
+		if _, err := SaveReplay(fmt.Sprintf("replay_%02d.json", i), []byte("{}")); err != nil {
+			t.Fatalf("SaveReplay %d failed: %v", i, err)
+		}
+		// Replays are ordered by modification time
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	replays, err := ListReplays()
+	if err != nil {
+		t.Fatalf("ListReplays failed: %v", err)
+	}
+	if len(replays) != MaxReplays {
+		t.Fatalf("Expected %d replays after cleanup, got: %d", MaxReplays, len(replays))
+	}
+	if newest := fmt.Sprintf("replay_%02d.json", MaxReplays+1); replays[0].Name != newest {
+		t.Errorf("newest replay is %s, want %s", replays[0].Name, newest)
+	}
+	for _, replay := range replays {
+		if replay.Name == "replay_00.json" || replay.Name == "replay_01.json" {
+			t.Errorf("oldest replay %s was kept", replay.Name)
+		}
+	}
 }
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// HistoryDirName is the directory (inside the save directory) holding per-battle timelines
+const HistoryDirName = "history"
+
+// GetHistoryDirectory returns the directory battle timelines are saved to
+// Returns ~/.poketactix/history/
+func GetHistoryDirectory() (string, error) {
+	saveDir, err := GetSaveDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(saveDir, HistoryDirName), nil
+}
+
+// SaveBattleTimeline writes the encoded turn-by-turn timeline of the battle with the given ID
+func SaveBattleTimeline(battleID string, data []byte) error {
+	historyDir, err := GetHistoryDirectory()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(historyDir, 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	path := filepath.Join(historyDir, battleID+".json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write battle timeline: %w", err)
+	}
+
+	return nil
+}
+
+// LoadBattleTimeline reads the timeline saved for the battle with the given ID
+func LoadBattleTimeline(battleID string) ([]byte, error) {
+	historyDir, err := GetHistoryDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(historyDir, battleID+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read battle timeline: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeleteBattleTimeline removes the timeline saved for the battle with the given
+// ID, if there is one. Battles recorded before timelines existed have no ID.
+func DeleteBattleTimeline(battleID string) error {
+	if battleID == "" {
+		return nil
+	}
+
+	historyDir, err := GetHistoryDirectory()
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(historyDir, battleID+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete battle timeline: %w", err)
+	}
+
+	return nil
+}
@@ -1,9 +1,12 @@
 package storage
 
 import (
+	"cmp"
 	"time"
 
 	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/progression"
+	"pokemon-cli/internal/ruleset"
 )
 
 // GameState represents the complete state of the CLI game
@@ -14,6 +17,8 @@ type GameState struct {
 	Deck          []int          `json:"deck"` // Card IDs (indices in Collection)
 	Stats         PlayerStats    `json:"stats"`
 	ShopState     ShopState      `json:"shop_state"`
+	HeldItems     map[string]int `json:"held_items,omitempty"` // Held items bought and not given to a card, by name
+	Bag           map[string]int `json:"bag,omitempty"`        // Battle items bought and not used yet, by name
 	BattleHistory []BattleRecord `json:"battle_history,omitempty"`
 	Settings      GameSettings   `json:"settings"`
 	LastSaved     time.Time      `json:"last_saved"`
@@ -22,27 +27,34 @@ type GameState struct {
 
 // GameSettings stores user preferences
 type GameSettings struct {
-	QuickBattle bool   `json:"quick_battle"` // Skip animations and delays
-	BattleSpeed string `json:"battle_speed"` // "slow", "normal", "fast"
+	QuickBattle   bool   `json:"quick_battle"`   // Skip animations and delays
+	BattleSpeed   string `json:"battle_speed"`   // "slow", "normal", "fast"
+	SpeedOrder    bool   `json:"speed_order"`    // Faster Pokemon attacks first (speed order rule)
+	DamageRules   string `json:"damage_rules"`   // "classic" or "modern" (accuracy, critical hits and STAB)
+	RandomWeather bool   `json:"random_weather"` // Battles start with a random weather or terrain
+	ItemLimit     int    `json:"item_limit"`     // Items usable from the bag per battle; 0 for the mode's default, -1 for none
 }
 
 // PlayerCard represents a Pokemon card owned by the player in CLI mode
 type PlayerCard struct {
-	ID           int            `json:"id"`
-	PokemonID    int            `json:"pokemon_id"` // ID from pokemon database
-	Name         string         `json:"name"`
-	Level        int            `json:"level"`
-	XP           int            `json:"xp"`
-	BaseHP       int            `json:"base_hp"`
-	BaseAttack   int            `json:"base_attack"`
-	BaseDefense  int            `json:"base_defense"`
-	BaseSpeed    int            `json:"base_speed"`
-	Types        []string       `json:"types"`
-	Moves        []pokemon.Move `json:"moves"`
-	Sprite       string         `json:"sprite"`
-	IsLegendary  bool           `json:"is_legendary"`
-	IsMythical   bool           `json:"is_mythical"`
-	AcquiredAt   time.Time      `json:"acquired_at"`
+	ID            int            `json:"id"`
+	PokemonID     int            `json:"pokemon_id"` // ID from pokemon database
+	Name          string         `json:"name"`
+	Level         int            `json:"level"`
+	XP            int            `json:"xp"`
+	BaseHP        int            `json:"base_hp"`
+	BaseAttack    int            `json:"base_attack"`
+	BaseDefense   int            `json:"base_defense"`
+	BaseSpAttack  int            `json:"base_sp_attack"` // Zero in saves from before special stats
+	BaseSpDefense int            `json:"base_sp_defense"`
+	BaseSpeed     int            `json:"base_speed"`
+	Types         []string       `json:"types"`
+	Moves         []pokemon.Move `json:"moves"`
+	Sprite        string         `json:"sprite"`
+	IsLegendary   bool           `json:"is_legendary"`
+	IsMythical    bool           `json:"is_mythical"`
+	HeldItem      string         `json:"held_item,omitempty"` // Held item the card brings into battle, if any
+	AcquiredAt    time.Time      `json:"acquired_at"`
 }
 
 // PlayerStats tracks battle statistics for the player
@@ -69,58 +81,69 @@ type ShopState struct {
 
 // ShopItem represents a Pokemon available for purchase in the shop
 type ShopItem struct {
-	PokemonID   int            `json:"pokemon_id"`
-	Name        string         `json:"name"`
-	Types       []string       `json:"types"`
-	BaseHP      int            `json:"base_hp"`
-	BaseAttack  int            `json:"base_attack"`
-	BaseDefense int            `json:"base_defense"`
-	BaseSpeed   int            `json:"base_speed"`
-	Moves       []pokemon.Move `json:"moves"`
-	Sprite      string         `json:"sprite"`
-	Price       int            `json:"price"`
-	Rarity      string         `json:"rarity"`
-	IsLegendary bool           `json:"is_legendary"`
-	IsMythical  bool           `json:"is_mythical"`
+	PokemonID     int            `json:"pokemon_id"`
+	Name          string         `json:"name"`
+	Types         []string       `json:"types"`
+	BaseHP        int            `json:"base_hp"`
+	BaseAttack    int            `json:"base_attack"`
+	BaseDefense   int            `json:"base_defense"`
+	BaseSpAttack  int            `json:"base_sp_attack"`
+	BaseSpDefense int            `json:"base_sp_defense"`
+	BaseSpeed     int            `json:"base_speed"`
+	Moves         []pokemon.Move `json:"moves"`
+	Sprite        string         `json:"sprite"`
+	Price         int            `json:"price"`
+	Rarity        string         `json:"rarity"`
+	IsLegendary   bool           `json:"is_legendary"`
+	IsMythical    bool           `json:"is_mythical"`
 }
 
 // BattleRecord represents a single battle in the history
 type BattleRecord struct {
-	Mode        string    `json:"mode"`        // "1v1" or "5v5"
-	Result      string    `json:"result"`      // "win", "loss", "draw"
+	ID          string    `json:"id,omitempty"` // Battle ID, names the timeline file in the history directory
+	Mode        string    `json:"mode"`         // "1v1" or "5v5"
+	Result      string    `json:"result"`       // "win", "loss", "draw"
 	CoinsEarned int       `json:"coins_earned"`
-	Duration    int       `json:"duration,omitempty"` // in seconds
+	Duration    int       `json:"duration,omitempty"`   // in seconds
+	Seed        int64     `json:"seed,omitempty"`       // Battle seed, for reproducing the battle
+	Difficulty  string    `json:"difficulty,omitempty"` // AI difficulty; empty for battles before difficulties existed
 	Timestamp   time.Time `json:"timestamp"`
 }
 
 // CardStats represents computed stats based on level
 type CardStats struct {
-	HP      int `json:"hp"`
-	Attack  int `json:"attack"`
-	Defense int `json:"defense"`
-	Speed   int `json:"speed"`
-	Stamina int `json:"stamina"`
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"sp_attack"`
+	SpDefense int `json:"sp_defense"`
+	Speed     int `json:"speed"`
+	Stamina   int `json:"stamina"`
 }
 
 // GetCurrentStats calculates current stats based on level for PlayerCard
 func (c *PlayerCard) GetCurrentStats() CardStats {
-	levelMultiplier := float64(c.Level - 1)
-
-	hp := int(float64(c.BaseHP) * (1.0 + levelMultiplier*0.03))
-	attack := int(float64(c.BaseAttack) * (1.0 + levelMultiplier*0.02))
-	defense := int(float64(c.BaseDefense) * (1.0 + levelMultiplier*0.02))
-	speed := int(float64(c.BaseSpeed) * (1.0 + levelMultiplier*0.01))
-	stamina := speed * 2
+	return CardStats(ruleset.Current().Progression.Stats(c.Level, c.BaseStats()))
+}
 
-	return CardStats{
-		HP:      hp,
-		Attack:  attack,
-		Defense: defense,
-		Speed:   speed,
-		Stamina: stamina,
+// BaseStats returns the card's stats at level 1. Cards saved before special
+// stats existed use their physical ones.
+func (c *PlayerCard) BaseStats() progression.Stats {
+	return progression.Stats{
+		HP:        c.BaseHP,
+		Attack:    c.BaseAttack,
+		Defense:   c.BaseDefense,
+		SpAttack:  cmp.Or(c.BaseSpAttack, c.BaseAttack),
+		SpDefense: cmp.Or(c.BaseSpDefense, c.BaseDefense),
+		Speed:     c.BaseSpeed,
 	}
 }
 
+// Ability returns the passive ability of the card's species
+func (c *PlayerCard) Ability() string {
+	return pokemon.SpeciesAbility(c.Name)
+}
+
 // ToCard converts a PlayerCard to a pokemon.Card for battle use
 func (c *PlayerCard) ToCard() pokemon.Card {
 	stats := c.GetCurrentStats()
@@ -131,13 +154,17 @@ func (c *PlayerCard) ToCard() pokemon.Card {
 		Stamina:     stats.Stamina,
 		Defense:     stats.Defense,
 		Attack:      stats.Attack,
+		SpAttack:    stats.SpAttack,
+		SpDefense:   stats.SpDefense,
 		Speed:       stats.Speed,
-		Moves:       c.Moves,
+		Moves:       pokemon.WithMoveEffects(c.Moves),
 		Types:       c.Types,
 		Sprite:      c.Sprite,
 		Level:       c.Level,
 		XP:          c.XP,
 		IsLegendary: c.IsLegendary,
 		IsMythical:  c.IsMythical,
+		HeldItem:    c.HeldItem,
+		Ability:     c.Ability(),
 	}
 }
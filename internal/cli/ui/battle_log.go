@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"pokemon-cli/internal/battle"
 )
 
 // LogEntry represents a single battle log entry
@@ -207,6 +209,52 @@ func (r *Renderer) RenderBattleLogSimple(messages []string, maxLines int) string
 	return r.RenderBattleLog(entries, maxLines)
 }
 
+// RenderBattleEvents renders battle events as a log, coloring each by its event type
+func (r *Renderer) RenderBattleEvents(events []battle.Event, maxLines int) string {
+	entries := make([]LogEntry, 0, len(events))
+	for _, e := range events {
+		msg := e.Message()
+		if msg == "" {
+			continue
+		}
+		entries = append(entries, LogEntry{
+			Message:   msg,
+			Timestamp: time.Now(),
+			Type:      eventLogType(e),
+		})
+	}
+	return r.RenderBattleLog(entries, maxLines)
+}
+
+// eventLogType picks the log entry type a battle event is colored as
+func eventLogType(e battle.Event) LogEntryType {
+	switch e.Type {
+	case battle.EventMoveChosen:
+		return LogTypeAction
+	case battle.EventDamageDealt, battle.EventStatusDamage, battle.EventCriticalHit,
+		battle.EventWeatherDamage:
+		return LogTypeDamage
+	case battle.EventHealed, battle.EventStaminaRestored, battle.EventBagItem:
+		return LogTypeHealing
+	case battle.EventBlocked, battle.EventPassed, battle.EventSacrificed,
+		battle.EventSwitched, battle.EventRoundStarted, battle.EventMovedFirst,
+		battle.EventCantMove, battle.EventStatusCured, battle.EventMissed,
+		battle.EventStatChanged, battle.EventFieldStarted, battle.EventFieldEnded,
+		battle.EventItemUsed, battle.EventAbility:
+		return LogTypeStatus
+	case battle.EventKnockedOut, battle.EventSurrendered, battle.EventStatusApplied:
+		return LogTypeWarning
+	case battle.EventBattleEnded:
+		switch e.Winner {
+		case "player":
+			return LogTypeVictory
+		case "ai":
+			return LogTypeDefeat
+		}
+	}
+	return LogTypeInfo
+}
+
 // renderLogEntry renders a single log entry with color coding
 func (r *Renderer) renderLogEntry(entry LogEntry) string {
 	var result strings.Builder
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/pokemon"
 )
 
 // RenderBattleScreen displays the complete battle state
@@ -89,6 +90,18 @@ func (r *Renderer) renderBattleArena(bs *battle.BattleState, width int) string {
 	result.WriteString(strings.Repeat(" ", padding))
 	result.WriteString(" ║\n")
 
+	// Weather and terrain line
+	if field := r.renderField(bs.Field); field != "" {
+		padding := width - len(stripANSI(field)) - 4
+		if padding < 0 {
+			padding = 0
+		}
+		result.WriteString("║ ")
+		result.WriteString(field)
+		result.WriteString(strings.Repeat(" ", padding))
+		result.WriteString(" ║\n")
+	}
+
 	result.WriteString("║")
 	result.WriteString(strings.Repeat(" ", width-2))
 	result.WriteString("║\n")
@@ -224,8 +237,12 @@ func (r *Renderer) renderPokemonCard(card *battle.BattleCard, isPlayer bool) str
 	result.WriteString(strings.Repeat(" ", padding))
 	result.WriteString(" │")
 
-	// Level
-	result.WriteString(fmt.Sprintf("\n│ Lv %-19d │", card.Level))
+	// Level, and the status condition if any
+	badge := r.renderStatusBadge(card.Status)
+	result.WriteString(fmt.Sprintf("\n│ Lv %-13d ", card.Level))
+	result.WriteString(badge)
+	result.WriteString(strings.Repeat(" ", 5-len(stripANSI(badge))))
+	result.WriteString(" │")
 
 	// Types
 	result.WriteString("\n│ ")
@@ -288,9 +305,17 @@ func (r *Renderer) renderPokemonCard(card *battle.BattleCard, isPlayer bool) str
 	result.WriteString(strings.Repeat(" ", staPadding))
 	result.WriteString("│")
 
-	// Stats - ensure proper spacing (24 chars inside borders)
-	result.WriteString(fmt.Sprintf("\n│ ATK: %-3d  DEF: %-3d     │", card.Attack, card.Defense))
-	result.WriteString(fmt.Sprintf("\n│ SPD: %-18d│", card.Speed))
+	// Stats with their stages - ensure proper spacing (24 chars inside borders)
+	result.WriteString(fmt.Sprintf("\n│ ATK: %-3d%-2s DEF: %-3d%-2s  │", card.Attack, stageText(card.Stages.Attack), card.Defense, stageText(card.Stages.Defense)))
+	result.WriteString(fmt.Sprintf("\n│ SPD: %-3d%-2s SP: %3d/%-3d │", card.Speed, stageText(card.Stages.Speed), card.SpAttack, card.SpDefense))
+
+	// Ability and held item, if any
+	if card.Ability != "" {
+		result.WriteString(fmt.Sprintf("\n│ Abil: %-16.16s │", pokemon.AbilityName(card.Ability)))
+	}
+	if card.HeldItem != "" {
+		result.WriteString(fmt.Sprintf("\n│ Item: %-16.16s │", pokemon.HeldItemName(card.HeldItem)))
+	}
 
 	// Card border bottom
 	result.WriteString("\n└────────────────────────┘")
@@ -329,6 +354,125 @@ func stripANSIToLength(s string, length int) string {
 	return stripped + strings.Repeat(" ", length-len(stripped))
 }
 
+// statusBadges are the labels and colors status conditions are shown with
+var statusBadges = map[string]struct{ label, color string }{
+	pokemon.StatusBurn:      {"BRN", ColorRed},
+	pokemon.StatusPoison:    {"PSN", ColorMagenta},
+	pokemon.StatusParalysis: {"PAR", ColorYellow},
+	pokemon.StatusSleep:     {"SLP", ColorGray},
+	pokemon.StatusFreeze:    {"FRZ", ColorCyan},
+}
+
+// renderStatusBadge renders a status condition as a short label such as [BRN],
+// or "" for a Pokemon without one
+func (r *Renderer) renderStatusBadge(status string) string {
+	badge, ok := statusBadges[status]
+	if !ok {
+		return ""
+	}
+	label := "[" + badge.label + "]"
+	if r.ColorSupport {
+		return Colorize(label, Bold+badge.color)
+	}
+	return label
+}
+
+// stageText renders a stat stage as "+2" or "-1", or "" for an unchanged stat
+func stageText(stage int) string {
+	if stage == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+d", stage)
+}
+
+// renderStages renders the stat stages of a Pokemon such as "ATK+2 SPD-1", or
+// "" when none are changed
+func renderStages(stages battle.StatStages) string {
+	var parts []string
+	for _, stat := range []struct {
+		label string
+		stage int
+	}{{"ATK", stages.Attack}, {"DEF", stages.Defense}, {"SP.ATK", stages.SpAttack}, {"SP.DEF", stages.SpDefense}, {"SPD", stages.Speed}} {
+		if stat.stage != 0 {
+			parts = append(parts, stat.label+stageText(stat.stage))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// fieldLabels are the names and colors weathers and terrains are shown with
+var fieldLabels = map[string]struct{ name, color string }{
+	pokemon.WeatherSun:       {"Harsh Sunlight", ColorYellow},
+	pokemon.WeatherRain:      {"Rain", ColorBlue},
+	pokemon.WeatherSandstorm: {"Sandstorm", ColorYellow},
+	pokemon.WeatherHail:      {"Hail", ColorCyan},
+	pokemon.TerrainElectric:  {"Electric Terrain", ColorYellow},
+	pokemon.TerrainGrassy:    {"Grassy Terrain", ColorGreen},
+	pokemon.TerrainPsychic:   {"Psychic Terrain", ColorMagenta},
+	pokemon.TerrainMisty:     {"Misty Terrain", ColorMagenta},
+}
+
+// FieldName returns the name a weather or terrain is shown with
+func FieldName(effect string) string {
+	if label, ok := fieldLabels[effect]; ok {
+		return label.name
+	}
+	return effect
+}
+
+// renderFieldEffect renders a weather or terrain with the turns it has left,
+// such as "Rain (3 turns left)"
+func (r *Renderer) renderFieldEffect(effect string, turns int) string {
+	text := FieldName(effect)
+	if r.ColorSupport {
+		text = Colorize(text, Bold+fieldLabels[effect].color)
+	}
+	switch turns {
+	case 0:
+		return text + " (whole battle)"
+	case 1:
+		return text + " (1 turn left)"
+	}
+	return fmt.Sprintf("%s (%d turns left)", text, turns)
+}
+
+// renderField renders the weather and terrain in play such as
+// "Weather: Rain (3 turns left) | Terrain: Grassy Terrain (whole battle)", or
+// "" when there are none
+func (r *Renderer) renderField(field battle.Field) string {
+	var parts []string
+	if field.Weather != "" {
+		parts = append(parts, "Weather: "+r.renderFieldEffect(field.Weather, field.WeatherTurns))
+	}
+	if field.Terrain != "" {
+		parts = append(parts, "Terrain: "+r.renderFieldEffect(field.Terrain, field.TerrainTurns))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// describeSupportMove lists what a support move does, such as
+// "heal 50%, attack +2"
+func describeSupportMove(move pokemon.Move) string {
+	var effects []string
+	if move.Healing > 0 {
+		effects = append(effects, fmt.Sprintf("heal %d%%", move.Healing))
+	}
+	if move.StaminaGain > 0 {
+		effects = append(effects, fmt.Sprintf("stamina +%d%%", move.StaminaGain))
+	}
+	for _, change := range move.StatChanges {
+		target := ""
+		if change.Target == pokemon.TargetOpponent {
+			target = "foe's "
+		}
+		effects = append(effects, fmt.Sprintf("%s%s %+d", target, change.Stat, change.Stages))
+	}
+	if move.Field != "" {
+		effects = append(effects, FieldName(move.Field))
+	}
+	return strings.Join(effects, ", ")
+}
+
 // RenderBattleActions renders the action menu for battle
 func (r *Renderer) RenderBattleActions(actions []string, selected int) string {
 	var result strings.Builder
@@ -376,7 +520,17 @@ func (r *Renderer) RenderMoveSelection(card *battle.BattleCard, selected int) st
 		line += moveName
 
 		// Move details
-		details := fmt.Sprintf(" | Power: %d | Stamina: %d", move.Power, move.StaminaCost)
+		category := "Physical"
+		if move.IsSpecial() {
+			category = "Special"
+		}
+		details := fmt.Sprintf(" | %s | Power: %d | Stamina: %d", category, move.Power, move.StaminaCost)
+		if move.IsSupport() {
+			details = fmt.Sprintf(" | Support: %s | Stamina: %d", describeSupportMove(move), move.StaminaCost)
+		}
+		if move.Effect != "" {
+			details += fmt.Sprintf(" | %d%% %s", move.EffectChance, move.Effect)
+		}
 		if !canUse {
 			if r.ColorSupport {
 				details += Colorize(" [NOT ENOUGH STAMINA]", ColorRed)
@@ -433,6 +587,9 @@ func (r *Renderer) RenderPokemonSwitchMenu(deck []battle.BattleCard, activeIdx i
 		if r.ColorSupport && isSelected {
 			info = Colorize(info, Bold+ColorBrightYellow)
 		}
+		if badge := r.renderStatusBadge(card.Status); badge != "" {
+			info += " " + badge
+		}
 
 		line += info
 		result.WriteString(line)
@@ -460,6 +617,11 @@ func (r *Renderer) RenderBattleScreenCondensed(bs *battle.BattleState) string {
 	result.WriteString(strings.Repeat("═", 60))
 	result.WriteString("\n\n")
 
+	if field := r.renderField(bs.Field); field != "" {
+		result.WriteString(field)
+		result.WriteString("\n\n")
+	}
+
 	// Get active Pokemon
 	playerActive := bs.GetActivePlayerCard()
 	aiActive := bs.GetActiveAICard()
@@ -472,7 +634,17 @@ func (r *Renderer) RenderBattleScreenCondensed(bs *battle.BattleState) string {
 		} else {
 			result.WriteString(playerActive.Name)
 		}
-		result.WriteString(fmt.Sprintf(" (Lv %d)\n", playerActive.Level))
+		result.WriteString(fmt.Sprintf(" (Lv %d)", playerActive.Level))
+		if badge := r.renderStatusBadge(playerActive.Status); badge != "" {
+			result.WriteString(" " + badge)
+		}
+		if stages := renderStages(playerActive.Stages); stages != "" {
+			result.WriteString(" " + stages)
+		}
+		if playerActive.HeldItem != "" {
+			result.WriteString(" @ " + pokemon.HeldItemName(playerActive.HeldItem))
+		}
+		result.WriteString("\n")
 		
 		// HP bar
 		result.WriteString("  HP:  ")
@@ -504,7 +676,17 @@ func (r *Renderer) RenderBattleScreenCondensed(bs *battle.BattleState) string {
 		} else {
 			result.WriteString(aiActive.Name)
 		}
-		result.WriteString(fmt.Sprintf(" (Lv %d)\n", aiActive.Level))
+		result.WriteString(fmt.Sprintf(" (Lv %d)", aiActive.Level))
+		if badge := r.renderStatusBadge(aiActive.Status); badge != "" {
+			result.WriteString(" " + badge)
+		}
+		if stages := renderStages(aiActive.Stages); stages != "" {
+			result.WriteString(" " + stages)
+		}
+		if aiActive.HeldItem != "" {
+			result.WriteString(" @ " + pokemon.HeldItemName(aiActive.HeldItem))
+		}
+		result.WriteString("\n")
 		
 		// HP bar
 		result.WriteString("  HP:  ")
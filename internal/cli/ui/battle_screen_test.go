@@ -145,3 +145,46 @@ func TestBattleScreenWidth(t *testing.T) {
 		t.Error("Screen should end with bottom border")
 	}
 }
+
+func TestBattleArenaShowsField(t *testing.T) {
+	renderer := &Renderer{Width: 100, Height: 30}
+	card := battle.BattleCard{Name: "Pikachu", Level: 5, HP: 100, HPMax: 100, Stamina: 180, StaminaMax: 180, Types: []string{"electric"}}
+	bs := &battle.BattleState{
+		Mode:       "1v1",
+		PlayerDeck: []battle.BattleCard{card},
+		AIDeck:     []battle.BattleCard{card},
+	}
+
+	if arena := renderer.renderBattleArena(bs, renderer.Width); strings.Contains(arena, "Weather") || strings.Contains(arena, "Terrain:") {
+		t.Error("arena shows a field when none is in play")
+	}
+
+	bs.Field = battle.Field{Weather: "rain", WeatherTurns: 3, Terrain: "grassy-terrain"}
+	arena := renderer.renderBattleArena(bs, renderer.Width)
+	if want := "Weather: Rain (3 turns left) | Terrain: Grassy Terrain (whole battle)"; !strings.Contains(arena, want) {
+		t.Errorf("arena does not show %q:\n%s", want, arena)
+	}
+	for _, line := range strings.Split(arena, "\n") {
+		if strings.Contains(line, "Weather:") && len([]rune(line)) != renderer.Width {
+			t.Errorf("field line is %d wide, want %d: %q", len([]rune(line)), renderer.Width, line)
+		}
+	}
+}
+
+func TestPokemonCardShowsHeldItemAndAbility(t *testing.T) {
+	renderer := &Renderer{Width: 100, Height: 30}
+	card := &battle.BattleCard{Name: "Pikachu", Level: 5, HP: 100, HPMax: 100, Stamina: 180, StaminaMax: 180, Types: []string{"electric"}, HeldItem: "never-melt-ice", Ability: "lightning-rod"}
+
+	cardStr := renderer.renderPokemonCard(card, true)
+	if !strings.Contains(cardStr, "Item: Never Melt Ice") {
+		t.Errorf("card does not show its held item:\n%s", cardStr)
+	}
+	if !strings.Contains(cardStr, "Abil: Lightning Rod") {
+		t.Errorf("card does not show its ability:\n%s", cardStr)
+	}
+	for i, line := range strings.Split(cardStr, "\n") {
+		if width := len([]rune(line)); width != 26 {
+			t.Errorf("line %d is %d wide, want 26: %q", i, width, line)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	"pokemon-cli/internal/battle"
@@ -115,6 +116,44 @@ func TestDetectLogType(t *testing.T) {
 	}
 }
 
+func TestEventLogType(t *testing.T) {
+	tests := []struct {
+		event    battle.Event
+		expected LogEntryType
+	}{
+		{battle.Event{Type: battle.EventMoveChosen, Side: "player", Move: "defend"}, LogTypeAction},
+		{battle.Event{Type: battle.EventDamageDealt, Side: "ai", Damage: 12}, LogTypeDamage},
+		{battle.Event{Type: battle.EventBlocked, Side: "player"}, LogTypeStatus},
+		{battle.Event{Type: battle.EventKnockedOut, Side: "ai", Pokemon: "squirtle"}, LogTypeWarning},
+		{battle.Event{Type: battle.EventBattleEnded, Winner: "player", Reason: battle.EndSurrender}, LogTypeVictory},
+		{battle.Event{Type: battle.EventBattleEnded, Winner: "ai", Reason: battle.EndNoPokemonLeft}, LogTypeDefeat},
+		{battle.Event{Type: battle.EventBattleStarted, Mode: "1v1"}, LogTypeInfo},
+	}
+
+	for _, tt := range tests {
+		if result := eventLogType(tt.event); result != tt.expected {
+			t.Errorf("eventLogType(%s) = %v, want %v", tt.event.Type, result, tt.expected)
+		}
+	}
+}
+
+func TestRenderBattleEventsSkipsSilentEvents(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.ColorSupport = false
+	events := []battle.Event{
+		{Type: battle.EventDamageDealt, Side: "player", Damage: 30},
+		{Type: battle.EventBattleEnded, Winner: "player", Reason: battle.EndKnockout},
+	}
+
+	result := renderer.RenderBattleEvents(events, 10)
+	if !strings.Contains(result, "Player dealt 30 damage to AI.") {
+		t.Errorf("expected damage line in log, got:\n%s", result)
+	}
+	if strings.Count(result, "[") != 1 {
+		t.Errorf("expected exactly one log line, got:\n%s", result)
+	}
+}
+
 func TestRenderMenu(t *testing.T) {
 	renderer := NewRenderer()
 	options := []MenuOption{
@@ -0,0 +1,2 @@
+-- Drop battle_turns table
+DROP TABLE IF EXISTS battle_turns;
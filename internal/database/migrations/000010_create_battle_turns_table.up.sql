@@ -0,0 +1,24 @@
+-- Create battle_turns table holding the turn-by-turn timeline of a finished battle
+CREATE TABLE IF NOT EXISTS battle_turns (
+    id SERIAL PRIMARY KEY,
+    battle_history_id INTEGER NOT NULL REFERENCES battle_history(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL CHECK (seq >= 0),
+    turn INTEGER NOT NULL,
+    round INTEGER NOT NULL,
+    player_pokemon VARCHAR(100) NOT NULL,
+    ai_pokemon VARCHAR(100) NOT NULL,
+    player_move VARCHAR(20) NOT NULL,
+    player_move_name VARCHAR(100),
+    ai_move VARCHAR(20),
+    ai_move_name VARCHAR(100),
+    damage_dealt INTEGER NOT NULL DEFAULT 0,
+    damage_taken INTEGER NOT NULL DEFAULT 0,
+    player_hp_delta INTEGER NOT NULL DEFAULT 0,
+    player_stamina_delta INTEGER NOT NULL DEFAULT 0,
+    ai_hp_delta INTEGER NOT NULL DEFAULT 0,
+    ai_stamina_delta INTEGER NOT NULL DEFAULT 0,
+    player_knocked_out BOOLEAN NOT NULL DEFAULT FALSE,
+    ai_knocked_out BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Also the index timelines are looked up and ordered by
+    UNIQUE (battle_history_id, seq)
+);
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_battle_sessions_opponent_user_id;
+
+-- Remove opponent_user_id column from battle_sessions
+ALTER TABLE battle_sessions DROP COLUMN IF EXISTS opponent_user_id;
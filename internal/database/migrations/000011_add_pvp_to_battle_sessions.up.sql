@@ -0,0 +1,6 @@
+-- Add the second player of PvP battles to battle_sessions
+ALTER TABLE battle_sessions
+ADD COLUMN IF NOT EXISTS opponent_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+-- Create index on opponent_user_id for looking up incoming challenges
+CREATE INDEX IF NOT EXISTS idx_battle_sessions_opponent_user_id ON battle_sessions(opponent_user_id);
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_matchmaking_queue_mode_status;
+
+-- Drop matchmaking_queue table
+DROP TABLE IF EXISTS matchmaking_queue;
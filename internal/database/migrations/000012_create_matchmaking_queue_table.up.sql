@@ -0,0 +1,13 @@
+-- Create matchmaking_queue table holding the users looking for a PvP opponent
+CREATE TABLE IF NOT EXISTS matchmaking_queue (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    mode VARCHAR(10) NOT NULL CHECK (mode IN ('1v1', '5v5')),
+    win_rate DOUBLE PRECISION NOT NULL CHECK (win_rate >= 0 AND win_rate <= 1),
+    status VARCHAR(10) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'matched')),
+    battle_id VARCHAR(255),
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    matched_at TIMESTAMP
+);
+
+-- Create index for the pairing loop, which scans the waiting users of each mode
+CREATE INDEX IF NOT EXISTS idx_matchmaking_queue_mode_status ON matchmaking_queue(mode, status, joined_at);
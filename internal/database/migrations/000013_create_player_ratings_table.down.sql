@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_player_ratings_updated_at ON player_ratings;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_player_ratings_leaderboard;
+
+-- Drop player_ratings table
+DROP TABLE IF EXISTS player_ratings;
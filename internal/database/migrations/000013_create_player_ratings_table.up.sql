@@ -0,0 +1,20 @@
+-- Create player_ratings table holding each player's Glicko-2 rating per mode.
+-- PvP battles move the 'pvp' ladder and battles against the AI the 'pve' one.
+CREATE TABLE IF NOT EXISTS player_ratings (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mode VARCHAR(10) NOT NULL CHECK (mode IN ('1v1', '5v5')),
+    ladder VARCHAR(10) NOT NULL CHECK (ladder IN ('pvp', 'pve')),
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1500,
+    deviation DOUBLE PRECISION NOT NULL DEFAULT 350 CHECK (deviation > 0),
+    volatility DOUBLE PRECISION NOT NULL DEFAULT 0.06 CHECK (volatility > 0),
+    games INTEGER NOT NULL DEFAULT 0 CHECK (games >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, mode, ladder)
+);
+
+-- Create index for leaderboard queries
+CREATE INDEX IF NOT EXISTS idx_player_ratings_leaderboard ON player_ratings(mode, ladder, rating DESC);
+
+-- Create trigger to update updated_at timestamp
+CREATE TRIGGER update_player_ratings_updated_at BEFORE UPDATE ON player_ratings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
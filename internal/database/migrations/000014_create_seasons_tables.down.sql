@@ -0,0 +1,17 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_season_snapshots_user_id;
+DROP INDEX IF EXISTS idx_seasons_one_active;
+
+-- Drop season_snapshots table
+DROP TABLE IF EXISTS season_snapshots;
+
+-- Remove seasonal counters from player_stats
+ALTER TABLE player_stats
+DROP COLUMN IF EXISTS season_wins,
+DROP COLUMN IF EXISTS season_losses,
+DROP COLUMN IF EXISTS season_draws,
+DROP COLUMN IF EXISTS season_win_streak,
+DROP COLUMN IF EXISTS season_best_win_streak;
+
+-- Drop seasons table
+DROP TABLE IF EXISTS seasons;
@@ -0,0 +1,38 @@
+-- Create seasons table. Only one season is active at a time.
+CREATE TABLE IF NOT EXISTS seasons (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'closed')),
+    closed_at TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seasons_one_active ON seasons(status) WHERE status = 'active';
+
+-- Add the counters of the current season to player_stats; they are reset at rollover
+ALTER TABLE player_stats
+ADD COLUMN IF NOT EXISTS season_wins INTEGER DEFAULT 0 CHECK (season_wins >= 0),
+ADD COLUMN IF NOT EXISTS season_losses INTEGER DEFAULT 0 CHECK (season_losses >= 0),
+ADD COLUMN IF NOT EXISTS season_draws INTEGER DEFAULT 0 CHECK (season_draws >= 0),
+ADD COLUMN IF NOT EXISTS season_win_streak INTEGER DEFAULT 0 CHECK (season_win_streak >= 0),
+ADD COLUMN IF NOT EXISTS season_best_win_streak INTEGER DEFAULT 0 CHECK (season_best_win_streak >= 0);
+
+-- Create season_snapshots table holding each player's record in a closed season
+CREATE TABLE IF NOT EXISTS season_snapshots (
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wins INTEGER NOT NULL DEFAULT 0,
+    losses INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0,
+    best_win_streak INTEGER NOT NULL DEFAULT 0,
+    tier VARCHAR(20) NOT NULL,
+    coins_rewarded INTEGER NOT NULL DEFAULT 0,
+    cards_rewarded INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (season_id, user_id)
+);
+
+-- Create index on user_id for a player's season history
+CREATE INDEX IF NOT EXISTS idx_season_snapshots_user_id ON season_snapshots(user_id);
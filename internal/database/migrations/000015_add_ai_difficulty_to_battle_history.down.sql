@@ -0,0 +1,2 @@
+-- Remove ai_difficulty column from battle_history
+ALTER TABLE battle_history DROP COLUMN IF EXISTS ai_difficulty;
@@ -0,0 +1,4 @@
+-- Record which AI difficulty a battle was played against; NULL for PvP battles
+-- and battles recorded before difficulties existed
+ALTER TABLE battle_history
+ADD COLUMN IF NOT EXISTS ai_difficulty VARCHAR(10) CHECK (ai_difficulty IN ('easy', 'normal', 'hard', 'expert'));
@@ -0,0 +1,7 @@
+-- Forget which battles were against the master AI, which the old constraint doesn't allow
+UPDATE battle_history SET ai_difficulty = NULL WHERE ai_difficulty = 'master';
+
+-- Restore the original ai_difficulty constraint
+ALTER TABLE battle_history DROP CONSTRAINT IF EXISTS battle_history_ai_difficulty_check;
+ALTER TABLE battle_history
+ADD CONSTRAINT battle_history_ai_difficulty_check CHECK (ai_difficulty IN ('easy', 'normal', 'hard', 'expert'));
@@ -0,0 +1,4 @@
+-- Allow the master AI difficulty in battle_history
+ALTER TABLE battle_history DROP CONSTRAINT IF EXISTS battle_history_ai_difficulty_check;
+ALTER TABLE battle_history
+ADD CONSTRAINT battle_history_ai_difficulty_check CHECK (ai_difficulty IN ('easy', 'normal', 'hard', 'expert', 'master'));
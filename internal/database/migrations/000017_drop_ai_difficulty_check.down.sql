@@ -0,0 +1,7 @@
+-- Forget difficulties the restored constraint doesn't allow
+UPDATE battle_history SET ai_difficulty = NULL
+WHERE ai_difficulty NOT IN ('easy', 'normal', 'hard', 'expert', 'master');
+
+-- Restore the ai_difficulty constraint
+ALTER TABLE battle_history
+ADD CONSTRAINT battle_history_ai_difficulty_check CHECK (ai_difficulty IN ('easy', 'normal', 'hard', 'expert', 'master'));
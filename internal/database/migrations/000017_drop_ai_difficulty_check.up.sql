@@ -0,0 +1,3 @@
+-- AI difficulties are validated by the application; drop the constraint so new
+-- levels don't each need a migration
+ALTER TABLE battle_history DROP CONSTRAINT IF EXISTS battle_history_ai_difficulty_check;
@@ -0,0 +1,4 @@
+-- Remove the special stats from player_cards
+ALTER TABLE player_cards
+DROP COLUMN IF EXISTS base_sp_attack,
+DROP COLUMN IF EXISTS base_sp_defense;
@@ -0,0 +1,13 @@
+-- Add special attack and special defense, used by special moves. Existing cards
+-- take their physical stats, which is what they battled with until now.
+ALTER TABLE player_cards
+ADD COLUMN IF NOT EXISTS base_sp_attack INTEGER CHECK (base_sp_attack > 0),
+ADD COLUMN IF NOT EXISTS base_sp_defense INTEGER CHECK (base_sp_defense > 0);
+
+UPDATE player_cards
+SET base_sp_attack = base_attack, base_sp_defense = base_defense
+WHERE base_sp_attack IS NULL OR base_sp_defense IS NULL;
+
+ALTER TABLE player_cards
+ALTER COLUMN base_sp_attack SET NOT NULL,
+ALTER COLUMN base_sp_defense SET NOT NULL;
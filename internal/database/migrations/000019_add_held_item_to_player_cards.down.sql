@@ -0,0 +1,3 @@
+-- Remove the held item from player_cards
+ALTER TABLE player_cards
+DROP COLUMN IF EXISTS held_item;
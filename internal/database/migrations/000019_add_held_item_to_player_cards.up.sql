@@ -0,0 +1,3 @@
+-- Add the held item a card brings into battle; empty when it holds none
+ALTER TABLE player_cards
+ADD COLUMN IF NOT EXISTS held_item VARCHAR(30) NOT NULL DEFAULT '';
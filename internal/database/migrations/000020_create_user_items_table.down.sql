@@ -0,0 +1,5 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_user_items_updated_at ON user_items;
+
+-- Drop user_items table
+DROP TABLE IF EXISTS user_items;
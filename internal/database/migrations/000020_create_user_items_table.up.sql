@@ -0,0 +1,13 @@
+-- Create user_items table holding the battle items in each player's bag.
+-- Items are used one at a time until their quantity reaches 0.
+CREATE TABLE IF NOT EXISTS user_items (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item VARCHAR(30) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, item)
+);
+
+-- Create trigger to update updated_at timestamp
+CREATE TRIGGER update_user_items_updated_at BEFORE UPDATE ON user_items
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
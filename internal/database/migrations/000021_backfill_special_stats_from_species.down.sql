@@ -0,0 +1,2 @@
+-- Nothing to undo: the species' special stats are what the cards should have had
+-- all along, and rolling back 000018 drops the columns
@@ -0,0 +1,659 @@
+-- Give cards the special attack and special defense of their species. 000018
+-- copied the physical stats into the special ones; cards still holding those
+-- copies get the species' real special stats.
+UPDATE player_cards
+SET base_sp_attack = species.sp_attack, base_sp_defense = species.sp_defense
+FROM (VALUES
+    ('bulbasaur', 65, 65),
+    ('ivysaur', 80, 80),
+    ('venusaur', 100, 100),
+    ('charmander', 60, 50),
+    ('charmeleon', 80, 65),
+    ('charizard', 109, 85),
+    ('squirtle', 50, 64),
+    ('wartortle', 65, 80),
+    ('blastoise', 85, 105),
+    ('caterpie', 20, 20),
+    ('metapod', 25, 25),
+    ('butterfree', 90, 80),
+    ('weedle', 20, 20),
+    ('kakuna', 25, 25),
+    ('beedrill', 45, 80),
+    ('pidgey', 35, 35),
+    ('pidgeotto', 50, 50),
+    ('pidgeot', 70, 70),
+    ('rattata', 25, 35),
+    ('raticate', 50, 70),
+    ('spearow', 31, 31),
+    ('fearow', 61, 61),
+    ('ekans', 40, 54),
+    ('arbok', 65, 79),
+    ('pikachu', 50, 50),
+    ('raichu', 90, 80),
+    ('sandshrew', 20, 30),
+    ('sandslash', 45, 55),
+    ('nidoran-f', 40, 40),
+    ('nidorina', 55, 55),
+    ('nidoqueen', 75, 85),
+    ('nidoran-m', 40, 40),
+    ('nidorino', 55, 55),
+    ('nidoking', 85, 75),
+    ('clefairy', 60, 65),
+    ('clefable', 95, 90),
+    ('vulpix', 50, 65),
+    ('ninetales', 81, 100),
+    ('jigglypuff', 45, 25),
+    ('wigglytuff', 85, 50),
+    ('zubat', 30, 40),
+    ('golbat', 65, 75),
+    ('oddish', 75, 65),
+    ('gloom', 85, 75),
+    ('vileplume', 110, 90),
+    ('paras', 45, 55),
+    ('parasect', 60, 80),
+    ('venonat', 40, 55),
+    ('venomoth', 90, 75),
+    ('diglett', 35, 45),
+    ('dugtrio', 50, 70),
+    ('meowth', 40, 40),
+    ('persian', 65, 65),
+    ('psyduck', 65, 50),
+    ('golduck', 95, 80),
+    ('mankey', 35, 45),
+    ('primeape', 60, 70),
+    ('growlithe', 70, 50),
+    ('arcanine', 100, 80),
+    ('poliwag', 40, 40),
+    ('poliwhirl', 50, 50),
+    ('poliwrath', 70, 90),
+    ('abra', 105, 55),
+    ('kadabra', 120, 70),
+    ('alakazam', 135, 95),
+    ('machop', 35, 35),
+    ('machoke', 50, 60),
+    ('machamp', 65, 85),
+    ('bellsprout', 70, 30),
+    ('weepinbell', 85, 45),
+    ('victreebel', 100, 70),
+    ('tentacool', 50, 100),
+    ('tentacruel', 80, 120),
+    ('geodude', 30, 30),
+    ('graveler', 45, 45),
+    ('golem', 55, 65),
+    ('ponyta', 65, 65),
+    ('rapidash', 80, 80),
+    ('slowpoke', 40, 40),
+    ('slowbro', 100, 80),
+    ('magnemite', 95, 55),
+    ('magneton', 120, 70),
+    ('farfetchd', 58, 62),
+    ('doduo', 35, 35),
+    ('dodrio', 60, 60),
+    ('seel', 45, 70),
+    ('dewgong', 70, 95),
+    ('grimer', 40, 50),
+    ('muk', 65, 100),
+    ('shellder', 45, 25),
+    ('cloyster', 85, 45),
+    ('gastly', 100, 35),
+    ('haunter', 115, 55),
+    ('gengar', 130, 75),
+    ('onix', 30, 45),
+    ('drowzee', 43, 90),
+    ('hypno', 73, 115),
+    ('krabby', 25, 25),
+    ('kingler', 50, 50),
+    ('voltorb', 55, 55),
+    ('electrode', 80, 80),
+    ('exeggcute', 60, 45),
+    ('exeggutor', 125, 75),
+    ('cubone', 40, 50),
+    ('marowak', 50, 80),
+    ('hitmonlee', 35, 110),
+    ('hitmonchan', 35, 110),
+    ('lickitung', 60, 75),
+    ('koffing', 60, 45),
+    ('weezing', 85, 70),
+    ('rhyhorn', 30, 30),
+    ('rhydon', 45, 45),
+    ('chansey', 35, 105),
+    ('tangela', 100, 40),
+    ('kangaskhan', 40, 80),
+    ('horsea', 70, 25),
+    ('seadra', 95, 45),
+    ('goldeen', 35, 50),
+    ('seaking', 65, 80),
+    ('staryu', 70, 55),
+    ('starmie', 100, 85),
+    ('mr-mime', 100, 120),
+    ('scyther', 55, 80),
+    ('jynx', 115, 95),
+    ('electabuzz', 95, 85),
+    ('magmar', 100, 85),
+    ('pinsir', 55, 70),
+    ('tauros', 40, 70),
+    ('magikarp', 15, 20),
+    ('gyarados', 60, 100),
+    ('lapras', 85, 95),
+    ('ditto', 48, 48),
+    ('eevee', 45, 65),
+    ('vaporeon', 110, 95),
+    ('jolteon', 110, 95),
+    ('flareon', 95, 110),
+    ('porygon', 85, 75),
+    ('omanyte', 90, 55),
+    ('omastar', 115, 70),
+    ('kabuto', 55, 45),
+    ('kabutops', 65, 70),
+    ('aerodactyl', 60, 75),
+    ('snorlax', 65, 110),
+    ('articuno', 95, 125),
+    ('zapdos', 125, 90),
+    ('moltres', 125, 85),
+    ('dratini', 50, 50),
+    ('dragonair', 70, 70),
+    ('dragonite', 100, 100),
+    ('mewtwo', 154, 90),
+    ('mew', 100, 100),
+    ('chikorita', 49, 65),
+    ('bayleef', 63, 80),
+    ('meganium', 83, 100),
+    ('cyndaquil', 60, 50),
+    ('quilava', 80, 65),
+    ('typhlosion', 109, 85),
+    ('totodile', 44, 48),
+    ('croconaw', 59, 63),
+    ('feraligatr', 79, 83),
+    ('sentret', 35, 45),
+    ('furret', 45, 55),
+    ('hoothoot', 36, 56),
+    ('noctowl', 86, 96),
+    ('ledyba', 40, 80),
+    ('ledian', 55, 110),
+    ('spinarak', 40, 40),
+    ('ariados', 60, 70),
+    ('crobat', 70, 80),
+    ('chinchou', 56, 56),
+    ('lanturn', 76, 76),
+    ('pichu', 35, 35),
+    ('cleffa', 45, 55),
+    ('igglybuff', 40, 20),
+    ('togepi', 40, 65),
+    ('togetic', 80, 105),
+    ('natu', 70, 45),
+    ('xatu', 95, 70),
+    ('mareep', 65, 45),
+    ('flaaffy', 80, 60),
+    ('ampharos', 115, 90),
+    ('bellossom', 90, 100),
+    ('marill', 20, 50),
+    ('azumarill', 60, 80),
+    ('sudowoodo', 30, 65),
+    ('politoed', 90, 100),
+    ('hoppip', 35, 55),
+    ('skiploom', 45, 65),
+    ('jumpluff', 55, 95),
+    ('aipom', 40, 55),
+    ('sunkern', 30, 30),
+    ('sunflora', 105, 85),
+    ('yanma', 75, 45),
+    ('wooper', 25, 25),
+    ('quagsire', 65, 65),
+    ('espeon', 130, 95),
+    ('umbreon', 60, 130),
+    ('murkrow', 85, 42),
+    ('slowking', 100, 110),
+    ('misdreavus', 85, 85),
+    ('unown', 72, 48),
+    ('wobbuffet', 33, 58),
+    ('girafarig', 90, 65),
+    ('pineco', 35, 35),
+    ('forretress', 60, 60),
+    ('dunsparce', 65, 65),
+    ('gligar', 35, 65),
+    ('steelix', 55, 65),
+    ('snubbull', 40, 40),
+    ('granbull', 60, 60),
+    ('qwilfish', 55, 55),
+    ('scizor', 55, 80),
+    ('shuckle', 10, 230),
+    ('heracross', 40, 95),
+    ('sneasel', 35, 75),
+    ('teddiursa', 50, 50),
+    ('ursaring', 75, 75),
+    ('slugma', 70, 40),
+    ('magcargo', 90, 80),
+    ('swinub', 30, 30),
+    ('piloswine', 60, 60),
+    ('corsola', 65, 95),
+    ('remoraid', 65, 35),
+    ('octillery', 105, 75),
+    ('delibird', 65, 45),
+    ('mantine', 80, 140),
+    ('skarmory', 40, 70),
+    ('houndour', 80, 50),
+    ('houndoom', 110, 80),
+    ('kingdra', 95, 95),
+    ('phanpy', 40, 40),
+    ('donphan', 60, 60),
+    ('porygon2', 105, 95),
+    ('stantler', 85, 65),
+    ('smeargle', 20, 45),
+    ('tyrogue', 35, 35),
+    ('hitmontop', 35, 110),
+    ('smoochum', 85, 65),
+    ('elekid', 65, 55),
+    ('magby', 70, 55),
+    ('miltank', 40, 70),
+    ('blissey', 75, 135),
+    ('raikou', 115, 100),
+    ('entei', 90, 75),
+    ('suicune', 90, 115),
+    ('larvitar', 45, 50),
+    ('pupitar', 65, 70),
+    ('tyranitar', 95, 100),
+    ('lugia', 90, 154),
+    ('ho-oh', 110, 154),
+    ('celebi', 100, 100),
+    ('treecko', 65, 55),
+    ('grovyle', 85, 65),
+    ('sceptile', 105, 85),
+    ('torchic', 70, 50),
+    ('combusken', 85, 60),
+    ('blaziken', 110, 70),
+    ('mudkip', 50, 50),
+    ('marshtomp', 60, 70),
+    ('swampert', 85, 90),
+    ('poochyena', 30, 30),
+    ('mightyena', 60, 60),
+    ('zigzagoon', 30, 41),
+    ('linoone', 50, 61),
+    ('wurmple', 20, 30),
+    ('silcoon', 25, 25),
+    ('beautifly', 100, 50),
+    ('cascoon', 25, 25),
+    ('dustox', 50, 90),
+    ('lotad', 40, 50),
+    ('lombre', 60, 70),
+    ('ludicolo', 90, 100),
+    ('seedot', 30, 30),
+    ('nuzleaf', 60, 40),
+    ('shiftry', 90, 60),
+    ('taillow', 30, 30),
+    ('swellow', 75, 50),
+    ('wingull', 55, 30),
+    ('pelipper', 95, 70),
+    ('ralts', 45, 35),
+    ('kirlia', 65, 55),
+    ('gardevoir', 125, 115),
+    ('surskit', 50, 52),
+    ('masquerain', 100, 82),
+    ('shroomish', 40, 60),
+    ('breloom', 60, 60),
+    ('slakoth', 35, 35),
+    ('vigoroth', 55, 55),
+    ('slaking', 95, 65),
+    ('nincada', 30, 30),
+    ('ninjask', 50, 50),
+    ('shedinja', 30, 30),
+    ('whismur', 51, 23),
+    ('loudred', 71, 43),
+    ('exploud', 91, 73),
+    ('makuhita', 20, 30),
+    ('hariyama', 40, 60),
+    ('azurill', 20, 40),
+    ('nosepass', 45, 90),
+    ('skitty', 35, 35),
+    ('delcatty', 55, 55),
+    ('sableye', 65, 65),
+    ('mawile', 55, 55),
+    ('aron', 40, 40),
+    ('lairon', 50, 50),
+    ('aggron', 60, 60),
+    ('meditite', 40, 55),
+    ('medicham', 60, 75),
+    ('electrike', 65, 40),
+    ('manectric', 105, 60),
+    ('plusle', 85, 75),
+    ('minun', 75, 85),
+    ('volbeat', 47, 85),
+    ('illumise', 73, 85),
+    ('roselia', 100, 80),
+    ('gulpin', 43, 53),
+    ('swalot', 73, 83),
+    ('carvanha', 65, 20),
+    ('sharpedo', 95, 40),
+    ('wailmer', 70, 35),
+    ('wailord', 90, 45),
+    ('numel', 65, 45),
+    ('camerupt', 105, 75),
+    ('torkoal', 85, 70),
+    ('spoink', 70, 80),
+    ('grumpig', 90, 110),
+    ('spinda', 60, 60),
+    ('trapinch', 45, 45),
+    ('vibrava', 50, 50),
+    ('flygon', 80, 80),
+    ('cacnea', 85, 40),
+    ('cacturne', 115, 60),
+    ('swablu', 40, 75),
+    ('altaria', 70, 105),
+    ('zangoose', 60, 60),
+    ('seviper', 100, 60),
+    ('lunatone', 95, 85),
+    ('solrock', 55, 65),
+    ('barboach', 46, 41),
+    ('whiscash', 76, 71),
+    ('corphish', 50, 35),
+    ('crawdaunt', 90, 55),
+    ('baltoy', 40, 70),
+    ('claydol', 70, 120),
+    ('lileep', 61, 87),
+    ('cradily', 81, 107),
+    ('anorith', 40, 50),
+    ('armaldo', 70, 80),
+    ('feebas', 10, 55),
+    ('milotic', 100, 125),
+    ('castform', 70, 70),
+    ('kecleon', 60, 120),
+    ('shuppet', 63, 33),
+    ('banette', 83, 63),
+    ('duskull', 30, 90),
+    ('dusclops', 60, 130),
+    ('tropius', 72, 87),
+    ('chimecho', 95, 90),
+    ('absol', 75, 60),
+    ('wynaut', 23, 48),
+    ('snorunt', 50, 50),
+    ('glalie', 80, 80),
+    ('spheal', 55, 50),
+    ('sealeo', 75, 70),
+    ('walrein', 95, 90),
+    ('clamperl', 74, 55),
+    ('huntail', 94, 75),
+    ('gorebyss', 114, 75),
+    ('relicanth', 45, 65),
+    ('luvdisc', 40, 65),
+    ('bagon', 40, 30),
+    ('shelgon', 60, 50),
+    ('salamence', 110, 80),
+    ('beldum', 35, 60),
+    ('metang', 55, 80),
+    ('metagross', 95, 90),
+    ('regirock', 50, 100),
+    ('regice', 100, 200),
+    ('registeel', 75, 150),
+    ('latias', 110, 130),
+    ('latios', 130, 110),
+    ('kyogre', 150, 140),
+    ('groudon', 100, 90),
+    ('rayquaza', 150, 90),
+    ('jirachi', 100, 100),
+    ('deoxys-normal', 150, 50),
+    ('turtwig', 45, 55),
+    ('grotle', 55, 65),
+    ('torterra', 75, 85),
+    ('chimchar', 58, 44),
+    ('monferno', 78, 52),
+    ('infernape', 104, 71),
+    ('piplup', 61, 56),
+    ('prinplup', 81, 76),
+    ('empoleon', 111, 101),
+    ('starly', 30, 30),
+    ('staravia', 40, 40),
+    ('staraptor', 50, 60),
+    ('bidoof', 35, 40),
+    ('bibarel', 55, 60),
+    ('kricketot', 25, 41),
+    ('kricketune', 55, 51),
+    ('shinx', 40, 34),
+    ('luxio', 60, 49),
+    ('luxray', 95, 79),
+    ('budew', 50, 70),
+    ('roserade', 125, 105),
+    ('cranidos', 30, 30),
+    ('rampardos', 65, 50),
+    ('shieldon', 42, 88),
+    ('bastiodon', 47, 138),
+    ('burmy', 29, 45),
+    ('wormadam-plant', 79, 105),
+    ('mothim', 94, 50),
+    ('combee', 30, 42),
+    ('vespiquen', 80, 102),
+    ('pachirisu', 45, 90),
+    ('buizel', 60, 30),
+    ('floatzel', 85, 50),
+    ('cherubi', 62, 53),
+    ('cherrim', 87, 78),
+    ('shellos', 57, 62),
+    ('gastrodon', 92, 82),
+    ('ambipom', 60, 66),
+    ('drifloon', 60, 44),
+    ('drifblim', 90, 54),
+    ('buneary', 44, 56),
+    ('lopunny', 54, 96),
+    ('mismagius', 105, 105),
+    ('honchkrow', 105, 52),
+    ('glameow', 42, 37),
+    ('purugly', 64, 59),
+    ('chingling', 65, 50),
+    ('stunky', 41, 41),
+    ('skuntank', 71, 61),
+    ('bronzor', 24, 86),
+    ('bronzong', 79, 116),
+    ('bonsly', 10, 45),
+    ('mime-jr', 70, 90),
+    ('happiny', 15, 65),
+    ('chatot', 92, 42),
+    ('spiritomb', 92, 108),
+    ('gible', 40, 45),
+    ('gabite', 50, 55),
+    ('garchomp', 80, 85),
+    ('munchlax', 40, 85),
+    ('riolu', 35, 40),
+    ('lucario', 115, 70),
+    ('hippopotas', 38, 42),
+    ('hippowdon', 68, 72),
+    ('skorupi', 30, 55),
+    ('drapion', 60, 75),
+    ('croagunk', 61, 40),
+    ('toxicroak', 86, 65),
+    ('carnivine', 90, 72),
+    ('finneon', 49, 61),
+    ('lumineon', 69, 86),
+    ('mantyke', 60, 120),
+    ('snover', 62, 60),
+    ('abomasnow', 92, 85),
+    ('weavile', 45, 85),
+    ('magnezone', 130, 90),
+    ('lickilicky', 80, 95),
+    ('rhyperior', 55, 55),
+    ('tangrowth', 110, 50),
+    ('electivire', 95, 85),
+    ('magmortar', 125, 95),
+    ('togekiss', 120, 115),
+    ('yanmega', 116, 56),
+    ('leafeon', 60, 65),
+    ('glaceon', 130, 95),
+    ('gliscor', 45, 75),
+    ('mamoswine', 70, 60),
+    ('porygon-z', 135, 75),
+    ('gallade', 65, 115),
+    ('probopass', 75, 150),
+    ('dusknoir', 65, 135),
+    ('froslass', 80, 70),
+    ('rotom', 95, 77),
+    ('uxie', 75, 130),
+    ('mesprit', 105, 105),
+    ('azelf', 125, 70),
+    ('dialga', 150, 100),
+    ('palkia', 150, 120),
+    ('heatran', 130, 106),
+    ('regigigas', 80, 110),
+    ('giratina-altered', 100, 120),
+    ('cresselia', 75, 120),
+    ('phione', 80, 80),
+    ('manaphy', 100, 100),
+    ('darkrai', 135, 90),
+    ('shaymin-land', 100, 100),
+    ('arceus', 120, 120),
+    ('victini', 100, 100),
+    ('snivy', 45, 55),
+    ('servine', 60, 75),
+    ('serperior', 75, 95),
+    ('tepig', 45, 45),
+    ('pignite', 70, 55),
+    ('emboar', 100, 65),
+    ('oshawott', 63, 45),
+    ('dewott', 83, 60),
+    ('samurott', 108, 70),
+    ('patrat', 35, 39),
+    ('watchog', 60, 69),
+    ('lillipup', 25, 45),
+    ('herdier', 35, 65),
+    ('stoutland', 45, 90),
+    ('purrloin', 50, 37),
+    ('liepard', 88, 50),
+    ('pansage', 53, 48),
+    ('simisage', 98, 63),
+    ('pansear', 53, 48),
+    ('simisear', 98, 63),
+    ('panpour', 53, 48),
+    ('simipour', 98, 63),
+    ('munna', 67, 55),
+    ('musharna', 107, 95),
+    ('pidove', 36, 30),
+    ('tranquill', 50, 42),
+    ('unfezant', 65, 55),
+    ('blitzle', 50, 32),
+    ('zebstrika', 80, 63),
+    ('roggenrola', 25, 25),
+    ('boldore', 50, 40),
+    ('gigalith', 60, 80),
+    ('woobat', 55, 43),
+    ('swoobat', 77, 55),
+    ('drilbur', 30, 45),
+    ('excadrill', 50, 65),
+    ('audino', 60, 86),
+    ('timburr', 25, 35),
+    ('gurdurr', 40, 50),
+    ('conkeldurr', 55, 65),
+    ('tympole', 50, 40),
+    ('palpitoad', 65, 55),
+    ('seismitoad', 85, 75),
+    ('throh', 30, 85),
+    ('sawk', 30, 75),
+    ('sewaddle', 40, 60),
+    ('swadloon', 50, 80),
+    ('leavanny', 70, 80),
+    ('venipede', 30, 39),
+    ('whirlipede', 40, 79),
+    ('scolipede', 55, 69),
+    ('cottonee', 37, 50),
+    ('whimsicott', 77, 75),
+    ('petilil', 70, 50),
+    ('lilligant', 110, 75),
+    ('basculin-red-striped', 80, 55),
+    ('sandile', 35, 35),
+    ('krokorok', 45, 45),
+    ('krookodile', 65, 70),
+    ('darumaka', 15, 45),
+    ('darmanitan-standard', 30, 55),
+    ('maractus', 106, 67),
+    ('dwebble', 35, 35),
+    ('crustle', 65, 75),
+    ('scraggy', 35, 70),
+    ('scrafty', 45, 115),
+    ('sigilyph', 103, 80),
+    ('yamask', 55, 65),
+    ('cofagrigus', 95, 105),
+    ('tirtouga', 53, 45),
+    ('carracosta', 83, 65),
+    ('archen', 74, 45),
+    ('archeops', 112, 65),
+    ('trubbish', 40, 62),
+    ('garbodor', 60, 82),
+    ('zorua', 80, 40),
+    ('zoroark', 120, 60),
+    ('minccino', 40, 40),
+    ('cinccino', 65, 60),
+    ('gothita', 55, 65),
+    ('gothorita', 75, 85),
+    ('gothitelle', 95, 110),
+    ('solosis', 105, 50),
+    ('duosion', 125, 60),
+    ('reuniclus', 125, 85),
+    ('ducklett', 44, 50),
+    ('swanna', 87, 63),
+    ('vanillite', 65, 60),
+    ('vanillish', 80, 75),
+    ('vanilluxe', 110, 95),
+    ('deerling', 40, 50),
+    ('sawsbuck', 60, 70),
+    ('emolga', 75, 60),
+    ('karrablast', 40, 45),
+    ('escavalier', 60, 105),
+    ('foongus', 55, 55),
+    ('amoonguss', 85, 80),
+    ('frillish', 65, 85),
+    ('jellicent', 85, 105),
+    ('alomomola', 40, 45),
+    ('joltik', 57, 50),
+    ('galvantula', 97, 60),
+    ('ferroseed', 24, 86),
+    ('ferrothorn', 54, 116),
+    ('klink', 45, 60),
+    ('klang', 70, 85),
+    ('klinklang', 70, 85),
+    ('tynamo', 45, 40),
+    ('eelektrik', 75, 70),
+    ('eelektross', 105, 80),
+    ('elgyem', 85, 55),
+    ('beheeyem', 125, 95),
+    ('litwick', 65, 55),
+    ('lampent', 95, 60),
+    ('chandelure', 145, 90),
+    ('axew', 30, 40),
+    ('fraxure', 40, 50),
+    ('haxorus', 60, 70),
+    ('cubchoo', 60, 40),
+    ('beartic', 70, 80),
+    ('cryogonal', 95, 135),
+    ('shelmet', 40, 65),
+    ('accelgor', 100, 60),
+    ('stunfisk', 81, 99),
+    ('mienfoo', 55, 50),
+    ('mienshao', 95, 60),
+    ('druddigon', 60, 90),
+    ('golett', 35, 50),
+    ('golurk', 55, 80),
+    ('pawniard', 40, 40),
+    ('bisharp', 60, 70),
+    ('bouffalant', 40, 95),
+    ('rufflet', 37, 50),
+    ('braviary', 57, 75),
+    ('vullaby', 45, 65),
+    ('mandibuzz', 55, 95),
+    ('heatmor', 105, 66),
+    ('durant', 48, 48),
+    ('deino', 45, 50),
+    ('zweilous', 65, 70),
+    ('hydreigon', 125, 90),
+    ('larvesta', 50, 55),
+    ('volcarona', 135, 105),
+    ('cobalion', 90, 72),
+    ('terrakion', 72, 90),
+    ('virizion', 90, 129),
+    ('tornadus-incarnate', 125, 80),
+    ('thundurus-incarnate', 125, 80),
+    ('reshiram', 150, 120),
+    ('zekrom', 120, 100),
+    ('landorus-incarnate', 115, 80),
+    ('kyurem', 130, 90),
+    ('keldeo-ordinary', 129, 90),
+    ('meloetta-aria', 128, 128),
+    ('genesect', 120, 95)
+) AS species (pokemon_name, sp_attack, sp_defense)
+WHERE player_cards.pokemon_name = species.pokemon_name
+  AND player_cards.base_sp_attack = player_cards.base_attack
+  AND player_cards.base_sp_defense = player_cards.base_defense;
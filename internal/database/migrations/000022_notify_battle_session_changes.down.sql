@@ -0,0 +1,3 @@
+-- Drop trigger and function
+DROP TRIGGER IF EXISTS notify_battle_session_change ON battle_sessions;
+DROP FUNCTION IF EXISTS notify_battle_session_change();
@@ -0,0 +1,17 @@
+-- Notify every change to a battle session on the battle_sessions channel, so
+-- each API server can push it to the WebSocket clients watching the battle,
+-- whichever server or job made it
+CREATE OR REPLACE FUNCTION notify_battle_session_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('battle_sessions', json_build_object('session_id', OLD.session_id, 'deleted', TRUE)::text);
+        RETURN OLD;
+    END IF;
+    PERFORM pg_notify('battle_sessions', json_build_object('session_id', NEW.session_id, 'deleted', FALSE)::text);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER notify_battle_session_change AFTER INSERT OR UPDATE OR DELETE ON battle_sessions
+    FOR EACH ROW EXECUTE FUNCTION notify_battle_session_change();
@@ -0,0 +1,26 @@
+-- Fold the AI's sacrifice counts back into the shared ones, keeping the
+-- higher count for each deck index
+UPDATE battle_sessions
+SET state_json = jsonb_set(
+    state_json - 'ai_sacrifice_count',
+    '{sacrifice_count}',
+    COALESCE((
+        SELECT jsonb_object_agg(idx, most)
+        FROM (
+            SELECT idx, MAX(n::int) AS most
+            FROM (
+                SELECT key AS idx, value AS n FROM jsonb_each_text(CASE
+                    WHEN jsonb_typeof(state_json->'sacrifice_count') = 'object' THEN state_json->'sacrifice_count'
+                    ELSE '{}'::jsonb
+                END)
+                UNION ALL
+                SELECT key, value FROM jsonb_each_text(CASE
+                    WHEN jsonb_typeof(state_json->'ai_sacrifice_count') = 'object' THEN state_json->'ai_sacrifice_count'
+                    ELSE '{}'::jsonb
+                END)
+            ) AS counts
+            GROUP BY idx
+        ) AS merged
+    ), '{}'::jsonb)
+)
+WHERE state_json ? 'ai_sacrifice_count';
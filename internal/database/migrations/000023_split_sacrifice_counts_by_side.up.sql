@@ -0,0 +1,8 @@
+-- Battle sessions used to count the sacrifices of both sides' Pokemon in
+-- sacrifice_count, by deck index alone. Counts are now kept per side, the AI's
+-- (or PvP opponent's) in ai_sacrifice_count. A stored count may have been made
+-- by either side, so both sides keep it: no Pokemon gets more sacrifices than
+-- it was allowed.
+UPDATE battle_sessions
+SET state_json = jsonb_set(state_json, '{ai_sacrifice_count}', COALESCE(state_json->'sacrifice_count', '{}'::jsonb))
+WHERE NOT state_json ? 'ai_sacrifice_count';
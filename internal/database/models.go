@@ -2,6 +2,8 @@ package database
 
 import (
 	"encoding/json"
+	"pokemon-cli/internal/progression"
+	"pokemon-cli/internal/ruleset"
 	"time"
 )
 
@@ -18,63 +20,86 @@ type User struct {
 
 // PlayerCard represents a Pokemon card owned by a player
 type PlayerCard struct {
-	ID           int             `json:"id"`
-	UserID       int             `json:"user_id"`
-	PokemonName  string          `json:"pokemon_name"`
-	Level        int             `json:"level"`
-	XP           int             `json:"xp"`
-	BaseHP       int             `json:"base_hp"`
-	BaseAttack   int             `json:"base_attack"`
-	BaseDefense  int             `json:"base_defense"`
-	BaseSpeed    int             `json:"base_speed"`
-	Types        json.RawMessage `json:"types"`
-	Moves        json.RawMessage `json:"moves"`
-	Sprite       string          `json:"sprite"`
-	IsLegendary  bool            `json:"is_legendary"`
-	IsMythical   bool            `json:"is_mythical"`
-	InDeck       bool            `json:"in_deck"`
-	DeckPosition *int            `json:"deck_position,omitempty"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
+	ID            int             `json:"id"`
+	UserID        int             `json:"user_id"`
+	PokemonName   string          `json:"pokemon_name"`
+	Level         int             `json:"level"`
+	XP            int             `json:"xp"`
+	BaseHP        int             `json:"base_hp"`
+	BaseAttack    int             `json:"base_attack"`
+	BaseDefense   int             `json:"base_defense"`
+	BaseSpeed     int             `json:"base_speed"`
+	BaseSpAttack  int             `json:"base_sp_attack"`
+	BaseSpDefense int             `json:"base_sp_defense"`
+	Types         json.RawMessage `json:"types"`
+	Moves         json.RawMessage `json:"moves"`
+	Sprite        string          `json:"sprite"`
+	IsLegendary   bool            `json:"is_legendary"`
+	IsMythical    bool            `json:"is_mythical"`
+	InDeck        bool            `json:"in_deck"`
+	DeckPosition  *int            `json:"deck_position,omitempty"`
+	HeldItem      string          `json:"held_item,omitempty"` // Held item the card brings into battle, if any
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
 }
 
 // CardStats represents computed stats for a card at its current level
 type CardStats struct {
-	HP      int `json:"hp"`
-	Attack  int `json:"attack"`
-	Defense int `json:"defense"`
-	Speed   int `json:"speed"`
-	Stamina int `json:"stamina"`
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"sp_attack"`
+	SpDefense int `json:"sp_defense"`
+	Speed     int `json:"speed"`
+	Stamina   int `json:"stamina"`
 }
 
 // GetCurrentStats calculates current stats based on level
 func (c *PlayerCard) GetCurrentStats() CardStats {
-	levelMultiplier := float64(c.Level - 1)
-
-	hp := int(float64(c.BaseHP) * (1.0 + levelMultiplier*0.03))
-	attack := int(float64(c.BaseAttack) * (1.0 + levelMultiplier*0.02))
-	defense := int(float64(c.BaseDefense) * (1.0 + levelMultiplier*0.02))
-	speed := int(float64(c.BaseSpeed) * (1.0 + levelMultiplier*0.01))
-	stamina := speed * 2
-
-	return CardStats{
-		HP:      hp,
-		Attack:  attack,
-		Defense: defense,
-		Speed:   speed,
-		Stamina: stamina,
-	}
+	stats := ruleset.Current().Progression.Stats(c.Level, progression.Stats{
+		HP:        c.BaseHP,
+		Attack:    c.BaseAttack,
+		Defense:   c.BaseDefense,
+		SpAttack:  c.BaseSpAttack,
+		SpDefense: c.BaseSpDefense,
+		Speed:     c.BaseSpeed,
+	})
+	return CardStats(stats)
 }
 
 // BattleHistory represents a battle record
 type BattleHistory struct {
-	ID          int       `json:"id"`
-	UserID      int       `json:"user_id"`
-	Mode        string    `json:"mode"`
-	Result      string    `json:"result"`
-	CoinsEarned int       `json:"coins_earned"`
-	Duration    *int      `json:"duration,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID           int       `json:"id"`
+	UserID       int       `json:"user_id"`
+	Mode         string    `json:"mode"`
+	Result       string    `json:"result"`
+	CoinsEarned  int       `json:"coins_earned"`
+	Duration     *int      `json:"duration,omitempty"`
+	AIDifficulty *string   `json:"ai_difficulty,omitempty"` // nil for PvP battles
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// BattleTurn represents one turn of a recorded battle timeline
+type BattleTurn struct {
+	ID                 int    `json:"id"`
+	BattleHistoryID    int    `json:"battle_history_id"`
+	Seq                int    `json:"seq"`
+	Turn               int    `json:"turn"`
+	Round              int    `json:"round"`
+	PlayerPokemon      string `json:"player_pokemon"`
+	AIPokemon          string `json:"ai_pokemon"`
+	PlayerMove         string `json:"player_move"`
+	PlayerMoveName     string `json:"player_move_name,omitempty"`
+	AIMove             string `json:"ai_move,omitempty"`
+	AIMoveName         string `json:"ai_move_name,omitempty"`
+	DamageDealt        int    `json:"damage_dealt"`
+	DamageTaken        int    `json:"damage_taken"`
+	PlayerHPDelta      int    `json:"player_hp_delta"`
+	PlayerStaminaDelta int    `json:"player_stamina_delta"`
+	AIHPDelta          int    `json:"ai_hp_delta"`
+	AIStaminaDelta     int    `json:"ai_stamina_delta"`
+	PlayerKnockedOut   bool   `json:"player_knocked_out"`
+	AIKnockedOut       bool   `json:"ai_knocked_out"`
 }
 
 // PlayerStats represents player statistics
@@ -91,9 +116,60 @@ type PlayerStats struct {
 	TotalCoinsEarned  int       `json:"total_coins_earned"`
 	HighestLevel      int       `json:"highest_level"`
 	ConsecutiveLosses int       `json:"consecutive_losses"`
+	SeasonWins        int       `json:"season_wins"` // Counters of the current season, reset when it closes
+	SeasonLosses      int       `json:"season_losses"`
+	SeasonDraws       int       `json:"season_draws"`
+	SeasonWinStreak   int       `json:"season_win_streak"`
+	SeasonBestStreak  int       `json:"season_best_win_streak"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
+// Season represents a ranked season
+type Season struct {
+	ID       int        `json:"id"`
+	Name     string     `json:"name"`
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   time.Time  `json:"ends_at"`
+	Status   string     `json:"status"` // "active" or "closed"
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+}
+
+// SeasonSnapshot represents a player's record in a closed season
+type SeasonSnapshot struct {
+	SeasonID      int       `json:"season_id"`
+	SeasonName    string    `json:"season_name,omitempty"`
+	UserID        int       `json:"user_id"`
+	Wins          int       `json:"wins"`
+	Losses        int       `json:"losses"`
+	Draws         int       `json:"draws"`
+	BestWinStreak int       `json:"best_win_streak"`
+	Tier          string    `json:"tier"`
+	CoinsRewarded int       `json:"coins_rewarded"`
+	CardsRewarded int       `json:"cards_rewarded"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// LeaderboardEntry represents a player's place on a rating ladder
+type LeaderboardEntry struct {
+	Rank      int     `json:"rank"`
+	UserID    int     `json:"user_id"`
+	Username  string  `json:"username"`
+	Rating    float64 `json:"rating"`
+	Deviation float64 `json:"deviation"`
+	Games     int     `json:"games"`
+}
+
+// MatchmakingEntry represents a user's place in the matchmaking queue
+type MatchmakingEntry struct {
+	UserID    int        `json:"user_id"`
+	Mode      string     `json:"mode"`
+	WinRate   float64    `json:"win_rate"`
+	Status    string     `json:"status"` // "waiting" or "matched"
+	BattleID  *string    `json:"battle_id,omitempty"`
+	JoinedAt  time.Time  `json:"joined_at"`
+	MatchedAt *time.Time `json:"matched_at,omitempty"`
+}
+
 // Achievement represents an achievement definition
 type Achievement struct {
 	ID               int    `json:"id"`
@@ -117,3 +193,10 @@ type AchievementWithStatus struct {
 	Unlocked   bool       `json:"unlocked"`
 	UnlockedAt *time.Time `json:"unlocked_at,omitempty"`
 }
+
+// UserItem is a battle item in a player's bag
+type UserItem struct {
+	UserID   int    `json:"user_id"`
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
@@ -0,0 +1,159 @@
+package matchmaking
+
+import (
+	"errors"
+	"pokemon-cli/internal/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles HTTP requests for the matchmaking queue
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new matchmaking handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Join handles POST /api/matchmaking/join
+func (h *Handler) Join(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	var req struct {
+		Mode string `json:"mode"` // "1v1" or "5v5"
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_REQUEST",
+				"message": "Invalid request body",
+			},
+		})
+	}
+
+	entry, err := h.service.Join(c.Context(), userID, strings.TrimSpace(req.Mode))
+	if err != nil {
+		var deckErr *DeckError
+		switch {
+		case errors.Is(err, ErrInvalidMode):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_MODE",
+					"message": err.Error(),
+				},
+			})
+		case errors.As(err, &deckErr):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_DECK",
+					"message": err.Error(),
+				},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to join the matchmaking queue",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(statusResponse(entry))
+}
+
+// Leave handles POST /api/matchmaking/leave
+func (h *Handler) Leave(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	if err := h.service.Leave(c.Context(), userID); err != nil {
+		if errors.Is(err, ErrNotQueued) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_QUEUED",
+					"message": "You are not in the matchmaking queue",
+				},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to leave the matchmaking queue",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Left the matchmaking queue"})
+}
+
+// Status handles GET /api/matchmaking/status. Once the user is matched it
+// returns the battle to play through /api/battle/move.
+func (h *Handler) Status(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	entry, err := h.service.Status(c.Context(), userID)
+	if errors.Is(err, ErrNotQueued) {
+		return c.JSON(fiber.Map{"status": "idle"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to retrieve matchmaking status",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(statusResponse(entry))
+}
+
+// statusResponse describes a user's place in the queue, including how wide
+// their pairing window has grown while waiting
+func statusResponse(entry *database.MatchmakingEntry) fiber.Map {
+	response := fiber.Map{
+		"status":    entry.Status,
+		"mode":      entry.Mode,
+		"win_rate":  entry.WinRate,
+		"joined_at": entry.JoinedAt,
+	}
+	if entry.BattleID != nil {
+		response["battle_id"] = *entry.BattleID
+		response["matched_at"] = entry.MatchedAt
+		return response
+	}
+
+	now := time.Now()
+	response["waited_seconds"] = int(now.Sub(entry.JoinedAt).Seconds())
+	response["window"] = Window(entry.JoinedAt, now)
+	return response
+}
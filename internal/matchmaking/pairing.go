@@ -0,0 +1,66 @@
+package matchmaking
+
+import (
+	"math"
+	"pokemon-cli/internal/database"
+	"time"
+)
+
+// A user is only paired with opponents whose win rate is within their window.
+// The window starts narrow and widens the longer they wait, until anyone goes.
+const (
+	baseWindow   = 0.05             // Win rate difference accepted as soon as a user joins
+	windowGrowth = 0.05             // Added to the window for every windowStep waited
+	windowStep   = 10 * time.Second // How often the window widens
+	maxWindow    = 1.0              // Covers every win rate
+)
+
+// Match is two queued users paired into a battle. UserID joined first and
+// plays the "player" side.
+type Match struct {
+	UserID     int
+	OpponentID int
+}
+
+// Window returns how far from their own win rate a user who joined at joinedAt accepts opponents at now
+func Window(joinedAt, now time.Time) float64 {
+	steps := math.Floor(now.Sub(joinedAt).Seconds() / windowStep.Seconds())
+	if steps < 0 {
+		steps = 0
+	}
+	return math.Min(baseWindow+steps*windowGrowth, maxWindow)
+}
+
+// Pair pairs up the users waiting in one mode, given oldest first. Each user
+// in turn is paired with the unpaired user closest to their win rate, as long
+// as the difference is within the wider of the two users' windows, so someone
+// who has waited long enough can be paired with a newcomer.
+func Pair(waiting []database.MatchmakingEntry, now time.Time) []Match {
+	var matches []Match
+	paired := make([]bool, len(waiting))
+
+	for i, entry := range waiting {
+		if paired[i] {
+			continue
+		}
+
+		best, bestDiff := -1, 0.0
+		for j := i + 1; j < len(waiting); j++ {
+			if paired[j] {
+				continue
+			}
+			diff := math.Abs(entry.WinRate - waiting[j].WinRate)
+			window := math.Max(Window(entry.JoinedAt, now), Window(waiting[j].JoinedAt, now))
+			if diff <= window && (best < 0 || diff < bestDiff) {
+				best, bestDiff = j, diff
+			}
+		}
+
+		if best >= 0 {
+			paired[i], paired[best] = true, true
+			matches = append(matches, Match{UserID: entry.UserID, OpponentID: waiting[best].UserID})
+		}
+	}
+
+	return matches
+}
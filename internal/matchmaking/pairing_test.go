@@ -0,0 +1,68 @@
+package matchmaking
+
+import (
+	"math"
+	"pokemon-cli/internal/database"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWindowWidensWhileWaiting(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		waited time.Duration
+		want   float64
+	}{
+		{"just joined", 0, baseWindow},
+		{"under one step", 9 * time.Second, baseWindow},
+		{"two steps", 25 * time.Second, baseWindow + 2*windowGrowth},
+		{"capped", time.Hour, maxWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Window(now.Add(-tt.waited), now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Window() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPairPrefersClosestWinRate(t *testing.T) {
+	now := time.Now()
+	waiting := []database.MatchmakingEntry{
+		{UserID: 1, WinRate: 0.50, JoinedAt: now},
+		{UserID: 2, WinRate: 0.90, JoinedAt: now},
+		{UserID: 3, WinRate: 0.53, JoinedAt: now},
+		{UserID: 4, WinRate: 0.51, JoinedAt: now},
+	}
+
+	got := Pair(waiting, now)
+	want := []Match{{UserID: 1, OpponentID: 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pair() = %v, want %v", got, want)
+	}
+}
+
+func TestPairWidensWindowForLongWaits(t *testing.T) {
+	now := time.Now()
+	waiting := []database.MatchmakingEntry{
+		{UserID: 1, WinRate: 0.20, JoinedAt: now.Add(-time.Minute)},
+		{UserID: 2, WinRate: 0.80, JoinedAt: now},
+		{UserID: 3, WinRate: 0.83, JoinedAt: now},
+	}
+
+	// A minute in, user 1 accepts anyone within 0.35, which is still too narrow
+	if got := Pair(waiting, now); len(got) != 1 || got[0] != (Match{UserID: 2, OpponentID: 3}) {
+		t.Fatalf("Pair() = %v, want only users 2 and 3 paired", got)
+	}
+
+	// Later on user 1's window covers user 2, who is then the closest
+	got := Pair(waiting, now.Add(time.Minute))
+	want := []Match{{UserID: 1, OpponentID: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pair() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+package matchmaking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pokemon-cli/internal/database"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotQueued is returned when a user is not in the matchmaking queue
+var ErrNotQueued = errors.New("not in the matchmaking queue")
+
+// Repository handles database operations for the matchmaking queue
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+// NewRepository creates a new matchmaking repository
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// Begin starts the transaction a pairing pass runs in
+func (r *Repository) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// GetWinRate returns the share of the user's battles in mode they won.
+// Users who haven't played the mode yet are placed in the middle, at 0.5.
+func (r *Repository) GetWinRate(ctx context.Context, userID int, mode string) (float64, error) {
+	var wins, total int
+	var err error
+	if mode == "1v1" {
+		err = r.db.QueryRow(ctx, `
+			SELECT COALESCE(wins_1v1, 0), COALESCE(total_battles_1v1, 0) FROM player_stats WHERE user_id = $1
+		`, userID).Scan(&wins, &total)
+	} else {
+		err = r.db.QueryRow(ctx, `
+			SELECT COALESCE(wins_5v5, 0), COALESCE(total_battles_5v5, 0) FROM player_stats WHERE user_id = $1
+		`, userID).Scan(&wins, &total)
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get player stats: %w", err)
+	}
+
+	if total == 0 {
+		return 0.5, nil
+	}
+	return float64(wins) / float64(total), nil
+}
+
+// Join puts the user in the queue for mode, replacing any earlier entry
+func (r *Repository) Join(ctx context.Context, userID int, mode string, winRate float64) (*database.MatchmakingEntry, error) {
+	query := `
+		INSERT INTO matchmaking_queue (user_id, mode, win_rate, status, battle_id, joined_at, matched_at)
+		VALUES ($1, $2, $3, 'waiting', NULL, NOW(), NULL)
+		ON CONFLICT (user_id) DO UPDATE
+		SET mode = EXCLUDED.mode,
+		    win_rate = EXCLUDED.win_rate,
+		    status = 'waiting',
+		    battle_id = NULL,
+		    joined_at = NOW(),
+		    matched_at = NULL
+		RETURNING user_id, mode, win_rate, status, battle_id, joined_at, matched_at
+	`
+
+	var entry database.MatchmakingEntry
+	err := r.db.QueryRow(ctx, query, userID, mode, winRate).Scan(
+		&entry.UserID, &entry.Mode, &entry.WinRate, &entry.Status, &entry.BattleID, &entry.JoinedAt, &entry.MatchedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join queue: %w", err)
+	}
+	return &entry, nil
+}
+
+// Leave removes the user from the queue
+func (r *Repository) Leave(ctx context.Context, userID int) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM matchmaking_queue WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to leave queue: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotQueued
+	}
+	return nil
+}
+
+// GetEntry returns the user's place in the queue
+func (r *Repository) GetEntry(ctx context.Context, userID int) (*database.MatchmakingEntry, error) {
+	query := `
+		SELECT user_id, mode, win_rate, status, battle_id, joined_at, matched_at
+		FROM matchmaking_queue
+		WHERE user_id = $1
+	`
+
+	var entry database.MatchmakingEntry
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&entry.UserID, &entry.Mode, &entry.WinRate, &entry.Status, &entry.BattleID, &entry.JoinedAt, &entry.MatchedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotQueued
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// GetWaitingInTx returns the users waiting for a battle in mode, oldest first.
+// Their rows stay locked until tx ends, so two servers never pair the same user.
+func (r *Repository) GetWaitingInTx(ctx context.Context, tx pgx.Tx, mode string) ([]database.MatchmakingEntry, error) {
+	query := `
+		SELECT user_id, mode, win_rate, status, battle_id, joined_at, matched_at
+		FROM matchmaking_queue
+		WHERE mode = $1 AND status = 'waiting'
+		ORDER BY joined_at ASC
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.Query(ctx, query, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting users: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []database.MatchmakingEntry
+	for rows.Next() {
+		var entry database.MatchmakingEntry
+		err := rows.Scan(&entry.UserID, &entry.Mode, &entry.WinRate, &entry.Status, &entry.BattleID, &entry.JoinedAt, &entry.MatchedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queue entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MarkMatchedInTx records the battle two users were paired into
+func (r *Repository) MarkMatchedInTx(ctx context.Context, tx pgx.Tx, battleID string, userID, opponentID int) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE matchmaking_queue
+		SET status = 'matched', battle_id = $1, matched_at = NOW()
+		WHERE user_id IN ($2, $3)
+	`, battleID, userID, opponentID)
+	if err != nil {
+		return fmt.Errorf("failed to mark users as matched: %w", err)
+	}
+	return nil
+}
+
+// RemoveInTx takes a user out of the queue during a pairing pass
+func (r *Repository) RemoveInTx(ctx context.Context, tx pgx.Tx, userID int) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM matchmaking_queue WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to remove user from queue: %w", err)
+	}
+	return nil
+}
+
+// DeleteMatchedBefore removes the entries of users who were matched before cutoff
+func (r *Repository) DeleteMatchedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM matchmaking_queue WHERE status = 'matched' AND matched_at < $1
+	`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete matched entries: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,18 @@
+package matchmaking
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes registers matchmaking routes; matched battles are played through /api/battle
+func RegisterRoutes(app *fiber.App, handler *Handler, authMiddleware fiber.Handler) {
+	matchmaking := app.Group("/api/matchmaking")
+
+	matchmaking.Use(authMiddleware)
+
+	matchmaking.Post("/join", handler.Join)
+
+	matchmaking.Post("/leave", handler.Leave)
+
+	matchmaking.Get("/status", handler.Status)
+}
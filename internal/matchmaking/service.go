@@ -0,0 +1,199 @@
+package matchmaking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/database"
+	"pokemon-cli/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Modes users can queue for
+var Modes = []string{"1v1", "5v5"}
+
+const (
+	pairInterval     = 2 * time.Second // How often waiting users are paired up
+	matchedRetention = time.Hour       // How long a matched entry keeps pointing at its battle
+)
+
+// ErrInvalidMode is returned when a user queues for a mode that doesn't exist
+var ErrInvalidMode = errors.New("invalid battle mode. Must be '1v1' or '5v5'")
+
+// DeckError is returned when a user's deck can't be brought to the mode they queue for
+type DeckError struct {
+	err error
+}
+
+func (e *DeckError) Error() string {
+	return e.err.Error()
+}
+
+// Service pairs the users waiting in the matchmaking queue and starts their battles
+type Service struct {
+	repo    *Repository
+	battles *battle.Repository
+	logger  *logger.Logger
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewService creates a new matchmaking service
+func NewService(repo *Repository, battles *battle.Repository, logger *logger.Logger) *Service {
+	return &Service{repo: repo, battles: battles, logger: logger}
+}
+
+// Join puts the user in the queue for mode, at their current win rate in it
+func (s *Service) Join(ctx context.Context, userID int, mode string) (*database.MatchmakingEntry, error) {
+	if mode != "1v1" && mode != "5v5" {
+		return nil, ErrInvalidMode
+	}
+
+	deckCards, err := s.battles.GetUserDeck(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := battle.CheckDeck(mode, deckCards); err != nil {
+		return nil, &DeckError{err: err}
+	}
+
+	winRate, err := s.repo.GetWinRate(ctx, userID, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Join(ctx, userID, mode, winRate)
+}
+
+// Leave takes the user out of the queue
+func (s *Service) Leave(ctx context.Context, userID int) error {
+	return s.repo.Leave(ctx, userID)
+}
+
+// Status returns the user's place in the queue, or ErrNotQueued
+func (s *Service) Status(ctx context.Context, userID int) (*database.MatchmakingEntry, error) {
+	return s.repo.GetEntry(ctx, userID)
+}
+
+// Start runs the pairing loop in a goroutine until Stop is called
+func (s *Service) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx)
+}
+
+// Stop ends the pairing loop, waiting for the pass in progress to finish.
+// The queue stays in the database and is picked up again on the next Start.
+func (s *Service) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+}
+
+// run pairs waiting users every pairInterval until ctx is canceled
+func (s *Service) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(pairInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, mode := range Modes {
+			if err := s.pairMode(ctx, mode); err != nil && ctx.Err() == nil {
+				s.logger.Error("Matchmaking pass failed", "mode", mode, "error", err)
+			}
+		}
+
+		if _, err := s.repo.DeleteMatchedBefore(ctx, time.Now().Add(-matchedRetention)); err != nil && ctx.Err() == nil {
+			s.logger.Warn("Failed to clean up matched queue entries", "error", err)
+		}
+	}
+}
+
+// pairMode pairs the users waiting in mode and starts a battle for each pair
+func (s *Service) pairMode(ctx context.Context, mode string) error {
+	tx, err := s.repo.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	waiting, err := s.repo.GetWaitingInTx(ctx, tx, mode)
+	if err != nil {
+		return err
+	}
+
+	for _, match := range Pair(waiting, time.Now()) {
+		battleState, dropped, err := s.startBattle(ctx, tx, mode, match)
+		if err != nil {
+			return err
+		}
+
+		// Users whose deck changed since they joined leave the queue; the other waits for the next pass
+		if len(dropped) > 0 {
+			for _, userID := range dropped {
+				if err := s.repo.RemoveInTx(ctx, tx, userID); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := s.repo.MarkMatchedInTx(ctx, tx, battleState.ID, match.UserID, match.OpponentID); err != nil {
+			return err
+		}
+		s.logger.Info("Matched players", "mode", mode, "battle_id", battleState.ID, "user_id", match.UserID, "opponent_id", match.OpponentID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// startBattle creates the battle session of a match and saves it in tx, so it
+// only exists if the whole pass commits. It returns the users whose deck can no
+// longer be brought to the mode instead, if there are any.
+func (s *Service) startBattle(ctx context.Context, tx pgx.Tx, mode string, match Match) (*battle.BattleState, []int, error) {
+	userDeck, err := s.battles.GetUserDeck(ctx, match.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	opponentDeck, err := s.battles.GetUserDeck(ctx, match.OpponentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dropped []int
+	if battle.CheckDeck(mode, userDeck) != nil {
+		dropped = append(dropped, match.UserID)
+	}
+	if battle.CheckDeck(mode, opponentDeck) != nil {
+		dropped = append(dropped, match.OpponentID)
+	}
+	if len(dropped) > 0 {
+		return nil, dropped, nil
+	}
+
+	battleState, err := battle.NewMatchedPvPBattle(match.UserID, match.OpponentID, mode, userDeck, opponentDeck)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.battles.SaveBattleSessionInTx(ctx, tx, battleState); err != nil {
+		return nil, nil, err
+	}
+	return battleState, nil, nil
+}
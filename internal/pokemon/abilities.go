@@ -0,0 +1,102 @@
+package pokemon
+
+import "strings"
+
+// What abilities do
+const (
+	AbilityImmune  = "immune"  // Takes no damage from moves of the ability's type
+	AbilityPinch   = "pinch"   // Boosts moves of the ability's type while below 1/3 of max HP
+	AbilityGuard   = "guard"   // Halves the stamina cost of defending
+	AbilityReflect = "reflect" // Hurts attackers for part of the damage they deal
+)
+
+// Ability is the passive ability every Pokemon of a species has. Abilities
+// keep their PokeAPI names; the game models the ones in Abilities.
+type Ability struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Kind        string `json:"kind"`           // What the ability does
+	Type        string `json:"type,omitempty"` // Type of the moves immune and pinch abilities work on
+}
+
+// Abilities lists every ability the game models
+var Abilities = []Ability{
+	immunity("levitate", "ground"),
+	immunity("flash-fire", "fire"),
+	immunity("water-absorb", "water"),
+	immunity("storm-drain", "water"),
+	immunity("dry-skin", "water"),
+	immunity("volt-absorb", "electric"),
+	immunity("lightning-rod", "electric"),
+	immunity("motor-drive", "electric"),
+	immunity("sap-sipper", "grass"),
+	pinch("overgrow", "grass"),
+	pinch("blaze", "fire"),
+	pinch("torrent", "water"),
+	pinch("swarm", "bug"),
+	{Name: "battle-armor", Description: "Defending costs half the stamina", Kind: AbilityGuard},
+	{Name: "shell-armor", Description: "Defending costs half the stamina", Kind: AbilityGuard},
+	{Name: "sturdy", Description: "Defending costs half the stamina", Kind: AbilityGuard},
+	{Name: "solid-rock", Description: "Defending costs half the stamina", Kind: AbilityGuard},
+	{Name: "rough-skin", Description: "Attackers take 1/4 of the damage they deal", Kind: AbilityReflect},
+	{Name: "iron-barbs", Description: "Attackers take 1/4 of the damage they deal", Kind: AbilityReflect},
+}
+
+// defaultAbilities are the abilities of species none of whose abilities the
+// game models, by their first type
+var defaultAbilities = map[string]string{
+	"normal":   "battle-armor",
+	"fire":     "blaze",
+	"water":    "torrent",
+	"grass":    "overgrow",
+	"electric": "volt-absorb",
+	"ice":      "shell-armor",
+	"fighting": "battle-armor",
+	"poison":   "rough-skin",
+	"ground":   "sturdy",
+	"flying":   "battle-armor",
+	"psychic":  "levitate",
+	"bug":      "swarm",
+	"rock":     "sturdy",
+	"ghost":    "levitate",
+	"dragon":   "rough-skin",
+	"dark":     "rough-skin",
+	"steel":    "sturdy",
+	"fairy":    "shell-armor",
+}
+
+// immunity returns the ability called name that makes moves of moveType miss out
+func immunity(name, moveType string) Ability {
+	return Ability{Name: name, Description: "Immune to " + moveType + " moves", Kind: AbilityImmune, Type: moveType}
+}
+
+// pinch returns the ability called name that boosts moves of moveType in a pinch
+func pinch(name, moveType string) Ability {
+	return Ability{Name: name, Description: "Boosts " + moveType + " moves by 50% below 1/3 HP", Kind: AbilityPinch, Type: moveType}
+}
+
+// GetAbility returns the ability called name
+func GetAbility(name string) (Ability, bool) {
+	for _, ability := range Abilities {
+		if ability.Name == name {
+			return ability, true
+		}
+	}
+	return Ability{}, false
+}
+
+// AbilityName returns how an ability is shown to players, such as "Flash Fire"
+func AbilityName(ability string) string {
+	return HeldItemName(ability)
+}
+
+// DefaultAbility returns the ability of a species of types none of whose
+// abilities the game models
+func DefaultAbility(types []string) string {
+	if len(types) > 0 {
+		if ability, ok := defaultAbilities[strings.ToLower(types[0])]; ok {
+			return ability
+		}
+	}
+	return "battle-armor"
+}
@@ -2,7 +2,7 @@ package pokemon
 
 // BuildCardFromPokemon builds a Card from Pokemon API data
 func BuildCardFromPokemon(poke Pokemon, moves []Move) Card {
-	var hp, defense, attack, speed int
+	var hp, defense, attack, spAttack, spDefense, speed int
 	for _, stat := range poke.Stats {
 		switch stat.StName.Name {
 		case "hp":
@@ -11,6 +11,10 @@ func BuildCardFromPokemon(poke Pokemon, moves []Move) Card {
 			defense = stat.BaseSt
 		case "attack":
 			attack = stat.BaseSt
+		case "special-attack":
+			spAttack = stat.BaseSt
+		case "special-defense":
+			spDefense = stat.BaseSt
 		case "speed":
 			speed = stat.BaseSt
 		}
@@ -28,6 +32,12 @@ func BuildCardFromPokemon(poke Pokemon, moves []Move) Card {
 	// Sprite
 	sprite := poke.Sprites.FrontDflt
 
+	// Species beyond the offline data get the ability of their type
+	ability := SpeciesAbility(poke.Name)
+	if ability == "" {
+		ability = DefaultAbility(types)
+	}
+
 	// Check if legendary or mythical
 	isLegendary, isMythical := IsLegendaryOrMythical(poke.Name)
 
@@ -38,9 +48,12 @@ func BuildCardFromPokemon(poke Pokemon, moves []Move) Card {
 		Stamina:     stamina,
 		Defense:     defense,
 		Attack:      attack,
+		SpAttack:    spAttack,
+		SpDefense:   spDefense,
 		Speed:       speed,
 		Moves:       moves,
 		Types:       types,
+		Ability:     ability,
 		Sprite:      sprite,
 		Level:       1,
 		XP:          0,
@@ -14,8 +14,10 @@ import (
 // GetMoves fetches move details from the API
 func GetMoves(rawMoves []RawMove) []Move {
 	const maxMoves = 4
+	const maxSupportMoves = 1
 	perm := rand.Perm(len(rawMoves))
 	var gameMoves []Move
+	supportMoves := 0
 
 	// Create HTTP client with timeout
 	client := &http.Client{
@@ -30,11 +32,32 @@ func GetMoves(rawMoves []RawMove) []Move {
 		}
 
 		var data struct {
-			Name  string `json:"name"`
-			Power int    `json:"power"`
-			Type  struct {
+			Name     string `json:"name"`
+			Power    int    `json:"power"`
+			Accuracy int    `json:"accuracy"` // null for moves that never miss
+			Type     struct {
 				Name string `json:"name"`
 			} `json:"type"`
+			Meta struct {
+				Ailment struct {
+					Name string `json:"name"`
+				} `json:"ailment"`
+				AilmentChance int `json:"ailment_chance"`
+				CritRate      int `json:"crit_rate"`
+				Healing       int `json:"healing"`
+			} `json:"meta"`
+			StatChanges []struct {
+				Change int `json:"change"`
+				Stat   struct {
+					Name string `json:"name"`
+				} `json:"stat"`
+			} `json:"stat_changes"`
+			Target struct {
+				Name string `json:"name"`
+			} `json:"target"`
+			DamageClass struct {
+				Name string `json:"name"`
+			} `json:"damage_class"`
 		}
 
 		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
@@ -43,16 +66,40 @@ func GetMoves(rawMoves []RawMove) []Move {
 		}
 		resp.Body.Close()
 
-		if data.Power <= 0 {
-			continue
-		}
-
-		gameMoves = append(gameMoves, Move{
+		move := Move{
 			Name:        data.Name,
 			Power:       data.Power,
 			StaminaCost: data.Power / 3,
 			Type:        data.Type.Name,
-		})
+			Accuracy:    data.Accuracy,
+			CritRate:    data.Meta.CritRate,
+		}
+		if data.DamageClass.Name == CategoryPhysical || data.DamageClass.Name == CategorySpecial {
+			move.Category = data.DamageClass.Name
+		}
+		if IsStatusCondition(data.Meta.Ailment.Name) && data.Meta.AilmentChance > 0 {
+			move.Effect = data.Meta.Ailment.Name
+			move.EffectChance = data.Meta.AilmentChance
+		}
+
+		// Moves without power are kept as support moves if they do something
+		// the game models, at most one per card
+		if move.IsSupport() {
+			move.StaminaCost = SupportMoveStaminaCost
+			move.Healing = max(data.Meta.Healing, 0)
+			move.StaminaGain = staminaMoves[data.Name]
+			move.Field = fieldMoves[data.Name]
+			for _, change := range data.StatChanges {
+				if IsStageStat(change.Stat.Name) {
+					move.StatChanges = append(move.StatChanges, StatChange{Stat: change.Stat.Name, Stages: change.Change, Target: statChangeTarget(data.Target.Name)})
+				}
+			}
+			if !move.HasSupportEffect() || supportMoves == maxSupportMoves {
+				continue
+			}
+			supportMoves++
+		}
+		gameMoves = append(gameMoves, move)
 
 		if len(gameMoves) == maxMoves {
 			break
@@ -0,0 +1,114 @@
+package pokemon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Held items with an effect of their own; the others boost the moves of a type
+const (
+	ItemLeftovers    = "leftovers"
+	ItemFocusSash    = "focus-sash"
+	ItemStaminaCharm = "stamina-charm"
+)
+
+// TypeBoostPercent is how much a type-boosting item raises the damage of the
+// moves of its type
+const TypeBoostPercent = 20
+
+// typeBoosterPrice is what every type-boosting item costs in the shop
+const typeBoosterPrice = 150
+
+// HeldItem is an item a card holds into battle
+type HeldItem struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       int    `json:"price"`                // Coins it costs in the shop
+	BoostType   string `json:"boost_type,omitempty"` // Type of the moves it boosts by TypeBoostPercent, for type-boosting items
+}
+
+// HeldItems lists every held item, in the order the shop shows them
+var HeldItems = []HeldItem{
+	{Name: ItemLeftovers, Description: "Restores 1/16 of max HP at the end of every turn", Price: 300},
+	{Name: ItemFocusSash, Description: "Hangs on with 1 HP from a hit that would knock out from full HP, once a battle", Price: 250},
+	{Name: ItemStaminaCharm, Description: "Restores 1/10 of max stamina at the end of every turn", Price: 250},
+	typeBooster("silk-scarf", "normal"),
+	typeBooster("charcoal", "fire"),
+	typeBooster("mystic-water", "water"),
+	typeBooster("miracle-seed", "grass"),
+	typeBooster("magnet", "electric"),
+	typeBooster("never-melt-ice", "ice"),
+	typeBooster("black-belt", "fighting"),
+	typeBooster("poison-barb", "poison"),
+	typeBooster("soft-sand", "ground"),
+	typeBooster("sharp-beak", "flying"),
+	typeBooster("twisted-spoon", "psychic"),
+	typeBooster("silver-powder", "bug"),
+	typeBooster("hard-stone", "rock"),
+	typeBooster("spell-tag", "ghost"),
+	typeBooster("dragon-fang", "dragon"),
+	typeBooster("black-glasses", "dark"),
+	typeBooster("metal-coat", "steel"),
+	typeBooster("fairy-feather", "fairy"),
+}
+
+// typeBooster returns the held item called name that boosts moves of moveType
+func typeBooster(name, moveType string) HeldItem {
+	return HeldItem{
+		Name:        name,
+		Description: fmt.Sprintf("Boosts %s moves by %d%%", moveType, TypeBoostPercent),
+		Price:       typeBoosterPrice,
+		BoostType:   moveType,
+	}
+}
+
+// HeldItemName returns how a held item is shown to players, such as "Focus Sash"
+func HeldItemName(item string) string {
+	words := strings.Split(item, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// GetHeldItem returns the held item called name
+func GetHeldItem(name string) (HeldItem, bool) {
+	for _, item := range HeldItems {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return HeldItem{}, false
+}
+
+// BattleItem is an item players carry in their bag and use in battle, spending
+// their turn on it
+type BattleItem struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       int    `json:"price"`                  // Coins it costs in the shop
+	Healing     int    `json:"healing,omitempty"`      // Percent of max HP restored
+	StaminaGain int    `json:"stamina_gain,omitempty"` // Percent of max stamina restored
+	Cures       bool   `json:"cures,omitempty"`        // Cures the status condition
+}
+
+// BattleItems lists every battle item, in the order the shop shows them. The
+// "item" action picks one by its index here.
+var BattleItems = []BattleItem{
+	{Name: "potion", Description: "Restores 30% of max HP", Price: 60, Healing: 30},
+	{Name: "super-potion", Description: "Restores 60% of max HP", Price: 150, Healing: 60},
+	{Name: "stamina-drink", Description: "Restores 50% of max stamina", Price: 80, StaminaGain: 50},
+	{Name: "full-heal", Description: "Cures burn, poison, paralysis, sleep and freeze", Price: 100, Cures: true},
+}
+
+// GetBattleItem returns the battle item called name and its index in BattleItems
+func GetBattleItem(name string) (BattleItem, int, bool) {
+	for i, item := range BattleItems {
+		if item.Name == name {
+			return item, i, true
+		}
+	}
+	return BattleItem{}, -1, false
+}
@@ -4,7 +4,8 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"pokemon-cli/pkg/rng"
+	"strings"
 	"sync"
 )
 
@@ -19,9 +20,12 @@ type PokemonEntry struct {
 	HP          int      `json:"hp"`
 	Attack      int      `json:"attack"`
 	Defense     int      `json:"defense"`
+	SpAttack    int      `json:"sp_attack"`
+	SpDefense   int      `json:"sp_defense"`
 	Speed       int      `json:"speed"`
 	Types       []string `json:"types"`
 	Moves       []Move   `json:"moves"`
+	Ability     string   `json:"ability"` // Passive ability of the species; see Abilities
 	Sprite      string   `json:"sprite"`
 	IsLegendary bool     `json:"is_legendary"`
 	IsMythical  bool     `json:"is_mythical"`
@@ -35,6 +39,7 @@ type PokemonDatabase struct {
 	
 	// Internal index for fast lookup
 	pokemonByID map[int]*PokemonEntry
+	moveByName  map[string]Move
 }
 
 var (
@@ -57,7 +62,25 @@ func LoadPokemonDatabase() (*PokemonDatabase, error) {
 		// Build index for fast lookup
 		db.pokemonByID = make(map[int]*PokemonEntry, len(db.Pokemon))
 		for i := range db.Pokemon {
-			db.pokemonByID[db.Pokemon[i].ID] = &db.Pokemon[i]
+			entry := &db.Pokemon[i]
+			// Data generated before special stats existed uses the physical ones
+			if entry.SpAttack == 0 {
+				entry.SpAttack = entry.Attack
+			}
+			if entry.SpDefense == 0 {
+				entry.SpDefense = entry.Defense
+			}
+			// And data generated before abilities existed the one of its type
+			if entry.Ability == "" {
+				entry.Ability = DefaultAbility(entry.Types)
+			}
+			db.pokemonByID[entry.ID] = entry
+		}
+		db.moveByName = make(map[string]Move)
+		for _, entry := range db.Pokemon {
+			for _, move := range entry.Moves {
+				db.moveByName[move.Name] = move
+			}
 		}
 		
 		globalDatabase = &db
@@ -85,11 +108,78 @@ func GetPokemonByID(id int) (*PokemonEntry, error) {
 	return pokemon, nil
 }
 
+// GetPokemonByName retrieves a Pokemon by its name, ignoring case
+func GetPokemonByName(name string) (*PokemonEntry, error) {
+	db, err := LoadPokemonDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range db.Pokemon {
+		if strings.EqualFold(db.Pokemon[i].Name, name) {
+			return &db.Pokemon[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("pokemon %q not found", name)
+}
+
+// WithMoveEffects returns moves with the secondary effects, accuracy, crit rate
+// and damage category the offline data gives them filled in, for moves saved
+// before moves had them
+func WithMoveEffects(moves []Move) []Move {
+	db, err := LoadPokemonDatabase()
+	if err != nil {
+		return moves
+	}
+
+	tagged := make([]Move, len(moves))
+	for i, move := range moves {
+		if known, ok := db.moveByName[move.Name]; ok {
+			if move.Effect == "" {
+				move.Effect = known.Effect
+				move.EffectChance = known.EffectChance
+			}
+			if move.Accuracy == 0 {
+				move.Accuracy = known.Accuracy
+			}
+			if move.CritRate == 0 {
+				move.CritRate = known.CritRate
+			}
+			if move.Category == "" {
+				move.Category = known.Category
+			}
+		}
+		tagged[i] = move
+	}
+	return tagged
+}
+
+// SpeciesAbility returns the ability of the species called name, for cards
+// saved or fetched without one
+func SpeciesAbility(name string) string {
+	entry, err := GetPokemonByName(name)
+	if err != nil {
+		return ""
+	}
+	return entry.Ability
+}
+
+// CardFromEntry builds a level 1 Card for a Pokemon in the offline database
+func CardFromEntry(entry *PokemonEntry) Card {
+	return buildCardFromEntry(entry)
+}
+
 // GetRandomPokemon returns a random Pokemon from the database
 // excludeLegendary: if true, excludes legendary Pokemon
 // excludeMythical: if true, excludes mythical Pokemon
 // Optimized to avoid copying entire Pokemon slice
 func GetRandomPokemon(excludeLegendary, excludeMythical bool) (*PokemonEntry, error) {
+	return GetRandomPokemonWithRand(rng.Global, excludeLegendary, excludeMythical)
+}
+
+// GetRandomPokemonWithRand is GetRandomPokemon drawing from the given random source
+func GetRandomPokemonWithRand(r rng.Rand, excludeLegendary, excludeMythical bool) (*PokemonEntry, error) {
 	db, err := LoadPokemonDatabase()
 	if err != nil {
 		return nil, err
@@ -113,7 +203,7 @@ func GetRandomPokemon(excludeLegendary, excludeMythical bool) (*PokemonEntry, er
 	}
 	
 	// Select random Pokemon by index
-	selectedIdx := candidateIndices[rand.Intn(len(candidateIndices))]
+	selectedIdx := candidateIndices[r.Intn(len(candidateIndices))]
 	return &db.Pokemon[selectedIdx], nil
 }
 
@@ -141,33 +231,39 @@ func GetDatabaseStats() (total, legendary, mythical int, err error) {
 // Applies rarity logic: 0.01% mythical, 0.01% legendary, rest common/uncommon/rare
 // No network calls - completely offline
 func FetchRandomPokemonCardOffline() Card {
+	return FetchRandomPokemonCardOfflineWithRand(rng.Global)
+}
+
+// FetchRandomPokemonCardOfflineWithRand is FetchRandomPokemonCardOffline drawing from the
+// given random source, so a seeded caller always gets the same card
+func FetchRandomPokemonCardOfflineWithRand(r rng.Rand) Card {
 	mythicalOdds := 0.0001  // 0.01%
 	legendaryOdds := 0.0001 // 0.01%
 	
-	roll := rand.Float64()
+	roll := r.Float64()
 	var pokemon *PokemonEntry
 	var err error
 	
 	if roll < mythicalOdds {
 		// Try to get a mythical Pokemon
-		pokemon, err = GetRandomPokemon(true, false) // exclude legendary, include mythical
+		pokemon, err = GetRandomPokemonWithRand(r, true, false) // exclude legendary, include mythical
 		if err != nil {
 			// Fallback to any Pokemon if no mythical available
-			pokemon, _ = GetRandomPokemon(false, false)
+			pokemon, _ = GetRandomPokemonWithRand(r, false, false)
 		}
 	} else if roll < mythicalOdds+legendaryOdds {
 		// Try to get a legendary Pokemon
-		pokemon, err = GetRandomPokemon(false, true) // include legendary, exclude mythical
+		pokemon, err = GetRandomPokemonWithRand(r, false, true) // include legendary, exclude mythical
 		if err != nil {
 			// Fallback to any Pokemon if no legendary available
-			pokemon, _ = GetRandomPokemon(false, false)
+			pokemon, _ = GetRandomPokemonWithRand(r, false, false)
 		}
 	} else {
 		// Get a normal Pokemon (exclude legendary and mythical)
-		pokemon, err = GetRandomPokemon(true, true)
+		pokemon, err = GetRandomPokemonWithRand(r, true, true)
 		if err != nil {
 			// Fallback to any Pokemon
-			pokemon, _ = GetRandomPokemon(false, false)
+			pokemon, _ = GetRandomPokemonWithRand(r, false, false)
 		}
 	}
 	
@@ -222,9 +318,12 @@ func buildCardFromEntry(entry *PokemonEntry) Card {
 		Stamina:     stamina,
 		Attack:      entry.Attack,
 		Defense:     entry.Defense,
+		SpAttack:    entry.SpAttack,
+		SpDefense:   entry.SpDefense,
 		Speed:       entry.Speed,
 		Types:       entry.Types,
 		Moves:       moves,
+		Ability:     entry.Ability,
 		Sprite:      entry.Sprite,
 		Level:       1,
 		XP:          0,
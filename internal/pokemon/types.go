@@ -1,5 +1,10 @@
 package pokemon
 
+import (
+	"pokemon-cli/internal/progression"
+	"pokemon-cli/internal/ruleset"
+)
+
 // Stat represents a Pokemon stat from the API
 type Stat struct {
 	BaseSt int `json:"base_stat"`
@@ -39,10 +44,169 @@ type Pokemon struct {
 
 // Move represents a Pokemon move
 type Move struct {
-	Name        string `json:"name"`
-	Power       int    `json:"power"`
-	StaminaCost int    `json:"stamina_cost"`
-	Type        string `json:"attack_type"`
+	Name         string `json:"name"`
+	Power        int    `json:"power"`
+	StaminaCost  int    `json:"stamina_cost"`
+	Type         string `json:"attack_type"`
+	Effect       string `json:"effect,omitempty"`        // Status condition the move may inflict on a hit
+	EffectChance int    `json:"effect_chance,omitempty"` // Percent chance of inflicting Effect
+	Accuracy     int    `json:"accuracy,omitempty"`      // Percent chance of hitting under the modern damage rules; 0 never misses
+	CritRate     int    `json:"crit_rate,omitempty"`     // Critical hit stage: 0 is normal, 1 high, 3 or more always
+	Category     string `json:"category,omitempty"`      // CategoryPhysical or CategorySpecial; physical when empty
+
+	// Support moves have no power and do these instead of dealing damage
+	Healing     int          `json:"healing,omitempty"`      // Percent of the user's max HP restored
+	StaminaGain int          `json:"stamina_gain,omitempty"` // Percent of the user's max stamina restored
+	StatChanges []StatChange `json:"stat_changes,omitempty"` // Stat stages raised or lowered
+	Field       string       `json:"field,omitempty"`        // Weather or terrain the move sets
+}
+
+// Damage categories of moves. The names match PokeAPI's damage classes.
+const (
+	CategoryPhysical = "physical" // Uses the attacker's attack and the defender's defense
+	CategorySpecial  = "special"  // Uses the attacker's special attack and the defender's special defense
+)
+
+// IsSpecial reports whether the move is a special move. Moves without a
+// category are physical; WithMoveEffects fills in the category of moves saved
+// before categories existed.
+func (m Move) IsSpecial() bool {
+	return m.Category == CategorySpecial
+}
+
+// StatChange is a support move raising (positive Stages) or lowering
+// (negative Stages) a stat of its user or of the opposing Pokemon
+type StatChange struct {
+	Stat   string `json:"stat"` // One of the Stat constants
+	Stages int    `json:"stages"`
+	Target string `json:"target"` // TargetUser or TargetOpponent
+}
+
+// Stats support moves can raise or lower in stages. The names match PokeAPI's.
+const (
+	StatAttack    = "attack"
+	StatDefense   = "defense"
+	StatSpAttack  = "special-attack"
+	StatSpDefense = "special-defense"
+	StatSpeed     = "speed"
+)
+
+// Pokemon a StatChange can target
+const (
+	TargetUser     = "user"
+	TargetOpponent = "opponent"
+)
+
+// SupportMoveStaminaCost is the stamina cost of every support move; an attack's
+// comes from its power
+const SupportMoveStaminaCost = 20
+
+// IsSupport reports whether the move is a support move, one that heals,
+// restores stamina, changes stat stages or sets the weather or terrain instead
+// of dealing damage
+func (m Move) IsSupport() bool {
+	return m.Power <= 0
+}
+
+// HasSupportEffect reports whether the move does anything as a support move
+func (m Move) HasSupportEffect() bool {
+	return m.Healing > 0 || m.StaminaGain > 0 || len(m.StatChanges) > 0 || m.Field != ""
+}
+
+// IsStageStat reports whether name is a stat support moves can change
+func IsStageStat(name string) bool {
+	switch name {
+	case StatAttack, StatDefense, StatSpAttack, StatSpDefense, StatSpeed:
+		return true
+	}
+	return false
+}
+
+// statChangeTarget returns who a move's stat changes apply to from PokeAPI's
+// name for the move's target
+func statChangeTarget(pokeAPITarget string) string {
+	switch pokeAPITarget {
+	case "user", "user-or-ally", "user-and-allies", "users-field":
+		return TargetUser
+	}
+	return TargetOpponent
+}
+
+// staminaMoves are the support moves that restore stamina, with the percent of
+// max stamina they restore. PokeAPI has no stamina, so these are the moves
+// about focusing or charging up.
+var staminaMoves = map[string]int{
+	"focus-energy": 50,
+	"laser-focus":  50,
+	"charge":       50,
+	"stockpile":    40,
+}
+
+// Weather a support move's Field can set
+const (
+	WeatherSun       = "sun"
+	WeatherRain      = "rain"
+	WeatherSandstorm = "sandstorm"
+	WeatherHail      = "hail"
+)
+
+// Terrain a support move's Field can set. The names match the moves setting them.
+const (
+	TerrainElectric = "electric-terrain"
+	TerrainGrassy   = "grassy-terrain"
+	TerrainPsychic  = "psychic-terrain"
+	TerrainMisty    = "misty-terrain"
+)
+
+// IsWeather reports whether name is a weather
+func IsWeather(name string) bool {
+	switch name {
+	case WeatherSun, WeatherRain, WeatherSandstorm, WeatherHail:
+		return true
+	}
+	return false
+}
+
+// IsTerrain reports whether name is a terrain
+func IsTerrain(name string) bool {
+	switch name {
+	case TerrainElectric, TerrainGrassy, TerrainPsychic, TerrainMisty:
+		return true
+	}
+	return false
+}
+
+// fieldMoves are the support moves that set a weather or terrain. PokeAPI only
+// describes these effects in prose, so the moves are listed by name.
+var fieldMoves = map[string]string{
+	"sunny-day":        WeatherSun,
+	"rain-dance":       WeatherRain,
+	"sandstorm":        WeatherSandstorm,
+	"hail":             WeatherHail,
+	"snowscape":        WeatherHail,
+	"electric-terrain": TerrainElectric,
+	"grassy-terrain":   TerrainGrassy,
+	"psychic-terrain":  TerrainPsychic,
+	"misty-terrain":    TerrainMisty,
+}
+
+// Status conditions a move's Effect can inflict. The names match PokeAPI's
+// move ailments.
+const (
+	StatusBurn      = "burn"
+	StatusPoison    = "poison"
+	StatusParalysis = "paralysis"
+	StatusSleep     = "sleep"
+	StatusFreeze    = "freeze"
+)
+
+// IsStatusCondition reports whether name is a status condition a move can inflict
+func IsStatusCondition(name string) bool {
+	switch name {
+	case StatusBurn, StatusPoison, StatusParalysis, StatusSleep, StatusFreeze:
+		return true
+	}
+	return false
 }
 
 // Card represents a Pokemon card for battles
@@ -54,6 +218,8 @@ type Card struct {
 	Stamina     int
 	Defense     int
 	Attack      int
+	SpAttack    int
+	SpDefense   int
 	Speed       int
 	Moves       []Move
 	Types       []string
@@ -62,32 +228,49 @@ type Card struct {
 	XP          int
 	IsLegendary bool
 	IsMythical  bool
+	HeldItem    string // Name of the HeldItem the card holds into battle, if any
+	Ability     string // Passive ability of the card's species; see Abilities
 }
 
 // CardStats represents computed stats based on level
 type CardStats struct {
-	HP      int
-	Attack  int
-	Defense int
-	Speed   int
-	Stamina int
+	HP        int
+	Attack    int
+	Defense   int
+	SpAttack  int
+	SpDefense int
+	Speed     int
+	Stamina   int
 }
 
 // GetCurrentStats calculates current stats based on level for Card
 func (c *Card) GetCurrentStats() CardStats {
-	levelMultiplier := float64(c.Level - 1)
-
-	hp := int(float64(c.HPMax) * (1.0 + levelMultiplier*0.03))
-	attack := int(float64(c.Attack) * (1.0 + levelMultiplier*0.02))
-	defense := int(float64(c.Defense) * (1.0 + levelMultiplier*0.02))
-	speed := int(float64(c.Speed) * (1.0 + levelMultiplier*0.01))
-	stamina := speed * 2
-
-	return CardStats{
-		HP:      hp,
-		Attack:  attack,
-		Defense: defense,
-		Speed:   speed,
-		Stamina: stamina,
+	stats := ruleset.Current().Progression.Stats(c.Level, progression.Stats{
+		HP:        c.HPMax,
+		Attack:    c.Attack,
+		Defense:   c.Defense,
+		SpAttack:  c.SpAttack,
+		SpDefense: c.SpDefense,
+		Speed:     c.Speed,
+	})
+	return CardStats(stats)
+}
+
+// AttackStat returns the attacker's stat that move is used with: special
+// attack for special moves and attack otherwise. Cards without special stats,
+// built before they existed, use their physical ones.
+func (c *Card) AttackStat(move Move) int {
+	if move.IsSpecial() && c.SpAttack > 0 {
+		return c.SpAttack
+	}
+	return c.Attack
+}
+
+// DefenseStat returns the defender's stat that blocks move: special defense
+// for special moves and defense otherwise (see AttackStat)
+func (c *Card) DefenseStat(move Move) int {
+	if move.IsSpecial() && c.SpDefense > 0 {
+		return c.SpDefense
 	}
+	return c.Defense
 }
@@ -0,0 +1,143 @@
+// Package progression is how Pokemon grow: the XP battles earn them, the XP
+// each level takes, the level cap and the stats they have at each level. The
+// API and the offline CLI both level Pokemon up through it, so a battle
+// progresses a Pokemon the same way wherever it is played. The numbers come
+// from the ruleset (see internal/ruleset).
+package progression
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxLevel is the highest level cap a ruleset may set: the database refuses
+// cards above it
+const MaxLevel = 50
+
+// Progression is a set of progression rules
+type Progression struct {
+	LevelCap int                 `json:"level_cap"`
+	Curve    Curve               `json:"curve"`
+	XP       map[string]ResultXP `json:"battle_xp"` // XP each Pokemon that took part in a battle earns, by mode
+	Growth   Growth              `json:"growth"`
+}
+
+// Curve is the XP each level takes: Base * level^Exponent to go from level to
+// the next one, rounded
+type Curve struct {
+	Base     int     `json:"base"`     // XP a level 1 Pokemon needs to reach level 2
+	Exponent float64 `json:"exponent"` // How fast the XP each level takes grows: 1 is linear, above 1 steeper
+}
+
+// ResultXP is the XP battles of a mode earn for each result, before scaling
+// by AI difficulty
+type ResultXP struct {
+	Win  int `json:"win"`
+	Draw int `json:"draw"`
+	Loss int `json:"loss"`
+}
+
+// Growth is the share of each base stat a Pokemon gains every level above 1
+type Growth struct {
+	HP        float64 `json:"hp"`
+	Attack    float64 `json:"attack"`
+	Defense   float64 `json:"defense"`
+	SpAttack  float64 `json:"sp_attack"`
+	SpDefense float64 `json:"sp_defense"`
+	Speed     float64 `json:"speed"`
+}
+
+// Stats are a Pokemon's stats, either at level 1 (its base stats) or at a level
+type Stats struct {
+	HP        int
+	Attack    int
+	Defense   int
+	SpAttack  int
+	SpDefense int
+	Speed     int
+	Stamina   int // Twice the speed; ignored in base stats
+}
+
+// Validate checks that the progression rules make a playable game
+func (p *Progression) Validate() error {
+	if p.LevelCap < 1 || p.LevelCap > MaxLevel {
+		return fmt.Errorf("level cap must be between 1 and %d", MaxLevel)
+	}
+	if p.Curve.Base < 1 {
+		return fmt.Errorf("XP curve base must be at least 1")
+	}
+	if p.Curve.Exponent < 0 {
+		return fmt.Errorf("XP curve exponent must not be negative")
+	}
+	for _, mode := range []string{"1v1", "5v5"} {
+		xp, ok := p.XP[mode]
+		if !ok {
+			return fmt.Errorf("missing %s battle XP", mode)
+		}
+		if xp.Win < 0 || xp.Draw < 0 || xp.Loss < 0 {
+			return fmt.Errorf("%s battles earn negative XP", mode)
+		}
+	}
+	g := p.Growth
+	for _, share := range []float64{g.HP, g.Attack, g.Defense, g.SpAttack, g.SpDefense, g.Speed} {
+		if share < 0 {
+			return fmt.Errorf("stat growth must not be negative")
+		}
+	}
+	return nil
+}
+
+// BattleXP returns the XP each Pokemon that took part in a battle of mode
+// earns, by its winner ("player", "ai" or "draw") from their trainer's side
+func (p *Progression) BattleXP(mode, winner string) int {
+	xp := p.XP[mode]
+	switch winner {
+	case "player":
+		return xp.Win
+	case "draw":
+		return xp.Draw
+	}
+	return xp.Loss
+}
+
+// XPToNext returns the XP a Pokemon at level needs to reach the next one, or
+// 0 at the level cap
+func (p *Progression) XPToNext(level int) int {
+	if level >= p.LevelCap {
+		return 0
+	}
+	return max(int(math.Round(float64(p.Curve.Base)*math.Pow(float64(level), p.Curve.Exponent))), 1)
+}
+
+// AddXP returns the level and XP towards the next level of a Pokemon at level
+// with xp once it gains gained more. XP stops counting at the level cap.
+func (p *Progression) AddXP(level, xp, gained int) (int, int) {
+	xp += gained
+	for level < p.LevelCap && xp >= p.XPToNext(level) {
+		xp -= p.XPToNext(level)
+		level++
+	}
+	if level >= p.LevelCap {
+		return p.LevelCap, 0
+	}
+	return level, xp
+}
+
+// Stats returns the stats at level of a Pokemon with base stats
+func (p *Progression) Stats(level int, base Stats) Stats {
+	levels := float64(level - 1)
+	grow := func(stat int, share float64) int {
+		return int(float64(stat) * (1.0 + levels*share))
+	}
+
+	stats := Stats{
+		HP:        grow(base.HP, p.Growth.HP),
+		Attack:    grow(base.Attack, p.Growth.Attack),
+		Defense:   grow(base.Defense, p.Growth.Defense),
+		SpAttack:  grow(base.SpAttack, p.Growth.SpAttack),
+		SpDefense: grow(base.SpDefense, p.Growth.SpDefense),
+		Speed:     grow(base.Speed, p.Growth.Speed),
+	}
+	stats.Stamina = stats.Speed * 2
+	return stats
+}
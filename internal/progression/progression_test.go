@@ -0,0 +1,120 @@
+package progression
+
+import "testing"
+
+// classic returns the progression the game has always been played with
+func classic() *Progression {
+	return &Progression{
+		LevelCap: 50,
+		Curve:    Curve{Base: 100, Exponent: 1},
+		XP: map[string]ResultXP{
+			"1v1": {Win: 20, Draw: 10, Loss: 5},
+			"5v5": {Win: 15, Draw: 8, Loss: 5},
+		},
+		Growth: Growth{HP: 0.03, Attack: 0.02, Defense: 0.02, SpAttack: 0.02, SpDefense: 0.02, Speed: 0.01},
+	}
+}
+
+func TestXPToNext(t *testing.T) {
+	p := classic()
+	for level, want := range map[int]int{1: 100, 2: 200, 10: 1000, 49: 4900, 50: 0} {
+		if got := p.XPToNext(level); got != want {
+			t.Errorf("linear XPToNext(%d) = %d, want %d", level, got, want)
+		}
+	}
+
+	p.Curve = Curve{Base: 50, Exponent: 1.5}
+	for level, want := range map[int]int{1: 50, 4: 400, 9: 1350} {
+		if got := p.XPToNext(level); got != want {
+			t.Errorf("steep XPToNext(%d) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestAddXP(t *testing.T) {
+	p := classic()
+	tests := []struct {
+		name              string
+		level, xp, gained int
+		wantLevel, wantXP int
+	}{
+		{"no level up", 1, 0, 20, 1, 20},
+		{"one level up", 1, 90, 20, 2, 10},
+		{"several level ups", 1, 0, 350, 3, 50},
+		{"reaches the cap", 49, 4890, 20, 50, 0},
+		{"at the cap", 50, 0, 20, 50, 0},
+	}
+	for _, tt := range tests {
+		level, xp := p.AddXP(tt.level, tt.xp, tt.gained)
+		if level != tt.wantLevel || xp != tt.wantXP {
+			t.Errorf("%s: level %d with %d XP, want level %d with %d XP", tt.name, level, xp, tt.wantLevel, tt.wantXP)
+		}
+	}
+
+	p.LevelCap = 3
+	if level, xp := p.AddXP(1, 0, 10000); level != 3 || xp != 0 {
+		t.Errorf("level %d with %d XP past a level cap of 3", level, xp)
+	}
+}
+
+func TestBattleXP(t *testing.T) {
+	p := classic()
+	for _, tt := range []struct {
+		mode, winner string
+		want         int
+	}{
+		{"1v1", "player", 20},
+		{"1v1", "draw", 10},
+		{"1v1", "ai", 5},
+		{"5v5", "player", 15},
+		{"5v5", "draw", 8},
+		{"5v5", "ai", 5},
+	} {
+		if got := p.BattleXP(tt.mode, tt.winner); got != tt.want {
+			t.Errorf("BattleXP(%s, %s) = %d, want %d", tt.mode, tt.winner, got, tt.want)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	p := classic()
+	base := Stats{HP: 100, Attack: 50, Defense: 40, SpAttack: 80, SpDefense: 70, Speed: 60}
+
+	if got := p.Stats(1, base); got != (Stats{100, 50, 40, 80, 70, 60, 120}) {
+		t.Errorf("level 1 stats %+v, want the base stats", got)
+	}
+	levels := float64(9)
+	want := Stats{
+		HP:        int(float64(100) * (1.0 + levels*0.03)),
+		Attack:    int(float64(50) * (1.0 + levels*0.02)),
+		Defense:   int(float64(40) * (1.0 + levels*0.02)),
+		SpAttack:  int(float64(80) * (1.0 + levels*0.02)),
+		SpDefense: int(float64(70) * (1.0 + levels*0.02)),
+		Speed:     int(float64(60) * (1.0 + levels*0.01)),
+	}
+	want.Stamina = want.Speed * 2
+	if got := p.Stats(10, base); got != want {
+		t.Errorf("level 10 stats %+v, want %+v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := classic().Validate(); err != nil {
+		t.Fatalf("classic progression invalid: %v", err)
+	}
+	for name, change := range map[string]func(p *Progression){
+		"level cap above the database's": func(p *Progression) { p.LevelCap = MaxLevel + 1 },
+		"no levels":                      func(p *Progression) { p.LevelCap = 0 },
+		"free levels":                    func(p *Progression) { p.Curve.Base = 0 },
+		"negative exponent":              func(p *Progression) { p.Curve.Exponent = -1 },
+		"missing mode":                   func(p *Progression) { delete(p.XP, "5v5") },
+		"negative XP":                    func(p *Progression) { p.XP["1v1"] = ResultXP{Loss: -5} },
+		"shrinking stats":                func(p *Progression) { p.Growth.Speed = -0.01 },
+	} {
+		p := classic()
+		change(p)
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Package ruleset holds the numbers the battle rules and the game's economy
+// run on, such as what sacrificing and defending cost, what battles pay and
+// how Pokemon level up.
+// Every game path reads them from the current ruleset, so variant rulesets can
+// be played by loading one from JSON instead of changing code.
+package ruleset
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"pokemon-cli/internal/progression"
+	"sync/atomic"
+)
+
+// EnvVar names the environment variable holding the path of a ruleset to play
+// with instead of the default one
+const EnvVar = "RULESET_FILE"
+
+//go:embed default.json
+var defaultJSON []byte
+
+// Ruleset is a set of battle and economy constants
+type Ruleset struct {
+	Name                  string                  `json:"name"`
+	Sacrifices            []Sacrifice             `json:"sacrifices"`              // Each sacrifice a Pokemon may make, in order
+	SacrificeStaminaLimit float64                 `json:"sacrifice_stamina_limit"` // Share of max stamina a Pokemon must be below to sacrifice
+	DefendCostDivisor     int                     `json:"defend_cost_divisor"`     // Defending costs max HP / divisor stamina, rounded up
+	DefendDamageShare     float64                 `json:"defend_damage_share"`     // Share of a hit's damage that gets through a defence
+	LegendaryMultiplier   float64                 `json:"legendary_multiplier"`    // Damage multiplier of legendary and mythical attackers
+	Rewards               map[string]ModeRewards  `json:"rewards"`                 // Coins battles pay on the server, by mode
+	OfflineRewards        map[string]ModeRewards  `json:"offline_rewards"`         // Coins battles pay in the offline CLI game, by mode
+	Progression           progression.Progression `json:"progression"`             // XP battles earn and how Pokemon level up, everywhere
+}
+
+// Sacrifice is what one sacrifice costs and gives
+type Sacrifice struct {
+	HPCost      int     `json:"hp_cost"`
+	StaminaGain float64 `json:"stamina_gain"` // Share of max stamina restored
+}
+
+// ModeRewards is the coins battles of a mode pay for each result, before
+// scaling by AI difficulty
+type ModeRewards struct {
+	Win  int `json:"win"`
+	Draw int `json:"draw"`
+	Loss int `json:"loss"`
+}
+
+// modes are the battle modes a ruleset must pay rewards for
+var modes = []string{"1v1", "5v5"}
+
+var current atomic.Pointer[Ruleset]
+
+func init() {
+	current.Store(Default())
+}
+
+// Default returns the built-in ruleset the game has always been played with
+func Default() *Ruleset {
+	r := &Ruleset{}
+	if err := json.Unmarshal(defaultJSON, r); err != nil {
+		panic(fmt.Sprintf("invalid default ruleset: %v", err))
+	}
+	return r
+}
+
+// Parse reads a ruleset from JSON. Fields it leaves out keep their default
+// values, so a variant only needs to list what it changes.
+func Parse(data []byte) (*Ruleset, error) {
+	r := Default()
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("invalid ruleset: %w", err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Load reads a ruleset from a JSON file
+func Load(path string) (*Ruleset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ruleset: %w", err)
+	}
+	return Parse(data)
+}
+
+// LoadFromEnv makes the ruleset in the file EnvVar names the current one, if
+// it names one, and returns the current ruleset
+func LoadFromEnv() (*Ruleset, error) {
+	if path := os.Getenv(EnvVar); path != "" {
+		r, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		Use(r)
+	}
+	return Current(), nil
+}
+
+// Current returns the ruleset the game is played with
+func Current() *Ruleset {
+	return current.Load()
+}
+
+// Use makes r the ruleset the game is played with. Call it before any battle
+// starts: battles in progress would change rules halfway.
+func Use(r *Ruleset) {
+	current.Store(r)
+}
+
+// Validate checks that the ruleset makes a playable game
+func (r *Ruleset) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("ruleset needs a name")
+	}
+	for i, s := range r.Sacrifices {
+		if s.HPCost < 0 || s.StaminaGain < 0 || s.StaminaGain > 1 {
+			return fmt.Errorf("sacrifice %d: HP cost must not be negative and stamina gain must be between 0 and 1", i+1)
+		}
+	}
+	if r.SacrificeStaminaLimit < 0 || r.SacrificeStaminaLimit > 1 {
+		return fmt.Errorf("sacrifice stamina limit must be between 0 and 1")
+	}
+	if r.DefendCostDivisor < 1 {
+		return fmt.Errorf("defend cost divisor must be at least 1")
+	}
+	if r.DefendDamageShare < 0 || r.DefendDamageShare > 1 {
+		return fmt.Errorf("defend damage share must be between 0 and 1")
+	}
+	if r.LegendaryMultiplier <= 0 {
+		return fmt.Errorf("legendary multiplier must be positive")
+	}
+	for _, mode := range modes {
+		if err := validateRewards(r.Rewards, mode); err != nil {
+			return fmt.Errorf("rewards: %w", err)
+		}
+		if err := validateRewards(r.OfflineRewards, mode); err != nil {
+			return fmt.Errorf("offline rewards: %w", err)
+		}
+	}
+	if err := r.Progression.Validate(); err != nil {
+		return fmt.Errorf("progression: %w", err)
+	}
+	return nil
+}
+
+// validateRewards checks that rewards pays for battles of mode
+func validateRewards(rewards map[string]ModeRewards, mode string) error {
+	m, ok := rewards[mode]
+	if !ok {
+		return fmt.Errorf("missing %s", mode)
+	}
+	if m.Win < 0 || m.Draw < 0 || m.Loss < 0 {
+		return fmt.Errorf("%s pays negative coins", mode)
+	}
+	return nil
+}
+
+// Sacrifice returns the nth sacrifice (from 0) a Pokemon may make, reporting
+// false once it has made them all
+func (r *Ruleset) Sacrifice(n int) (Sacrifice, bool) {
+	if n < 0 || n >= len(r.Sacrifices) {
+		return Sacrifice{}, false
+	}
+	return r.Sacrifices[n], true
+}
+
+// MaxSacrifices is how many times a Pokemon may sacrifice
+func (r *Ruleset) MaxSacrifices() int {
+	return len(r.Sacrifices)
+}
+
+// CanSacrifice reports whether a Pokemon's stamina is low enough for it to sacrifice
+func (r *Ruleset) CanSacrifice(stamina, maxStamina int) bool {
+	return float64(stamina) < r.SacrificeStaminaLimit*float64(maxStamina)
+}
+
+// DefendCost returns the stamina a Pokemon with hpMax pays to defend
+func (r *Ruleset) DefendCost(hpMax int) int {
+	return (hpMax + r.DefendCostDivisor - 1) / r.DefendCostDivisor
+}
+
+// Coins returns the coins a battle of mode pays on the server, by its winner
+// ("player", "ai" or "draw") from the rewarded player's side
+func (r *Ruleset) Coins(mode, winner string) int {
+	return coins(r.Rewards, mode, winner)
+}
+
+// OfflineCoins returns the coins a battle of mode pays in the offline CLI game
+func (r *Ruleset) OfflineCoins(mode, winner string) int {
+	return coins(r.OfflineRewards, mode, winner)
+}
+
+// coins picks the coins a battle's result pays
+func coins(rewards map[string]ModeRewards, mode, winner string) int {
+	m := rewards[mode]
+	switch winner {
+	case "player":
+		return m.Win
+	case "draw":
+		return m.Draw
+	}
+	return m.Loss
+}
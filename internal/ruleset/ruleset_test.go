@@ -0,0 +1,118 @@
+package ruleset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultMatchesTheClassicGame(t *testing.T) {
+	r := Default()
+	if err := r.Validate(); err != nil {
+		t.Fatalf("default ruleset invalid: %v", err)
+	}
+
+	for i, want := range []Sacrifice{{10, 0.5}, {15, 0.25}, {20, 0.15}} {
+		if got, ok := r.Sacrifice(i); !ok || got != want {
+			t.Errorf("sacrifice %d is %+v, want %+v", i, got, want)
+		}
+	}
+	if _, ok := r.Sacrifice(3); ok {
+		t.Error("a fourth sacrifice is allowed")
+	}
+	if !r.CanSacrifice(49, 100) || r.CanSacrifice(50, 100) {
+		t.Error("sacrificing isn't limited to below half stamina")
+	}
+	for hpMax, want := range map[int]int{100: 50, 101: 51, 1: 1} {
+		if got := r.DefendCost(hpMax); got != want {
+			t.Errorf("DefendCost(%d) = %d, want %d", hpMax, got, want)
+		}
+	}
+	if r.DefendDamageShare != 0.25 || r.LegendaryMultiplier != 2 {
+		t.Errorf("defend share %v and legendary multiplier %v, want 0.25 and 2", r.DefendDamageShare, r.LegendaryMultiplier)
+	}
+
+	tests := []struct {
+		mode, winner string
+		api, offline int
+	}{
+		{"1v1", "player", 50, 50},
+		{"1v1", "draw", 25, 25},
+		{"1v1", "ai", 10, 10},
+		{"5v5", "player", 150, 150},
+		{"5v5", "draw", 75, 75},
+		{"5v5", "ai", 10, 25},
+	}
+	for _, tt := range tests {
+		if got := r.Coins(tt.mode, tt.winner); got != tt.api {
+			t.Errorf("Coins(%s, %s) = %d, want %d", tt.mode, tt.winner, got, tt.api)
+		}
+		if got := r.OfflineCoins(tt.mode, tt.winner); got != tt.offline {
+			t.Errorf("OfflineCoins(%s, %s) = %d, want %d", tt.mode, tt.winner, got, tt.offline)
+		}
+	}
+}
+
+func TestParseKeepsDefaultsForMissingFields(t *testing.T) {
+	r, err := Parse([]byte(`{"name": "variant", "defend_cost_divisor": 4, "rewards": {"1v1": {"win": 80}}, "progression": {"level_cap": 30}}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if r.Name != "variant" || r.DefendCost(100) != 25 {
+		t.Errorf("variant not applied: %+v", r)
+	}
+	if r.DefendDamageShare != 0.25 || r.MaxSacrifices() != 3 {
+		t.Error("fields the variant leaves out lost their defaults")
+	}
+	if got := r.Coins("1v1", "player"); got != 80 {
+		t.Errorf("1v1 win pays %d coins, want the variant's", got)
+	}
+	if got := r.Coins("5v5", "player"); got != 150 {
+		t.Errorf("5v5 win pays %d coins, want the default", got)
+	}
+	if r.Progression.LevelCap != 30 || r.Progression.Curve.Base != 100 {
+		t.Errorf("progression %+v, want the default with the variant's level cap", r.Progression)
+	}
+}
+
+func TestParseRejectsInvalidRulesets(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":             `{`,
+		"no name":              `{"name": ""}`,
+		"negative HP cost":     `{"sacrifices": [{"hp_cost": -1, "stamina_gain": 0.5}]}`,
+		"zero defend divisor":  `{"defend_cost_divisor": 0}`,
+		"defend share above 1": `{"defend_damage_share": 1.5}`,
+		"no legendary damage":  `{"legendary_multiplier": 0}`,
+		"no rewards":           `{"rewards": null}`,
+		"negative coins":       `{"rewards": {"1v1": {"loss": -10}}}`,
+		"level cap above 50":   `{"progression": {"level_cap": 60}}`,
+		"no battle XP":         `{"progression": {"battle_xp": null}}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	defer Use(Current())
+
+	path := filepath.Join(t.TempDir(), "variant.json")
+	if err := os.WriteFile(path, []byte(`{"name": "variant", "legendary_multiplier": 1.5}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvVar, path)
+
+	r, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv failed: %v", err)
+	}
+	if r.Name != "variant" || Current() != r {
+		t.Error("the variant didn't become the current ruleset")
+	}
+
+	t.Setenv(EnvVar, filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := LoadFromEnv(); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
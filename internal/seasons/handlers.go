@@ -0,0 +1,92 @@
+package seasons
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles HTTP requests for seasons
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new seasons handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetCurrentSeason handles GET /api/seasons/current
+func (h *Handler) GetCurrentSeason(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	season, record, err := h.service.GetCurrent(c.Context(), userID)
+	if err != nil {
+		if errors.Is(err, ErrNoActiveSeason) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NO_ACTIVE_SEASON",
+					"message": "No season is running",
+				},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to retrieve current season",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	remaining := int(time.Until(season.EndsAt).Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return c.JSON(fiber.Map{
+		"season":            season,
+		"seconds_remaining": remaining,
+		"record":            record,
+		"tier":              TierFor(record.Wins),
+		"tiers":             Tiers,
+	})
+}
+
+// GetSeasonHistory handles GET /api/seasons/history
+func (h *Handler) GetSeasonHistory(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	snapshots, err := h.service.GetHistory(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to retrieve season history",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"seasons": snapshots,
+		"count":   len(snapshots),
+	})
+}
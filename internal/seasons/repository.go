@@ -0,0 +1,331 @@
+package seasons
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/glicko2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNoActiveSeason is returned when no season is running
+var ErrNoActiveSeason = errors.New("no active season")
+
+// Record is a player's results in the current season
+type Record struct {
+	UserID        int `json:"-"`
+	Wins          int `json:"wins"`
+	Losses        int `json:"losses"`
+	Draws         int `json:"draws"`
+	WinStreak     int `json:"win_streak"`
+	BestWinStreak int `json:"best_win_streak"`
+}
+
+// Repository handles database operations for seasons
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+// NewRepository creates a new seasons repository
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// Begin starts the transaction a rollover runs in
+func (r *Repository) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// GetActiveSeason returns the season currently running
+func (r *Repository) GetActiveSeason(ctx context.Context) (*database.Season, error) {
+	var season database.Season
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, starts_at, ends_at, status, closed_at
+		FROM seasons
+		WHERE status = 'active'
+	`).Scan(&season.ID, &season.Name, &season.StartsAt, &season.EndsAt, &season.Status, &season.ClosedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNoActiveSeason
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active season: %w", err)
+	}
+	return &season, nil
+}
+
+// GetRecord returns the user's results in the current season
+func (r *Repository) GetRecord(ctx context.Context, userID int) (*Record, error) {
+	record := &Record{UserID: userID}
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(season_wins, 0), COALESCE(season_losses, 0), COALESCE(season_draws, 0),
+			COALESCE(season_win_streak, 0), COALESCE(season_best_win_streak, 0)
+		FROM player_stats
+		WHERE user_id = $1
+	`, userID).Scan(&record.Wins, &record.Losses, &record.Draws, &record.WinStreak, &record.BestWinStreak)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get season record: %w", err)
+	}
+	return record, nil
+}
+
+// GetSnapshots returns the user's records in closed seasons, most recent first
+func (r *Repository) GetSnapshots(ctx context.Context, userID int) ([]database.SeasonSnapshot, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ss.season_id, s.name, ss.user_id, ss.wins, ss.losses, ss.draws, ss.best_win_streak,
+			ss.tier, ss.coins_rewarded, ss.cards_rewarded, ss.created_at
+		FROM season_snapshots ss
+		JOIN seasons s ON s.id = ss.season_id
+		WHERE ss.user_id = $1
+		ORDER BY ss.season_id DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []database.SeasonSnapshot{}
+	for rows.Next() {
+		var snapshot database.SeasonSnapshot
+		err := rows.Scan(
+			&snapshot.SeasonID, &snapshot.SeasonName, &snapshot.UserID,
+			&snapshot.Wins, &snapshot.Losses, &snapshot.Draws, &snapshot.BestWinStreak,
+			&snapshot.Tier, &snapshot.CoinsRewarded, &snapshot.CardsRewarded, &snapshot.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan season snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating season snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// CreateSeason starts a season running from startsAt to endsAt. It fails if a season is already active.
+func (r *Repository) CreateSeason(ctx context.Context, startsAt, endsAt time.Time) (*database.Season, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	season, err := r.CreateSeasonInTx(ctx, tx, startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return season, nil
+}
+
+// CreateSeasonInTx starts a season running from startsAt to endsAt, numbered after the seasons before it
+func (r *Repository) CreateSeasonInTx(ctx context.Context, tx pgx.Tx, startsAt, endsAt time.Time) (*database.Season, error) {
+	var season database.Season
+	err := tx.QueryRow(ctx, `
+		INSERT INTO seasons (name, starts_at, ends_at, status)
+		VALUES ('Season ' || (SELECT COUNT(*) + 1 FROM seasons), $1, $2, 'active')
+		RETURNING id, name, starts_at, ends_at, status, closed_at
+	`, startsAt, endsAt).Scan(&season.ID, &season.Name, &season.StartsAt, &season.EndsAt, &season.Status, &season.ClosedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create season: %w", err)
+	}
+	return &season, nil
+}
+
+// GetEndedSeasonInTx locks and returns the active season if it has ended by now, or nil
+func (r *Repository) GetEndedSeasonInTx(ctx context.Context, tx pgx.Tx, now time.Time) (*database.Season, error) {
+	var season database.Season
+	err := tx.QueryRow(ctx, `
+		SELECT id, name, starts_at, ends_at, status, closed_at
+		FROM seasons
+		WHERE status = 'active' AND ends_at <= $1
+		FOR UPDATE
+	`, now).Scan(&season.ID, &season.Name, &season.StartsAt, &season.EndsAt, &season.Status, &season.ClosedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ended season: %w", err)
+	}
+	return &season, nil
+}
+
+// GetRecordsInTx locks and returns the season records of everyone who battled this season
+func (r *Repository) GetRecordsInTx(ctx context.Context, tx pgx.Tx) ([]Record, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT user_id, season_wins, season_losses, season_draws, season_win_streak, season_best_win_streak
+		FROM player_stats
+		WHERE season_wins + season_losses + season_draws > 0
+		ORDER BY user_id
+		FOR UPDATE
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		err := rows.Scan(&record.UserID, &record.Wins, &record.Losses, &record.Draws, &record.WinStreak, &record.BestWinStreak)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan season record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating season records: %w", err)
+	}
+
+	return records, nil
+}
+
+// InsertSnapshotInTx stores a player's record in a closed season. It reports
+// false if the snapshot already exists, in which case it was already rewarded.
+func (r *Repository) InsertSnapshotInTx(ctx context.Context, tx pgx.Tx, seasonID int, record Record, tier Tier) (bool, error) {
+	result, err := tx.Exec(ctx, `
+		INSERT INTO season_snapshots (season_id, user_id, wins, losses, draws, best_win_streak, tier, coins_rewarded, cards_rewarded)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (season_id, user_id) DO NOTHING
+	`, seasonID, record.UserID, record.Wins, record.Losses, record.Draws, record.BestWinStreak, tier.Name, tier.Coins, tier.Cards)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert season snapshot: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// GrantCoinsInTx adds coins to the user's balance
+func (r *Repository) GrantCoinsInTx(ctx context.Context, tx pgx.Tx, userID, coins int) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE users
+		SET coins = coins + $1
+		WHERE id = $2
+	`, coins, userID)
+	if err != nil {
+		return fmt.Errorf("failed to grant coins: %w", err)
+	}
+	return nil
+}
+
+// GrantCardInTx adds a level 1 card to the user's collection
+func (r *Repository) GrantCardInTx(ctx context.Context, tx pgx.Tx, userID int, card pokemon.Card) error {
+	typesJSON, err := json.Marshal(card.Types)
+	if err != nil {
+		return fmt.Errorf("failed to marshal types: %w", err)
+	}
+
+	movesJSON, err := json.Marshal(card.Moves)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moves: %w", err)
+	}
+
+	isLegendary, isMythical := pokemon.IsLegendaryOrMythical(card.Name)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO player_cards (
+			user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
+			base_sp_attack, base_sp_defense,
+			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position
+		)
+		VALUES ($1, $2, 1, 0, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, FALSE, NULL)
+	`, userID, card.Name, card.HPMax, card.Attack, card.Defense, card.Speed,
+		card.SpAttack, card.SpDefense,
+		typesJSON, movesJSON, card.Sprite, isLegendary, isMythical)
+	if err != nil {
+		return fmt.Errorf("failed to grant card: %w", err)
+	}
+	return nil
+}
+
+// ResetRecordsInTx zeroes everyone's season counters
+func (r *Repository) ResetRecordsInTx(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE player_stats
+		SET season_wins = 0,
+		    season_losses = 0,
+		    season_draws = 0,
+		    season_win_streak = 0,
+		    season_best_win_streak = 0,
+		    updated_at = NOW()
+		WHERE season_wins + season_losses + season_draws > 0
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to reset season counters: %w", err)
+	}
+	return nil
+}
+
+// SoftResetRatingsInTx replaces every rating, on both ladders and in both modes,
+// with what reset makes of it
+func (r *Repository) SoftResetRatingsInTx(ctx context.Context, tx pgx.Tx, reset func(glicko2.Rating) glicko2.Rating) error {
+	type ratingKey struct {
+		userID       int
+		mode, ladder string
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT user_id, mode, ladder, rating, deviation, volatility
+		FROM player_ratings
+		FOR UPDATE
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to get ratings: %w", err)
+	}
+
+	ratings := make(map[ratingKey]glicko2.Rating)
+	for rows.Next() {
+		var key ratingKey
+		var rating glicko2.Rating
+		if err := rows.Scan(&key.userID, &key.mode, &key.ladder, &rating.Rating, &rating.Deviation, &rating.Volatility); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings[key] = rating
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating ratings: %w", err)
+	}
+
+	for key, rating := range ratings {
+		rating = reset(rating)
+		_, err := tx.Exec(ctx, `
+			UPDATE player_ratings
+			SET rating = $1, deviation = $2, volatility = $3
+			WHERE user_id = $4 AND mode = $5 AND ladder = $6
+		`, rating.Rating, rating.Deviation, rating.Volatility, key.userID, key.mode, key.ladder)
+		if err != nil {
+			return fmt.Errorf("failed to reset rating: %w", err)
+		}
+	}
+	return nil
+}
+
+// CloseSeasonInTx marks a season as closed
+func (r *Repository) CloseSeasonInTx(ctx context.Context, tx pgx.Tx, seasonID int, closedAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE seasons
+		SET status = 'closed', closed_at = $1
+		WHERE id = $2
+	`, closedAt, seasonID)
+	if err != nil {
+		return fmt.Errorf("failed to close season: %w", err)
+	}
+	return nil
+}
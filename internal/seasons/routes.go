@@ -0,0 +1,15 @@
+package seasons
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterRoutes(app *fiber.App, handler *Handler, authMiddleware fiber.Handler) {
+	seasons := app.Group("/api/seasons")
+
+	seasons.Use(authMiddleware)
+
+	seasons.Get("/current", handler.GetCurrentSeason)
+
+	seasons.Get("/history", handler.GetSeasonHistory)
+}
@@ -0,0 +1,203 @@
+package seasons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pokemon-cli/internal/database"
+	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/pkg/glicko2"
+	"pokemon-cli/pkg/logger"
+	"pokemon-cli/pkg/rng"
+	"time"
+)
+
+const (
+	// SeasonLength is how long each season runs
+	SeasonLength = 30 * 24 * time.Hour
+
+	rolloverInterval = time.Minute // How often the scheduler checks whether the season has ended
+
+	// At rollover every rating keeps this share of its distance from the
+	// default and has its deviation raised to at least resetDeviation
+	resetKeep      = 0.5
+	resetDeviation = 150
+)
+
+// Service runs seasons: it closes each one when it ends, rewards its players and starts the next
+type Service struct {
+	repo   *Repository
+	logger *logger.Logger
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewService creates a new seasons service
+func NewService(repo *Repository, logger *logger.Logger) *Service {
+	return &Service{repo: repo, logger: logger}
+}
+
+// GetCurrent returns the active season and the user's record in it
+func (s *Service) GetCurrent(ctx context.Context, userID int) (*database.Season, *Record, error) {
+	season, err := s.repo.GetActiveSeason(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	record, err := s.repo.GetRecord(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return season, record, nil
+}
+
+// GetHistory returns the user's records in closed seasons
+func (s *Service) GetHistory(ctx context.Context, userID int) ([]database.SeasonSnapshot, error) {
+	return s.repo.GetSnapshots(ctx, userID)
+}
+
+// Start runs the rollover scheduler in a goroutine until Stop is called
+func (s *Service) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx)
+}
+
+// Stop ends the scheduler, waiting for a rollover in progress to finish
+func (s *Service) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+}
+
+// run rolls seasons over now and then every rolloverInterval until ctx is canceled
+func (s *Service) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(rolloverInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Rollover(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			s.logger.Error("Season rollover failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Rollover closes the active season if it has ended by now and starts the
+// next one, or starts the first season if there is none. Seasons close one at
+// a time in their own transaction, so a rollover interrupted by a restart
+// leaves nothing behind and simply runs again.
+func (s *Service) Rollover(ctx context.Context, now time.Time) error {
+	for {
+		closed, err := s.closeEndedSeason(ctx, now)
+		if err != nil {
+			return err
+		}
+		if !closed {
+			break
+		}
+	}
+
+	if _, err := s.repo.GetActiveSeason(ctx); !errors.Is(err, ErrNoActiveSeason) {
+		return err
+	}
+
+	season, err := s.repo.CreateSeason(ctx, now, now.Add(SeasonLength))
+	if err != nil {
+		return err
+	}
+	s.logger.Info("Season started", "season", season.Name, "ends_at", season.EndsAt)
+	return nil
+}
+
+// closeEndedSeason closes the active season if it has ended by now, reporting whether it did
+func (s *Service) closeEndedSeason(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	season, err := s.repo.GetEndedSeasonInTx(ctx, tx, now)
+	if err != nil || season == nil {
+		return false, err
+	}
+
+	records, err := s.repo.GetRecordsInTx(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, record := range records {
+		tier := TierFor(record.Wins)
+
+		// A player who already has a snapshot of this season was already rewarded
+		inserted, err := s.repo.InsertSnapshotInTx(ctx, tx, season.ID, record, tier)
+		if err != nil {
+			return false, err
+		}
+		if !inserted {
+			continue
+		}
+
+		if err := s.repo.GrantCoinsInTx(ctx, tx, record.UserID, tier.Coins); err != nil {
+			return false, err
+		}
+		for _, card := range rewardCards(season.ID, record.UserID, tier.Cards) {
+			if err := s.repo.GrantCardInTx(ctx, tx, record.UserID, card); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	// Soft reset: the season counters start over, and ratings move back
+	// towards the default without losing the ranking they carry
+	if err := s.repo.ResetRecordsInTx(ctx, tx); err != nil {
+		return false, err
+	}
+	if err := s.repo.SoftResetRatingsInTx(ctx, tx, softReset); err != nil {
+		return false, err
+	}
+	if err := s.repo.CloseSeasonInTx(ctx, tx, season.ID, now); err != nil {
+		return false, err
+	}
+
+	// The next season picks up where this one ended
+	next, err := s.repo.CreateSeasonInTx(ctx, tx, season.EndsAt, season.EndsAt.Add(SeasonLength))
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("Season closed", "season", season.Name, "players", len(records), "next_season", next.Name)
+	return true, nil
+}
+
+// softReset is the rating a player starts the next season with
+func softReset(rating glicko2.Rating) glicko2.Rating {
+	return glicko2.SoftReset(rating, resetKeep, resetDeviation)
+}
+
+// rewardCards draws the cards a player is granted for a season. The draw is
+// seeded by the season and player, so a rerun rollover grants the same cards.
+func rewardCards(seasonID, userID, count int) []pokemon.Card {
+	r := rng.New(int64(seasonID)<<32 | int64(userID))
+	cards := make([]pokemon.Card, count)
+	for i := range cards {
+		cards[i] = pokemon.FetchRandomPokemonCardOfflineWithRand(r)
+	}
+	return cards
+}
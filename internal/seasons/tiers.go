@@ -0,0 +1,28 @@
+package seasons
+
+// Tier is a reward bracket reached by winning battles during a season
+type Tier struct {
+	Name    string `json:"name"`
+	MinWins int    `json:"min_wins"`
+	Coins   int    `json:"coins"` // Coins granted when the season closes
+	Cards   int    `json:"cards"` // Random cards granted when the season closes
+}
+
+// Tiers from highest to lowest. Anyone who battled during a season reaches at least the last one.
+var Tiers = []Tier{
+	{Name: "master", MinWins: 100, Coins: 1200, Cards: 3},
+	{Name: "platinum", MinWins: 50, Coins: 600, Cards: 2},
+	{Name: "gold", MinWins: 25, Coins: 300, Cards: 1},
+	{Name: "silver", MinWins: 10, Coins: 150},
+	{Name: "bronze", MinWins: 0, Coins: 50},
+}
+
+// TierFor returns the highest tier reached with wins
+func TierFor(wins int) Tier {
+	for _, tier := range Tiers {
+		if wins >= tier.MinWins {
+			return tier
+		}
+	}
+	return Tiers[len(Tiers)-1]
+}
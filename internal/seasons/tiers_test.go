@@ -0,0 +1,35 @@
+package seasons
+
+import "testing"
+
+func TestTierFor(t *testing.T) {
+	tests := []struct {
+		wins int
+		want string
+	}{
+		{0, "bronze"},
+		{9, "bronze"},
+		{10, "silver"},
+		{24, "silver"},
+		{25, "gold"},
+		{50, "platinum"},
+		{99, "platinum"},
+		{100, "master"},
+		{1000, "master"},
+	}
+
+	for _, tt := range tests {
+		if got := TierFor(tt.wins); got.Name != tt.want {
+			t.Errorf("TierFor(%d) = %s, want %s", tt.wins, got.Name, tt.want)
+		}
+	}
+}
+
+func TestTierRewardsGrowWithTier(t *testing.T) {
+	for i := 1; i < len(Tiers); i++ {
+		higher, lower := Tiers[i-1], Tiers[i]
+		if higher.MinWins <= lower.MinWins || higher.Coins <= lower.Coins || higher.Cards < lower.Cards {
+			t.Errorf("tier %s should require more wins and reward more than %s", higher.Name, lower.Name)
+		}
+	}
+}
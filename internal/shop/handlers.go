@@ -1,9 +1,12 @@
 package shop
 
 import (
+	"errors"
 	"regexp"
 	"strings"
 
+	"pokemon-cli/internal/pokemon"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -142,3 +145,180 @@ func (h *Handler) Purchase(c *fiber.Ctx) error {
 		RemainingCoins: remainingCoins,
 	})
 }
+
+// GetHeldItems handles GET /api/shop/items
+func (h *Handler) GetHeldItems(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"items": pokemon.HeldItems})
+}
+
+// PurchaseHeldItem handles POST /api/shop/items/purchase
+func (h *Handler) PurchaseHeldItem(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	var req HeldItemPurchaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_REQUEST",
+				"message": "Invalid request body",
+			},
+		})
+	}
+
+	item, ok := pokemon.GetHeldItem(strings.TrimSpace(strings.ToLower(req.Item)))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "ITEM_NOT_FOUND",
+				"message": "Held item not found",
+			},
+		})
+	}
+
+	err := h.repository.PurchaseHeldItem(c.Context(), userID, req.CardID, item.Name, item.Price)
+	switch {
+	case errors.Is(err, ErrCardNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "CARD_NOT_FOUND",
+				"message": "Card not found in your collection",
+			},
+		})
+	case err != nil && strings.HasPrefix(err.Error(), "insufficient coins"):
+		return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INSUFFICIENT_COINS",
+				"message": err.Error(),
+			},
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "PURCHASE_FAILED",
+				"message": "Failed to complete purchase",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	remainingCoins, err := h.repository.GetUserCoins(c.Context(), userID)
+	if err != nil {
+		remainingCoins = 0 // Fallback
+	}
+
+	return c.JSON(HeldItemPurchaseResponse{
+		CardID:         req.CardID,
+		HeldItem:       item.Name,
+		RemainingCoins: remainingCoins,
+	})
+}
+
+// maxBattleItemPurchase is the most battle items of a kind bought at once
+const maxBattleItemPurchase = 99
+
+// GetBag handles GET /api/shop/bag
+func (h *Handler) GetBag(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	bag, err := h.repository.GetUserBag(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch bag",
+			},
+		})
+	}
+
+	return c.JSON(fiber.Map{"items": pokemon.BattleItems, "bag": bag})
+}
+
+// PurchaseBattleItems handles POST /api/shop/bag/purchase
+func (h *Handler) PurchaseBattleItems(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	var req BattleItemPurchaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_REQUEST",
+				"message": "Invalid request body",
+			},
+		})
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	if req.Quantity < 0 || req.Quantity > maxBattleItemPurchase {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_QUANTITY",
+				"message": "Quantity must be between 1 and 99",
+			},
+		})
+	}
+
+	item, _, ok := pokemon.GetBattleItem(strings.TrimSpace(strings.ToLower(req.Item)))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "ITEM_NOT_FOUND",
+				"message": "Battle item not found",
+			},
+		})
+	}
+
+	err := h.repository.PurchaseBattleItems(c.Context(), userID, item.Name, req.Quantity, item.Price)
+	switch {
+	case err != nil && strings.HasPrefix(err.Error(), "insufficient coins"):
+		return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INSUFFICIENT_COINS",
+				"message": err.Error(),
+			},
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "PURCHASE_FAILED",
+				"message": "Failed to complete purchase",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	remainingCoins, err := h.repository.GetUserCoins(c.Context(), userID)
+	if err != nil {
+		remainingCoins = 0 // Fallback
+	}
+
+	return c.JSON(BattleItemPurchaseResponse{
+		Item:           item.Name,
+		Quantity:       req.Quantity,
+		RemainingCoins: remainingCoins,
+	})
+}
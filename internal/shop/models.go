@@ -16,11 +16,13 @@ type ShopItem struct {
 	Types       []string `json:"types"`
 	InStock     bool     `json:"in_stock"`
 	// Full card details for display
-	BaseHP      int            `json:"base_hp"`
-	BaseAttack  int            `json:"base_attack"`
-	BaseDefense int            `json:"base_defense"`
-	BaseSpeed   int            `json:"base_speed"`
-	Moves       []pokemon.Move `json:"moves"`
+	BaseHP        int            `json:"base_hp"`
+	BaseAttack    int            `json:"base_attack"`
+	BaseDefense   int            `json:"base_defense"`
+	BaseSpAttack  int            `json:"base_sp_attack"`
+	BaseSpDefense int            `json:"base_sp_defense"`
+	BaseSpeed     int            `json:"base_speed"`
+	Moves         []pokemon.Move `json:"moves"`
 }
 
 // ShopInventory represents the current shop state
@@ -41,3 +43,29 @@ type PurchaseResponse struct {
 	Card           any `json:"card"`
 	RemainingCoins int `json:"remaining_coins"`
 }
+
+// HeldItemPurchaseRequest represents a request to buy a held item for a card
+type HeldItemPurchaseRequest struct {
+	Item   string `json:"item"`
+	CardID int    `json:"card_id"` // Card that holds the item, replacing the one it held
+}
+
+// HeldItemPurchaseResponse represents a held item purchase response
+type HeldItemPurchaseResponse struct {
+	CardID         int    `json:"card_id"`
+	HeldItem       string `json:"held_item"`
+	RemainingCoins int    `json:"remaining_coins"`
+}
+
+// BattleItemPurchaseRequest represents a request to buy battle items for the user's bag
+type BattleItemPurchaseRequest struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"` // Defaults to 1
+}
+
+// BattleItemPurchaseResponse represents a battle item purchase response
+type BattleItemPurchaseResponse struct {
+	Item           string `json:"item"`
+	Quantity       int    `json:"quantity"`
+	RemainingCoins int    `json:"remaining_coins"`
+}
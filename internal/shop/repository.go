@@ -3,6 +3,7 @@ package shop
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,9 @@ import (
 	"pokemon-cli/internal/pokemon"
 )
 
+// ErrCardNotFound is returned when buying a held item for a card the user doesn't own
+var ErrCardNotFound = errors.New("card not found")
+
 // Repository handles shop data access
 type Repository struct {
 	db *pgxpool.Pool
@@ -77,36 +81,40 @@ func (r *Repository) PurchaseCard(ctx context.Context, userID int, pokemonName s
 
 	// Create player card at level 1
 	playerCard := &database.PlayerCard{
-		UserID:       userID,
-		PokemonName:  card.Name,
-		Level:        1,
-		XP:           0,
-		BaseHP:       card.HPMax,
-		BaseAttack:   card.Attack,
-		BaseDefense:  card.Defense,
-		BaseSpeed:    card.Speed,
-		Types:        typesJSON,
-		Moves:        movesJSON,
-		Sprite:       card.Sprite,
-		IsLegendary:  isLegendary,
-		IsMythical:   isMythical,
-		InDeck:       false,
-		DeckPosition: nil,
+		UserID:        userID,
+		PokemonName:   card.Name,
+		Level:         1,
+		XP:            0,
+		BaseHP:        card.HPMax,
+		BaseAttack:    card.Attack,
+		BaseDefense:   card.Defense,
+		BaseSpAttack:  card.SpAttack,
+		BaseSpDefense: card.SpDefense,
+		BaseSpeed:     card.Speed,
+		Types:         typesJSON,
+		Moves:         movesJSON,
+		Sprite:        card.Sprite,
+		IsLegendary:   isLegendary,
+		IsMythical:    isMythical,
+		InDeck:        false,
+		DeckPosition:  nil,
 	}
 
 	// Insert card into database
 	query := `
 		INSERT INTO player_cards (
 			user_id, pokemon_name, level, xp, base_hp, base_attack, base_defense, base_speed,
+			base_sp_attack, base_sp_defense,
 			types, moves, sprite, is_legendary, is_mythical, in_deck, deck_position
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id, created_at, updated_at
 	`
 
 	err = tx.QueryRow(ctx, query,
 		playerCard.UserID, playerCard.PokemonName, playerCard.Level, playerCard.XP,
 		playerCard.BaseHP, playerCard.BaseAttack, playerCard.BaseDefense, playerCard.BaseSpeed,
+		playerCard.BaseSpAttack, playerCard.BaseSpDefense,
 		playerCard.Types, playerCard.Moves, playerCard.Sprite,
 		playerCard.IsLegendary, playerCard.IsMythical, playerCard.InDeck, playerCard.DeckPosition,
 	).Scan(&playerCard.ID, &playerCard.CreatedAt, &playerCard.UpdatedAt)
@@ -123,6 +131,121 @@ func (r *Repository) PurchaseCard(ctx context.Context, userID int, pokemonName s
 	return playerCard, nil
 }
 
+// PurchaseHeldItem charges the user price coins for item and gives it to one
+// of their cards, replacing the item it held
+func (r *Repository) PurchaseHeldItem(ctx context.Context, userID, cardID int, item string, price int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var currentCoins int
+	err = tx.QueryRow(ctx, `SELECT coins FROM users WHERE id = $1`, userID).Scan(&currentCoins)
+	if err != nil {
+		return fmt.Errorf("failed to get user coins: %w", err)
+	}
+	if currentCoins < price {
+		return fmt.Errorf("insufficient coins: have %d, need %d", currentCoins, price)
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE player_cards
+		SET held_item = $1, updated_at = $2
+		WHERE id = $3 AND user_id = $4
+	`, item, time.Now(), cardID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to give held item: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCardNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET coins = coins - $1, updated_at = $2
+		WHERE id = $3
+	`, price, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to deduct coins: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// PurchaseBattleItems charges the user price coins for each of quantity of
+// item and puts them in their bag
+func (r *Repository) PurchaseBattleItems(ctx context.Context, userID int, item string, quantity, price int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	total := price * quantity
+	var currentCoins int
+	err = tx.QueryRow(ctx, `SELECT coins FROM users WHERE id = $1`, userID).Scan(&currentCoins)
+	if err != nil {
+		return fmt.Errorf("failed to get user coins: %w", err)
+	}
+	if currentCoins < total {
+		return fmt.Errorf("insufficient coins: have %d, need %d", currentCoins, total)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_items (user_id, item, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, item) DO UPDATE SET quantity = user_items.quantity + EXCLUDED.quantity
+	`, userID, item, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to add items to bag: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET coins = coins - $1, updated_at = $2
+		WHERE id = $3
+	`, total, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to deduct coins: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetUserBag retrieves the battle items in the user's bag
+func (r *Repository) GetUserBag(ctx context.Context, userID int) ([]database.UserItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, item, quantity
+		FROM user_items
+		WHERE user_id = $1 AND quantity > 0
+		ORDER BY item
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user bag: %w", err)
+	}
+	defer rows.Close()
+
+	items := []database.UserItem{}
+	for rows.Next() {
+		var item database.UserItem
+		if err := rows.Scan(&item.UserID, &item.Item, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating items: %w", err)
+	}
+	return items, nil
+}
+
 // GetUserCoins retrieves the user's current coin balance
 func (r *Repository) GetUserCoins(ctx context.Context, userID int) (int, error) {
 	var coins int
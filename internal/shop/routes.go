@@ -21,6 +21,20 @@ func RegisterRoutes(app *fiber.App, handler *Handler, authMiddleware fiber.Handl
 	// POST /api/shop/purchase - Purchase a Pokemon card
 	// Rate limit: 10 purchases per minute
 	shop.Post("/purchase", createPurchaseRateLimiter(), handler.Purchase)
+
+	// GET /api/shop/items - List the held items for sale
+	shop.Get("/items", handler.GetHeldItems)
+
+	// POST /api/shop/items/purchase - Buy a held item for one of the user's cards
+	// Rate limit: 10 purchases per minute
+	shop.Post("/items/purchase", createPurchaseRateLimiter(), handler.PurchaseHeldItem)
+
+	// GET /api/shop/bag - List the battle items for sale and the ones in the user's bag
+	shop.Get("/bag", handler.GetBag)
+
+	// POST /api/shop/bag/purchase - Buy battle items for the user's bag
+	// Rate limit: 10 purchases per minute
+	shop.Post("/bag/purchase", createPurchaseRateLimiter(), handler.PurchaseBattleItems)
 }
 
 // createPurchaseRateLimiter creates a rate limiter for purchase endpoint
@@ -96,19 +96,21 @@ func (s *Service) generateInventory() {
 		}
 
 		items = append(items, ShopItem{
-			PokemonName: card.Name,
-			Price:       price,
-			Rarity:      rarity,
-			IsLegendary: false,
-			IsMythical:  false,
-			Sprite:      card.Sprite,
-			Types:       card.Types,
-			InStock:     true,
-			BaseHP:      card.HPMax,
-			BaseAttack:  card.Attack,
-			BaseDefense: card.Defense,
-			BaseSpeed:   card.Speed,
-			Moves:       card.Moves,
+			PokemonName:   card.Name,
+			Price:         price,
+			Rarity:        rarity,
+			IsLegendary:   false,
+			IsMythical:    false,
+			Sprite:        card.Sprite,
+			Types:         card.Types,
+			InStock:       true,
+			BaseHP:        card.HPMax,
+			BaseAttack:    card.Attack,
+			BaseDefense:   card.Defense,
+			BaseSpAttack:  card.SpAttack,
+			BaseSpDefense: card.SpDefense,
+			BaseSpeed:     card.Speed,
+			Moves:         card.Moves,
 		})
 	}
 
@@ -124,19 +126,21 @@ func (s *Service) generateInventory() {
 		}
 
 		items = append(items, ShopItem{
-			PokemonName: card.Name,
-			Price:       500,
-			Rarity:      "rare",
-			IsLegendary: false,
-			IsMythical:  false,
-			Sprite:      card.Sprite,
-			Types:       card.Types,
-			InStock:     true,
-			BaseHP:      card.HPMax,
-			BaseAttack:  card.Attack,
-			BaseDefense: card.Defense,
-			BaseSpeed:   card.Speed,
-			Moves:       card.Moves,
+			PokemonName:   card.Name,
+			Price:         500,
+			Rarity:        "rare",
+			IsLegendary:   false,
+			IsMythical:    false,
+			Sprite:        card.Sprite,
+			Types:         card.Types,
+			InStock:       true,
+			BaseHP:        card.HPMax,
+			BaseAttack:    card.Attack,
+			BaseDefense:   card.Defense,
+			BaseSpAttack:  card.SpAttack,
+			BaseSpDefense: card.SpDefense,
+			BaseSpeed:     card.Speed,
+			Moves:         card.Moves,
 		})
 	}
 
@@ -169,19 +173,21 @@ func (s *Service) generateInventory() {
 			}
 
 			items = append(items, ShopItem{
-				PokemonName: card.Name,
-				Price:       price,
-				Rarity:      rarity,
-				IsLegendary: isLegendary,
-				IsMythical:  isMythical,
-				Sprite:      card.Sprite,
-				Types:       card.Types,
-				InStock:     true,
-				BaseHP:      card.HPMax,
-				BaseAttack:  card.Attack,
-				BaseDefense: card.Defense,
-				BaseSpeed:   card.Speed,
-				Moves:       card.Moves,
+				PokemonName:   card.Name,
+				Price:         price,
+				Rarity:        rarity,
+				IsLegendary:   isLegendary,
+				IsMythical:    isMythical,
+				Sprite:        card.Sprite,
+				Types:         card.Types,
+				InStock:       true,
+				BaseHP:        card.HPMax,
+				BaseAttack:    card.Attack,
+				BaseDefense:   card.Defense,
+				BaseSpAttack:  card.SpAttack,
+				BaseSpDefense: card.SpDefense,
+				BaseSpeed:     card.Speed,
+				Moves:         card.Moves,
 			})
 		}
 	}
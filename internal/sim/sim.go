@@ -0,0 +1,384 @@
+// Package sim plays large numbers of AI-vs-AI battles with no UI and
+// summarizes how they went, for tuning the game's numbers.
+package sim
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"pokemon-cli/internal/battle"
+	"pokemon-cli/internal/pokemon"
+	"pokemon-cli/internal/ruleset"
+	"pokemon-cli/pkg/rng"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultMaxTurns is how many turns a battle may last before it is called off
+const DefaultMaxTurns = 500
+
+// Config describes a simulation run
+type Config struct {
+	Battles          int          // Battles to play
+	Mode             string       // "1v1" or "5v5"
+	Seed             int64        // Every battle's seed, and its decks when drawn at random, derive from this
+	Workers          int          // Battles played at once; 0 means one per CPU
+	PlayerDifficulty string       // Strategy playing the player side
+	AIDifficulty     string       // Strategy playing the AI side
+	PlayerDeck       []string     // Species the player side brings; empty draws a random deck for every battle
+	AIDeck           []string     // Species the AI side brings; empty draws a random deck for every battle
+	MaxTurns         int          // Turns after which a battle is called off; 0 means DefaultMaxTurns
+	Rules            battle.Rules // Optional rules every battle is played with
+}
+
+// Report summarizes a simulation run
+type Report struct {
+	Battles          int            `json:"battles"`
+	Mode             string         `json:"mode"`
+	Seed             int64          `json:"seed"`
+	PlayerDifficulty string         `json:"player_difficulty"`
+	AIDifficulty     string         `json:"ai_difficulty"`
+	Rules            battle.Rules   `json:"rules"`
+	Ruleset          string         `json:"ruleset"` // Name of the ruleset the battles were played under
+	PlayerWins       int            `json:"player_wins"`
+	AIWins           int            `json:"ai_wins"`
+	Draws            int            `json:"draws"`
+	Unfinished       int            `json:"unfinished"` // Battles called off after MaxTurns
+	PlayerWinRate    float64        `json:"player_win_rate"`
+	AIWinRate        float64        `json:"ai_win_rate"`
+	DrawRate         float64        `json:"draw_rate"`
+	AverageTurns     float64        `json:"average_turns"`
+	Actions          map[string]int `json:"actions"` // How often each action was chosen, by both sides
+	Species          []SpeciesStats `json:"species"`
+	Moves            []MoveStats    `json:"moves"`
+}
+
+// SpeciesStats is how a species fared over the battles it was brought to
+type SpeciesStats struct {
+	Name    string  `json:"name"`
+	Battles int     `json:"battles"` // Sides it was on; a mirror match counts twice
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"win_rate"`
+}
+
+// MoveStats is how often an attack was used
+type MoveStats struct {
+	Name  string  `json:"name"`
+	Uses  int     `json:"uses"`
+	Share float64 `json:"share"` // Fraction of all attacks
+}
+
+// result is the outcome of one battle
+type result struct {
+	winner   string // "player", "ai", "draw", or "" if called off
+	turns    int
+	species  map[string][]string // Species each side brought
+	actions  map[string]int
+	moveUses map[string]int
+	err      error
+}
+
+// Run plays cfg.Battles battles across cfg.Workers goroutines. Each battle's
+// seed is drawn up front from cfg.Seed and results are combined in battle
+// order, so the same config always produces the same report however many
+// workers play it.
+func Run(cfg Config) (*Report, error) {
+	if err := cfg.normalize(); err != nil {
+		return nil, err
+	}
+
+	seeds := make([]int64, cfg.Battles)
+	r := rng.New(cfg.Seed)
+	for i := range seeds {
+		seeds[i] = r.Int63()
+	}
+
+	results := make([]result, cfg.Battles)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = playBattle(cfg, seeds[i])
+			}
+		}()
+	}
+	for i := range seeds {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := &Report{
+		Battles:          cfg.Battles,
+		Mode:             cfg.Mode,
+		Seed:             cfg.Seed,
+		PlayerDifficulty: cfg.PlayerDifficulty,
+		AIDifficulty:     cfg.AIDifficulty,
+		Rules:            cfg.Rules,
+		Ruleset:          ruleset.Current().Name,
+		Actions:          map[string]int{},
+	}
+	species := map[string]*SpeciesStats{}
+	moves := map[string]int{}
+	totalTurns, totalAttacks := 0, 0
+
+	for i, res := range results {
+		if res.err != nil {
+			return nil, fmt.Errorf("battle %d (seed %d): %w", i+1, seeds[i], res.err)
+		}
+
+		switch res.winner {
+		case "player":
+			report.PlayerWins++
+		case "ai":
+			report.AIWins++
+		case "draw":
+			report.Draws++
+		default:
+			report.Unfinished++
+		}
+		totalTurns += res.turns
+
+		for side, names := range res.species {
+			for _, name := range names {
+				stats := species[name]
+				if stats == nil {
+					stats = &SpeciesStats{Name: name}
+					species[name] = stats
+				}
+				stats.Battles++
+				if res.winner == side {
+					stats.Wins++
+				}
+			}
+		}
+		for action, count := range res.actions {
+			report.Actions[action] += count
+		}
+		for move, count := range res.moveUses {
+			moves[move] += count
+			totalAttacks += count
+		}
+	}
+
+	n := float64(cfg.Battles)
+	report.PlayerWinRate = float64(report.PlayerWins) / n
+	report.AIWinRate = float64(report.AIWins) / n
+	report.DrawRate = float64(report.Draws) / n
+	report.AverageTurns = float64(totalTurns) / n
+
+	for _, stats := range species {
+		stats.WinRate = float64(stats.Wins) / float64(stats.Battles)
+		report.Species = append(report.Species, *stats)
+	}
+	sort.Slice(report.Species, func(i, j int) bool { return report.Species[i].Name < report.Species[j].Name })
+
+	for name, uses := range moves {
+		report.Moves = append(report.Moves, MoveStats{Name: name, Uses: uses, Share: float64(uses) / float64(totalAttacks)})
+	}
+	sort.Slice(report.Moves, func(i, j int) bool {
+		if report.Moves[i].Uses != report.Moves[j].Uses {
+			return report.Moves[i].Uses > report.Moves[j].Uses
+		}
+		return report.Moves[i].Name < report.Moves[j].Name
+	})
+
+	return report, nil
+}
+
+// normalize checks cfg and fills in its defaults
+func (cfg *Config) normalize() error {
+	if cfg.Battles <= 0 {
+		return fmt.Errorf("number of battles must be positive")
+	}
+	if cfg.Mode != "1v1" && cfg.Mode != "5v5" {
+		return fmt.Errorf("invalid battle mode: %s", cfg.Mode)
+	}
+
+	var err error
+	if cfg.PlayerDifficulty, err = battle.ParseDifficulty(cfg.PlayerDifficulty); err != nil {
+		return err
+	}
+	if cfg.AIDifficulty, err = battle.ParseDifficulty(cfg.AIDifficulty); err != nil {
+		return err
+	}
+	if err := cfg.Rules.Validate(); err != nil {
+		return err
+	}
+
+	for _, deck := range [][]string{cfg.PlayerDeck, cfg.AIDeck} {
+		if len(deck) == 0 {
+			continue
+		}
+		if _, err := buildDeck(deck); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.MaxTurns <= 0 {
+		cfg.MaxTurns = DefaultMaxTurns
+	}
+	return nil
+}
+
+// playBattle plays one battle from seed to the end
+func playBattle(cfg Config, seed int64) result {
+	res := result{
+		species:  map[string][]string{},
+		actions:  map[string]int{},
+		moveUses: map[string]int{},
+	}
+
+	decks := rng.New(seed)
+	playerDeck, err := deckFor(cfg.PlayerDeck, cfg.Mode, decks)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	aiDeck, err := deckFor(cfg.AIDeck, cfg.Mode, decks)
+	if err != nil {
+		res.err = err
+		return res
+	}
+
+	bs, err := battle.StartBattleWithSeed(0, cfg.Mode, playerDeck, aiDeck, seed)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	if err := bs.SetDifficulty(cfg.AIDifficulty); err != nil {
+		res.err = err
+		return res
+	}
+	if err := bs.SetRules(cfg.Rules); err != nil {
+		res.err = err
+		return res
+	}
+	// The simulation never looks back at the battle, so don't record it
+	bs.Replay = nil
+
+	for _, card := range bs.PlayerDeck {
+		res.species["player"] = append(res.species["player"], card.Name)
+	}
+	for _, card := range bs.AIDeck {
+		res.species["ai"] = append(res.species["ai"], card.Name)
+	}
+
+	player := battle.DifficultyStrategy(cfg.PlayerDifficulty)
+	for !bs.BattleOver && bs.TurnNumber <= cfg.MaxTurns {
+		events, err := battle.PlayTurn(bs, player)
+		if err != nil {
+			res.err = err
+			return res
+		}
+		for _, e := range events {
+			switch e.Type {
+			case battle.EventMoveChosen:
+				res.actions[e.Move]++
+				if e.Move == "attack" {
+					res.moveUses[e.MoveName]++
+				}
+			case battle.EventSacrificed:
+				res.actions["sacrifice"]++
+			case battle.EventSwitched:
+				if e.Round > 0 {
+					// Switches into a knocked out Pokemon's place have no round
+					res.actions["switch"]++
+				}
+			case battle.EventSurrendered:
+				res.actions["surrender"]++
+			}
+		}
+	}
+
+	res.turns = min(bs.TurnNumber, cfg.MaxTurns)
+	if bs.BattleOver {
+		res.winner = bs.Winner
+	}
+	return res
+}
+
+// deckFor builds the named deck, or draws a random one for mode
+func deckFor(names []string, mode string, r rng.Rand) ([]pokemon.Card, error) {
+	if len(names) > 0 {
+		return buildDeck(names)
+	}
+
+	count := 1
+	if mode == "5v5" {
+		count = 5
+	}
+	deck := make([]pokemon.Card, count)
+	for i := range deck {
+		deck[i] = pokemon.FetchRandomPokemonCardOfflineWithRand(r)
+	}
+	return deck, nil
+}
+
+// buildDeck builds level 1 cards for the named species
+func buildDeck(names []string) ([]pokemon.Card, error) {
+	deck := make([]pokemon.Card, len(names))
+	for i, name := range names {
+		entry, err := pokemon.GetPokemonByName(name)
+		if err != nil {
+			return nil, err
+		}
+		deck[i] = pokemon.CardFromEntry(entry)
+	}
+	return deck, nil
+}
+
+// WriteCSV writes the report as three CSV tables separated by blank lines: the
+// summary as metric/value pairs, then the species and the moves
+func WriteCSV(w io.Writer, report *Report) error {
+	cw := csv.NewWriter(w)
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+
+	rows := [][]string{
+		{"metric", "value"},
+		{"battles", strconv.Itoa(report.Battles)},
+		{"mode", report.Mode},
+		{"seed", strconv.FormatInt(report.Seed, 10)},
+		{"player_difficulty", report.PlayerDifficulty},
+		{"ai_difficulty", report.AIDifficulty},
+		{"speed_order", strconv.FormatBool(report.Rules.SpeedOrder)},
+		{"damage", report.Rules.Damage},
+		{"field", report.Rules.Field},
+		{"player_wins", strconv.Itoa(report.PlayerWins)},
+		{"ai_wins", strconv.Itoa(report.AIWins)},
+		{"draws", strconv.Itoa(report.Draws)},
+		{"unfinished", strconv.Itoa(report.Unfinished)},
+		{"player_win_rate", f(report.PlayerWinRate)},
+		{"ai_win_rate", f(report.AIWinRate)},
+		{"draw_rate", f(report.DrawRate)},
+		{"average_turns", f(report.AverageTurns)},
+	}
+	actions := make([]string, 0, len(report.Actions))
+	for action := range report.Actions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		rows = append(rows, []string{"action_" + action, strconv.Itoa(report.Actions[action])})
+	}
+
+	rows = append(rows, []string{}, []string{"species", "battles", "wins", "win_rate"})
+	for _, s := range report.Species {
+		rows = append(rows, []string{s.Name, strconv.Itoa(s.Battles), strconv.Itoa(s.Wins), f(s.WinRate)})
+	}
+
+	rows = append(rows, []string{}, []string{"move", "uses", "share"})
+	for _, m := range report.Moves {
+		rows = append(rows, []string{m.Name, strconv.Itoa(m.Uses), f(m.Share)})
+	}
+
+	return cw.WriteAll(rows)
+}
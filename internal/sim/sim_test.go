@@ -0,0 +1,102 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/csv"
+	"pokemon-cli/internal/battle"
+	"reflect"
+	"testing"
+)
+
+func TestRunIsReproducible(t *testing.T) {
+	cfg := Config{Battles: 40, Mode: "5v5", Seed: 77, Workers: 1}
+	first, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	cfg.Workers = 6
+	second, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same seed gave different reports with different worker counts")
+	}
+
+	if got := first.PlayerWins + first.AIWins + first.Draws + first.Unfinished; got != cfg.Battles {
+		t.Errorf("outcomes add up to %d battles, want %d", got, cfg.Battles)
+	}
+	if first.AverageTurns <= 0 {
+		t.Error("average turns not computed")
+	}
+}
+
+func TestRunWithGivenDecks(t *testing.T) {
+	report, err := Run(Config{
+		Battles:    20,
+		Mode:       "1v1",
+		Seed:       3,
+		PlayerDeck: []string{"pikachu"},
+		AIDeck:     []string{"Bulbasaur"},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(report.Species) != 2 {
+		t.Fatalf("got %d species, want 2", len(report.Species))
+	}
+	for _, s := range report.Species {
+		if s.Battles != 20 {
+			t.Errorf("%s was in %d battles, want 20", s.Name, s.Battles)
+		}
+	}
+	if len(report.Moves) == 0 {
+		t.Error("no move usage recorded")
+	}
+}
+
+func TestRunRejectsBadConfig(t *testing.T) {
+	tests := []Config{
+		{Battles: 0, Mode: "5v5"},
+		{Battles: 1, Mode: "3v3"},
+		{Battles: 1, Mode: "1v1", AIDifficulty: "impossible"},
+		{Battles: 1, Mode: "1v1", PlayerDeck: []string{"missingno"}},
+		{Battles: 1, Mode: "1v1", Rules: battle.Rules{Damage: "realistic"}},
+	}
+
+	for _, cfg := range tests {
+		if _, err := Run(cfg); err == nil {
+			t.Errorf("Run(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	report, err := Run(Config{Battles: 5, Mode: "1v1", Seed: 1})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, report); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	r := csv.NewReader(&buf)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	headers := map[string]bool{}
+	for _, row := range rows {
+		headers[row[0]] = true
+	}
+	for _, header := range []string{"metric", "species", "move"} {
+		if !headers[header] {
+			t.Errorf("missing %s table", header)
+		}
+	}
+}
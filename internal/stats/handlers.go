@@ -1,6 +1,8 @@
 package stats
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -85,6 +87,54 @@ func (h *Handler) GetBattleHistory(c *fiber.Ctx) error {
 	})
 }
 
+// GetBattleTimeline handles GET /api/stats/history/:id
+func (h *Handler) GetBattleTimeline(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	historyID, err := c.ParamsInt("id")
+	if err != nil || historyID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_ID",
+				"message": "Battle ID must be a positive integer",
+			},
+		})
+	}
+
+	battle, turns, err := h.service.GetBattleTimeline(c.Context(), userID, historyID)
+	if err != nil {
+		if errors.Is(err, ErrBattleNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "BATTLE_NOT_FOUND",
+					"message": "Battle not found",
+				},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to retrieve battle timeline",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"battle": battle,
+		"turns":  turns,
+		"count":  len(turns),
+	})
+}
+
 // GetAchievements handles GET /api/profile/achievements
 // Requirements: 8.4
 func (h *Handler) GetAchievements(c *fiber.Ctx) error {
@@ -174,3 +224,103 @@ func (h *Handler) CheckAchievements(c *fiber.Ctx) error {
 		"count":          len(newlyUnlocked),
 	})
 }
+
+// ladderQuery reads the mode and ladder query parameters of the leaderboard
+// endpoints, defaulting to the 5v5 PvP ladder
+func ladderQuery(c *fiber.Ctx) (mode, ladder string, ok bool) {
+	mode = c.Query("mode", "5v5")
+	ladder = c.Query("ladder", "pvp")
+	if mode != "1v1" && mode != "5v5" {
+		return "", "", false
+	}
+	if ladder != "pvp" && ladder != "pve" {
+		return "", "", false
+	}
+	return mode, ladder, true
+}
+
+// GetLeaderboard handles GET /api/leaderboard?mode=5v5&ladder=pvp&page=1
+func (h *Handler) GetLeaderboard(c *fiber.Ctx) error {
+	mode, ladder, ok := ladderQuery(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_LADDER",
+				"message": "Mode must be '1v1' or '5v5' and ladder must be 'pvp' or 'pve'",
+			},
+		})
+	}
+
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	entries, total, err := h.service.GetLeaderboard(c.Context(), mode, ladder, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to retrieve leaderboard",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"mode":      mode,
+		"ladder":    ladder,
+		"page":      page,
+		"page_size": LeaderboardPageSize,
+		"total":     total,
+		"entries":   entries,
+	})
+}
+
+// GetMyRank handles GET /api/leaderboard/me?mode=5v5&ladder=pvp
+func (h *Handler) GetMyRank(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNAUTHORIZED",
+				"message": "User not authenticated",
+			},
+		})
+	}
+
+	mode, ladder, ok := ladderQuery(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_LADDER",
+				"message": "Mode must be '1v1' or '5v5' and ladder must be 'pvp' or 'pve'",
+			},
+		})
+	}
+
+	entry, err := h.service.GetRank(c.Context(), userID, mode, ladder)
+	if err != nil {
+		if errors.Is(err, ErrNotRanked) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_RANKED",
+					"message": "Play a rated battle on this ladder to get a rank",
+				},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to retrieve rank",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"mode":   mode,
+		"ladder": ladder,
+		"entry":  entry,
+	})
+}
@@ -10,6 +10,12 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// ErrBattleNotFound is returned when a battle does not exist or belongs to another user
+var ErrBattleNotFound = errors.New("battle not found")
+
+// ErrNotRanked is returned when a user hasn't played a rated battle on a ladder
+var ErrNotRanked = errors.New("not ranked")
+
 // Repository handles database operations for statistics
 type Repository struct {
 	db *pgxpool.Pool
@@ -148,6 +154,11 @@ func (r *Repository) GetPlayerStats(ctx context.Context, userID int) (*database.
 			COALESCE(total_coins_earned, 0),
 			COALESCE(highest_level, 1),
 			COALESCE(consecutive_losses, 0),
+			COALESCE(season_wins, 0),
+			COALESCE(season_losses, 0),
+			COALESCE(season_draws, 0),
+			COALESCE(season_win_streak, 0),
+			COALESCE(season_best_win_streak, 0),
 			updated_at
 		FROM player_stats
 		WHERE user_id = $1
@@ -163,6 +174,11 @@ func (r *Repository) GetPlayerStats(ctx context.Context, userID int) (*database.
 		&stats.TotalCoinsEarned,
 		&stats.HighestLevel,
 		&stats.ConsecutiveLosses,
+		&stats.SeasonWins,
+		&stats.SeasonLosses,
+		&stats.SeasonDraws,
+		&stats.SeasonWinStreak,
+		&stats.SeasonBestStreak,
 		&stats.UpdatedAt,
 	)
 
@@ -192,7 +208,7 @@ func (r *Repository) GetPlayerStats(ctx context.Context, userID int) (*database.
 // GetBattleHistory retrieves the last N battles for a user
 func (r *Repository) GetBattleHistory(ctx context.Context, userID int, limit int) ([]database.BattleHistory, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT id, user_id, mode, result, coins_earned, duration, created_at
+		SELECT id, user_id, mode, result, coins_earned, duration, ai_difficulty, created_at
 		FROM battle_history
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -213,6 +229,7 @@ func (r *Repository) GetBattleHistory(ctx context.Context, userID int, limit int
 			&battle.Result,
 			&battle.CoinsEarned,
 			&battle.Duration,
+			&battle.AIDifficulty,
 			&battle.CreatedAt,
 		)
 		if err != nil {
@@ -228,6 +245,144 @@ func (r *Repository) GetBattleHistory(ctx context.Context, userID int, limit int
 	return history, nil
 }
 
+// GetLeaderboard returns a page of the players on a ladder in mode, highest
+// rated first, along with how many players the ladder has
+func (r *Repository) GetLeaderboard(ctx context.Context, mode, ladder string, limit, offset int) ([]database.LeaderboardEntry, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM player_ratings WHERE mode = $1 AND ladder = $2
+	`, mode, ladder).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count ranked players: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT RANK() OVER (ORDER BY pr.rating DESC), pr.user_id, u.username, pr.rating, pr.deviation, pr.games
+		FROM player_ratings pr
+		JOIN users u ON u.id = pr.user_id
+		WHERE pr.mode = $1 AND pr.ladder = $2
+		ORDER BY pr.rating DESC, pr.user_id ASC
+		LIMIT $3 OFFSET $4
+	`, mode, ladder, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []database.LeaderboardEntry{}
+	for rows.Next() {
+		var entry database.LeaderboardEntry
+		err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Username, &entry.Rating, &entry.Deviation, &entry.Games)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating leaderboard: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// GetRank returns the user's place on a ladder in mode
+func (r *Repository) GetRank(ctx context.Context, userID int, mode, ladder string) (*database.LeaderboardEntry, error) {
+	var entry database.LeaderboardEntry
+	err := r.db.QueryRow(ctx, `
+		SELECT ranked.rank, ranked.user_id, u.username, ranked.rating, ranked.deviation, ranked.games
+		FROM (
+			SELECT user_id, rating, deviation, games, RANK() OVER (ORDER BY rating DESC) AS rank
+			FROM player_ratings
+			WHERE mode = $1 AND ladder = $2
+		) ranked
+		JOIN users u ON u.id = ranked.user_id
+		WHERE ranked.user_id = $3
+	`, mode, ladder, userID).Scan(&entry.Rank, &entry.UserID, &entry.Username, &entry.Rating, &entry.Deviation, &entry.Games)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotRanked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank: %w", err)
+	}
+	return &entry, nil
+}
+
+// GetBattleTimeline retrieves a battle owned by userID together with its turns in order
+func (r *Repository) GetBattleTimeline(ctx context.Context, userID, historyID int) (*database.BattleHistory, []database.BattleTurn, error) {
+	battle := &database.BattleHistory{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, mode, result, coins_earned, duration, ai_difficulty, created_at
+		FROM battle_history
+		WHERE id = $1 AND user_id = $2
+	`, historyID, userID).Scan(
+		&battle.ID,
+		&battle.UserID,
+		&battle.Mode,
+		&battle.Result,
+		&battle.CoinsEarned,
+		&battle.Duration,
+		&battle.AIDifficulty,
+		&battle.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrBattleNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get battle: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, battle_history_id, seq, turn, round, player_pokemon, ai_pokemon,
+			player_move, COALESCE(player_move_name, ''), COALESCE(ai_move, ''), COALESCE(ai_move_name, ''),
+			damage_dealt, damage_taken, player_hp_delta, player_stamina_delta,
+			ai_hp_delta, ai_stamina_delta, player_knocked_out, ai_knocked_out
+		FROM battle_turns
+		WHERE battle_history_id = $1
+		ORDER BY seq
+	`, historyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query battle turns: %w", err)
+	}
+	defer rows.Close()
+
+	turns := []database.BattleTurn{}
+	for rows.Next() {
+		var turn database.BattleTurn
+		err := rows.Scan(
+			&turn.ID,
+			&turn.BattleHistoryID,
+			&turn.Seq,
+			&turn.Turn,
+			&turn.Round,
+			&turn.PlayerPokemon,
+			&turn.AIPokemon,
+			&turn.PlayerMove,
+			&turn.PlayerMoveName,
+			&turn.AIMove,
+			&turn.AIMoveName,
+			&turn.DamageDealt,
+			&turn.DamageTaken,
+			&turn.PlayerHPDelta,
+			&turn.PlayerStaminaDelta,
+			&turn.AIHPDelta,
+			&turn.AIStaminaDelta,
+			&turn.PlayerKnockedOut,
+			&turn.AIKnockedOut,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan battle turn: %w", err)
+		}
+		turns = append(turns, turn)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating battle turns: %w", err)
+	}
+
+	return battle, turns, nil
+}
+
 // UpdateHighestLevel updates the highest level achieved by a player
 func (r *Repository) UpdateHighestLevel(ctx context.Context, userID int, level int) error {
 	_, err := r.db.Exec(ctx, `
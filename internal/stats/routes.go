@@ -16,4 +16,18 @@ func RegisterRoutes(app *fiber.App, handler *Handler, authMiddleware fiber.Handl
 	profile.Get("/achievements", handler.GetAchievements)
 
 	profile.Post("/achievements/check", handler.CheckAchievements)
+
+	stats := app.Group("/api/stats")
+
+	stats.Use(authMiddleware)
+
+	stats.Get("/history/:id", handler.GetBattleTimeline)
+
+	leaderboard := app.Group("/api/leaderboard")
+
+	leaderboard.Use(authMiddleware)
+
+	leaderboard.Get("/", handler.GetLeaderboard)
+
+	leaderboard.Get("/me", handler.GetMyRank)
 }
@@ -30,7 +30,28 @@ func (s *Service) GetBattleHistory(ctx context.Context, userID int, limit int) (
 	return s.repo.GetBattleHistory(ctx, userID, limit)
 }
 
+// GetBattleTimeline returns one of the user's battles with its turn-by-turn timeline
+func (s *Service) GetBattleTimeline(ctx context.Context, userID, historyID int) (*database.BattleHistory, []database.BattleTurn, error) {
+	return s.repo.GetBattleTimeline(ctx, userID, historyID)
+}
+
 // UpdateHighestLevel updates the highest level achieved by a player
+// LeaderboardPageSize is how many players a page of the leaderboard lists
+const LeaderboardPageSize = 20
+
+// GetLeaderboard returns a page of a ladder in mode, counting pages from 1, and how many players the ladder has
+func (s *Service) GetLeaderboard(ctx context.Context, mode, ladder string, page int) ([]database.LeaderboardEntry, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	return s.repo.GetLeaderboard(ctx, mode, ladder, LeaderboardPageSize, (page-1)*LeaderboardPageSize)
+}
+
+// GetRank returns the user's place on a ladder in mode
+func (s *Service) GetRank(ctx context.Context, userID int, mode, ladder string) (*database.LeaderboardEntry, error) {
+	return s.repo.GetRank(ctx, userID, mode, ladder)
+}
+
 func (s *Service) UpdateHighestLevel(ctx context.Context, userID int, level int) error {
 	return s.repo.UpdateHighestLevel(ctx, userID, level)
 }
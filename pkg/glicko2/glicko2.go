@@ -0,0 +1,127 @@
+// Package glicko2 implements Mark Glickman's Glicko-2 rating system, which
+// tracks how certain it is of each rating alongside the rating itself.
+// See http://www.glicko.net/glicko/glicko2.pdf.
+package glicko2
+
+import (
+	"math"
+)
+
+const (
+	// Tau constrains how much volatility can change between rating periods
+	Tau = 0.5
+
+	scale   = 173.7178 // Converts between the Glicko and Glicko-2 scales
+	epsilon = 0.000001 // Convergence tolerance of the volatility iteration
+)
+
+// Rating is a player's rating on the Glicko scale
+type Rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`  // Uncertainty of the rating; ~95% of the time the true rating is within twice this
+	Volatility float64 `json:"volatility"` // How erratic the player's results are
+}
+
+// Default is the rating of a player who hasn't played yet
+func Default() Rating {
+	return Rating{Rating: 1500, Deviation: 350, Volatility: 0.06}
+}
+
+// Result is the outcome of one game against an opponent
+type Result struct {
+	Opponent Rating
+	Score    float64 // 1 for a win, 0.5 for a draw, 0 for a loss
+}
+
+// Update returns the player's rating after a rating period with results.
+// A period without results only grows the deviation.
+func Update(player Rating, results []Result) Rating {
+	mu := (player.Rating - 1500) / scale
+	phi := player.Deviation / scale
+	sigma := player.Volatility
+
+	if len(results) == 0 {
+		return Rating{
+			Rating:     player.Rating,
+			Deviation:  math.Sqrt(phi*phi+sigma*sigma) * scale,
+			Volatility: sigma,
+		}
+	}
+
+	// Estimated variance of the rating from game outcomes, and the improvement they show
+	var invV, sum float64
+	for _, result := range results {
+		muJ := (result.Opponent.Rating - 1500) / scale
+		gJ := g(result.Opponent.Deviation / scale)
+		e := 1 / (1 + math.Exp(-gJ*(mu-muJ)))
+		invV += gJ * gJ * e * (1 - e)
+		sum += gJ * (result.Score - e)
+	}
+	v := 1 / invV
+	delta := v * sum
+
+	sigma = volatility(phi, sigma, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*sum
+
+	return Rating{
+		Rating:     newMu*scale + 1500,
+		Deviation:  newPhi * scale,
+		Volatility: sigma,
+	}
+}
+
+// SoftReset returns the rating a player starts a new season with: keep (0 to 1)
+// of its distance from the default rating, and at least deviation of
+// uncertainty, so the season's first games move it quickly.
+func SoftReset(player Rating, keep, deviation float64) Rating {
+	def := Default()
+	return Rating{
+		Rating:     def.Rating + (player.Rating-def.Rating)*keep,
+		Deviation:  min(max(player.Deviation, deviation), def.Deviation),
+		Volatility: player.Volatility,
+	}
+}
+
+// g reduces the impact of a game by the opponent's deviation
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// volatility finds the new volatility with the Illinois algorithm (step 5 of the paper)
+func volatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(Tau*Tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*Tau) < 0 {
+			k++
+		}
+		B = a - k*Tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
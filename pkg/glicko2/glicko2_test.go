@@ -0,0 +1,62 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+)
+
+// TestUpdateMatchesPaperExample checks the worked example from Glickman's paper
+func TestUpdateMatchesPaperExample(t *testing.T) {
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	results := []Result{
+		{Opponent: Rating{Rating: 1400, Deviation: 30, Volatility: 0.06}, Score: 1},
+		{Opponent: Rating{Rating: 1550, Deviation: 100, Volatility: 0.06}, Score: 0},
+		{Opponent: Rating{Rating: 1700, Deviation: 300, Volatility: 0.06}, Score: 0},
+	}
+
+	got := Update(player, results)
+
+	if math.Abs(got.Rating-1464.06) > 0.01 {
+		t.Errorf("Rating = %.2f, want 1464.06", got.Rating)
+	}
+	if math.Abs(got.Deviation-151.52) > 0.01 {
+		t.Errorf("Deviation = %.2f, want 151.52", got.Deviation)
+	}
+	if math.Abs(got.Volatility-0.05999) > 0.00001 {
+		t.Errorf("Volatility = %.5f, want 0.05999", got.Volatility)
+	}
+}
+
+func TestUpdateWithoutGamesOnlyGrowsDeviation(t *testing.T) {
+	player := Rating{Rating: 1700, Deviation: 50, Volatility: 0.06}
+
+	got := Update(player, nil)
+
+	if got.Rating != player.Rating || got.Volatility != player.Volatility {
+		t.Errorf("rating or volatility changed: %+v", got)
+	}
+	if got.Deviation <= player.Deviation {
+		t.Errorf("Deviation = %.2f, want more than %.2f", got.Deviation, player.Deviation)
+	}
+}
+
+func TestSoftReset(t *testing.T) {
+	tests := []struct {
+		player, want Rating
+	}{
+		{Rating{Rating: 1900, Deviation: 50, Volatility: 0.05}, Rating{Rating: 1700, Deviation: 150, Volatility: 0.05}},
+		{Rating{Rating: 1300, Deviation: 80, Volatility: 0.06}, Rating{Rating: 1400, Deviation: 150, Volatility: 0.06}},
+		{Rating{Rating: 1600, Deviation: 300, Volatility: 0.06}, Rating{Rating: 1550, Deviation: 300, Volatility: 0.06}},
+		{Default(), Default()},
+	}
+	for _, tt := range tests {
+		if got := SoftReset(tt.player, 0.5, 150); got != tt.want {
+			t.Errorf("SoftReset(%+v) = %+v, want %+v", tt.player, got, tt.want)
+		}
+	}
+
+	// Deviation never grows past a new player's
+	if got := SoftReset(Default(), 0.5, 500); got.Deviation != Default().Deviation {
+		t.Errorf("Deviation = %.2f, want at most %.2f", got.Deviation, Default().Deviation)
+	}
+}
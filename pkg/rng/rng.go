@@ -0,0 +1,64 @@
+// Package rng provides seedable, serializable random sources so that battles
+// can be reproduced from a seed.
+package rng
+
+import (
+	"math/rand"
+)
+
+// Rand is the subset of *rand.Rand used by game logic. Both *rand.Rand and
+// Global satisfy it.
+type Rand interface {
+	Float64() float64
+	Intn(n int) int
+}
+
+// Global draws from the shared math/rand source. It is safe for concurrent use
+// and is meant for code paths that do not need to be reproducible.
+var Global Rand = globalRand{}
+
+type globalRand struct{}
+
+func (globalRand) Float64() float64 { return rand.Float64() }
+func (globalRand) Intn(n int) int   { return rand.Intn(n) }
+
+// Source is a splitmix64 generator. Unlike the math/rand sources its entire
+// state is one exported field, so it survives a JSON round trip and a battle
+// stored in the database resumes exactly where it left off.
+type Source struct {
+	State uint64 `json:"state"`
+}
+
+// NewSource returns a Source seeded with seed
+func NewSource(seed int64) *Source {
+	return &Source{State: uint64(seed)}
+}
+
+// Seed resets the source to seed
+func (s *Source) Seed(seed int64) {
+	s.State = uint64(seed)
+}
+
+// Uint64 returns the next pseudo-random value
+func (s *Source) Uint64() uint64 {
+	s.State += 0x9e3779b97f4a7c15
+	z := s.State
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// New returns a *rand.Rand backed by a fresh Source seeded with seed
+func New(seed int64) *rand.Rand {
+	return rand.New(NewSource(seed))
+}
+
+// NewSeed returns a random seed for a new battle
+func NewSeed() int64 {
+	return rand.Int63()
+}
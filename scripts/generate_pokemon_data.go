@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -20,9 +22,12 @@ type PokemonEntry struct {
 	HP          int      `json:"hp"`
 	Attack      int      `json:"attack"`
 	Defense     int      `json:"defense"`
+	SpAttack    int      `json:"sp_attack"`
+	SpDefense   int      `json:"sp_defense"`
 	Speed       int      `json:"speed"`
 	Types       []string `json:"types"`
 	Moves       []Move   `json:"moves"`
+	Ability     string   `json:"ability"`
 	Sprite      string   `json:"sprite"`
 	IsLegendary bool     `json:"is_legendary"`
 	IsMythical  bool     `json:"is_mythical"`
@@ -30,10 +35,111 @@ type PokemonEntry struct {
 
 // Move represents a Pokemon move
 type Move struct {
-	Name        string `json:"name"`
-	Power       int    `json:"power"`
-	StaminaCost int    `json:"stamina_cost"`
-	Type        string `json:"attack_type"`
+	Name         string `json:"name"`
+	Power        int    `json:"power"`
+	StaminaCost  int    `json:"stamina_cost"`
+	Type         string `json:"attack_type"`
+	Effect       string `json:"effect,omitempty"`        // Status condition the move may inflict on a hit
+	EffectChance int    `json:"effect_chance,omitempty"` // Percent chance of inflicting Effect
+	Accuracy     int    `json:"accuracy,omitempty"`      // Percent chance of hitting; 0 never misses
+	CritRate     int    `json:"crit_rate,omitempty"`     // Critical hit stage: 0 is normal, 1 high, 3 or more always
+	Category     string `json:"category,omitempty"`      // "physical" or "special"
+
+	// Support moves have no power and do these instead of dealing damage
+	Healing     int          `json:"healing,omitempty"`      // Percent of the user's max HP restored
+	StaminaGain int          `json:"stamina_gain,omitempty"` // Percent of the user's max stamina restored
+	StatChanges []StatChange `json:"stat_changes,omitempty"` // Stat stages raised or lowered
+	Field       string       `json:"field,omitempty"`        // Weather or terrain the move sets
+}
+
+// StatChange is a support move raising or lowering a stat of its user or of
+// the opposing Pokemon
+type StatChange struct {
+	Stat   string `json:"stat"`
+	Stages int    `json:"stages"`
+	Target string `json:"target"` // "user" or "opponent"
+}
+
+// statusConditions are the move ailments the game models as status conditions
+var statusConditions = []string{"burn", "poison", "paralysis", "sleep", "freeze"}
+
+// stageStats are the stats support moves can raise or lower
+var stageStats = []string{"attack", "defense", "special-attack", "special-defense", "speed"}
+
+// userTargets are PokeAPI's move targets whose stat changes apply to the user
+var userTargets = []string{"user", "user-or-ally", "user-and-allies", "users-field"}
+
+// staminaMoves are the support moves that restore stamina, with the percent of
+// max stamina they restore
+var staminaMoves = map[string]int{
+	"focus-energy": 50,
+	"laser-focus":  50,
+	"charge":       50,
+	"stockpile":    40,
+}
+
+// fieldMoves are the support moves that set a weather or terrain, with the
+// weather or terrain they set
+var fieldMoves = map[string]string{
+	"sunny-day":        "sun",
+	"rain-dance":       "rain",
+	"sandstorm":        "sandstorm",
+	"hail":             "hail",
+	"snowscape":        "hail",
+	"electric-terrain": "electric-terrain",
+	"grassy-terrain":   "grassy-terrain",
+	"psychic-terrain":  "psychic-terrain",
+	"misty-terrain":    "misty-terrain",
+}
+
+// supportMoveStaminaCost is the stamina cost of every support move
+const supportMoveStaminaCost = 20
+
+// modelledAbilities are the PokeAPI abilities the game models
+var modelledAbilities = []string{
+	"levitate", "flash-fire", "water-absorb", "storm-drain", "dry-skin", "volt-absorb", "lightning-rod", "motor-drive", "sap-sipper",
+	"overgrow", "blaze", "torrent", "swarm",
+	"battle-armor", "shell-armor", "sturdy", "solid-rock",
+	"rough-skin", "iron-barbs",
+}
+
+// defaultAbilities are the abilities of species none of whose abilities the
+// game models, by their first type
+var defaultAbilities = map[string]string{
+	"normal":   "battle-armor",
+	"fire":     "blaze",
+	"water":    "torrent",
+	"grass":    "overgrow",
+	"electric": "volt-absorb",
+	"ice":      "shell-armor",
+	"fighting": "battle-armor",
+	"poison":   "rough-skin",
+	"ground":   "sturdy",
+	"flying":   "battle-armor",
+	"psychic":  "levitate",
+	"bug":      "swarm",
+	"rock":     "sturdy",
+	"ghost":    "levitate",
+	"dragon":   "rough-skin",
+	"dark":     "rough-skin",
+	"steel":    "sturdy",
+	"fairy":    "shell-armor",
+}
+
+// chooseAbility picks a species' ability: the first of its abilities, in slot
+// order, that the game models, or the default of its first type
+func chooseAbility(abilities []string, types []string) string {
+	for _, ability := range abilities {
+		if slices.Contains(modelledAbilities, ability) {
+			return ability
+		}
+	}
+	if len(types) > 0 {
+		if ability, ok := defaultAbilities[types[0]]; ok {
+			return ability
+		}
+	}
+	return "battle-armor"
 }
 
 // PokemonDatabase holds all Pokemon data
@@ -132,6 +238,11 @@ func fetchPokemonData(id int, client *http.Client) (*PokemonEntry, error) {
 		Sprites struct {
 			FrontDefault string `json:"front_default"`
 		} `json:"sprites"`
+		Abilities []struct {
+			Ability struct {
+				Name string `json:"name"`
+			} `json:"ability"`
+		} `json:"abilities"`
 	}
 	
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
@@ -139,7 +250,7 @@ func fetchPokemonData(id int, client *http.Client) (*PokemonEntry, error) {
 	}
 	
 	// Extract stats
-	var hp, attack, defense, speed int
+	var hp, attack, defense, spAttack, spDefense, speed int
 	for _, stat := range data.Stats {
 		switch stat.Stat.Name {
 		case "hp":
@@ -148,6 +259,10 @@ func fetchPokemonData(id int, client *http.Client) (*PokemonEntry, error) {
 			attack = stat.BaseStat
 		case "defense":
 			defense = stat.BaseStat
+		case "special-attack":
+			spAttack = stat.BaseStat
+		case "special-defense":
+			spDefense = stat.BaseStat
 		case "speed":
 			speed = stat.BaseStat
 		}
@@ -162,6 +277,13 @@ func fetchPokemonData(id int, client *http.Client) (*PokemonEntry, error) {
 		types[i] = t.Type.Name
 	}
 	
+	// PokeAPI lists abilities in slot order, the hidden one last
+	abilityNames := make([]string, len(data.Abilities))
+	for i, a := range data.Abilities {
+		abilityNames[i] = a.Ability.Name
+	}
+	ability := chooseAbility(abilityNames, types)
+
 	// Fetch moves (limit to 4)
 	moves := fetchMoves(data.Moves, client)
 	
@@ -174,9 +296,12 @@ func fetchPokemonData(id int, client *http.Client) (*PokemonEntry, error) {
 		HP:          hp,
 		Attack:      attack,
 		Defense:     defense,
+		SpAttack:    spAttack,
+		SpDefense:   spDefense,
 		Speed:       speed,
 		Types:       types,
 		Moves:       moves,
+		Ability:     ability,
 		Sprite:      data.Sprites.FrontDefault,
 		IsLegendary: isLegendary,
 		IsMythical:  isMythical,
@@ -190,6 +315,7 @@ func fetchMoves(rawMoves []struct {
 	} `json:"move"`
 }, client *http.Client) []Move {
 	const maxMoves = 4
+	const maxSupportMoves = 1
 	
 	if len(rawMoves) == 0 {
 		// Fallback move
@@ -201,6 +327,7 @@ func fetchMoves(rawMoves []struct {
 	// Shuffle moves to get random selection
 	perm := rand.Perm(len(rawMoves))
 	var moves []Move
+	supportMoves := 0
 	
 	for _, i := range perm {
 		if len(moves) >= maxMoves {
@@ -223,11 +350,32 @@ func fetchMoves(rawMoves []struct {
 		}
 		
 		var moveData struct {
-			Name  string `json:"name"`
-			Power int    `json:"power"`
-			Type  struct {
+			Name     string `json:"name"`
+			Power    int    `json:"power"`
+			Accuracy int    `json:"accuracy"` // null for moves that never miss
+			Type     struct {
 				Name string `json:"name"`
 			} `json:"type"`
+			Meta struct {
+				Ailment struct {
+					Name string `json:"name"`
+				} `json:"ailment"`
+				AilmentChance int `json:"ailment_chance"`
+				CritRate      int `json:"crit_rate"`
+				Healing       int `json:"healing"`
+			} `json:"meta"`
+			StatChanges []struct {
+				Change int `json:"change"`
+				Stat   struct {
+					Name string `json:"name"`
+				} `json:"stat"`
+			} `json:"stat_changes"`
+			Target struct {
+				Name string `json:"name"`
+			} `json:"target"`
+			DamageClass struct {
+				Name string `json:"name"`
+			} `json:"damage_class"`
 		}
 		
 		if err := json.NewDecoder(resp.Body).Decode(&moveData); err != nil {
@@ -236,17 +384,47 @@ func fetchMoves(rawMoves []struct {
 		}
 		resp.Body.Close()
 		
-		// Only include moves with power
-		if moveData.Power <= 0 {
-			continue
-		}
-		
-		moves = append(moves, Move{
+		move := Move{
 			Name:        moveData.Name,
 			Power:       moveData.Power,
 			StaminaCost: moveData.Power / 3,
 			Type:        moveData.Type.Name,
-		})
+			Accuracy:    moveData.Accuracy,
+			CritRate:    moveData.Meta.CritRate,
+		}
+		// Physical and special moves use different stats; status moves have no category
+		if moveData.DamageClass.Name == "physical" || moveData.DamageClass.Name == "special" {
+			move.Category = moveData.DamageClass.Name
+		}
+		// Tag moves that can inflict a status condition as a secondary effect
+		if slices.Contains(statusConditions, moveData.Meta.Ailment.Name) && moveData.Meta.AilmentChance > 0 {
+			move.Effect = moveData.Meta.Ailment.Name
+			move.EffectChance = moveData.Meta.AilmentChance
+		}
+		
+		// Moves without power are only included as support moves that heal,
+		// restore stamina, change stats or set the weather or terrain, at most
+		// one per Pokemon
+		if moveData.Power <= 0 {
+			move.StaminaCost = supportMoveStaminaCost
+			move.Healing = max(moveData.Meta.Healing, 0)
+			move.StaminaGain = staminaMoves[moveData.Name]
+			move.Field = fieldMoves[moveData.Name]
+			target := "opponent"
+			if slices.Contains(userTargets, moveData.Target.Name) {
+				target = "user"
+			}
+			for _, change := range moveData.StatChanges {
+				if slices.Contains(stageStats, change.Stat.Name) {
+					move.StatChanges = append(move.StatChanges, StatChange{Stat: change.Stat.Name, Stages: change.Change, Target: target})
+				}
+			}
+			if (move.Healing == 0 && move.StaminaGain == 0 && len(move.StatChanges) == 0 && move.Field == "") || supportMoves == maxSupportMoves {
+				continue
+			}
+			supportMoves++
+		}
+		moves = append(moves, move)
 	}
 	
 	// Ensure at least one move
@@ -259,7 +437,99 @@ func fetchMoves(rawMoves []struct {
 	return moves
 }
 
+// readCSV reads a CSV file of PokeAPI's data into rows keyed by the header's columns
+func readCSV(path string) ([]map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(record))
+		for i, column := range records[0] {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// importAbilities sets the ability of every Pokemon in the data file at path
+// from a local dump of PokeAPI's CSV data (data/v2/csv in the pokeapi
+// repository), without fetching anything
+func importAbilities(path, dumpDir string) error {
+	abilityRows, err := readCSV(filepath.Join(dumpDir, "abilities.csv"))
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(abilityRows))
+	for _, row := range abilityRows {
+		names[row["id"]] = row["identifier"]
+	}
+
+	pokemonRows, err := readCSV(filepath.Join(dumpDir, "pokemon_abilities.csv"))
+	if err != nil {
+		return err
+	}
+	// Hidden abilities have the last slot, so sorting by slot puts them last
+	slices.SortStableFunc(pokemonRows, func(a, b map[string]string) int {
+		return strings.Compare(a["slot"], b["slot"])
+	})
+	abilities := make(map[string][]string)
+	for _, row := range pokemonRows {
+		abilities[row["pokemon_id"]] = append(abilities[row["pokemon_id"]], names[row["ability_id"]])
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var database PokemonDatabase
+	if err := json.Unmarshal(raw, &database); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for i := range database.Pokemon {
+		entry := &database.Pokemon[i]
+		entry.Ability = chooseAbility(abilities[fmt.Sprint(entry.ID)], entry.Types)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(database)
+}
+
 func main() {
+	abilitiesDump := flag.String("abilities", "", "import abilities into the existing data file from a local PokeAPI CSV dump (data/v2/csv) instead of fetching everything")
+	flag.Parse()
+
+	if *abilitiesDump != "" {
+		path := filepath.Join("internal/pokemon/data", "pokemon_data.json")
+		if err := importAbilities(path, *abilitiesDump); err != nil {
+			log.Fatalf("Failed to import abilities: %v", err)
+		}
+		log.Printf("✅ Imported abilities into %s", path)
+		return
+	}
+
 	log.Println("Starting Pokemon data generation...")
 	log.Println("Fetching Gen 1-5 Pokemon (IDs 1-649)")
 	